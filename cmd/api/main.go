@@ -2,40 +2,62 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"product_review_hub/internal/config"
+	"product_review_hub/internal/logging"
 	"product_review_hub/internal/server"
+	"product_review_hub/internal/tracing"
 )
 
 func main() {
 	cfg := config.New()
 
+	if err := logging.Setup(cfg.Log, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, "product-review-hub-api", os.Stdout)
+	if err != nil {
+		slog.Error("Failed to configure tracing", logging.Error(err))
+		os.Exit(1)
+	}
+
 	srv := server.New(cfg)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		log.Printf("Starting server on %s", cfg.ServerAddress)
-		if err := srv.Start(); err != nil {
-			log.Fatalf("Server failed to start: %v", err)
+		slog.Info("Starting server", slog.String("address", cfg.ServerAddress))
+		if err := srv.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Server failed to start", logging.Error(err))
+			os.Exit(1)
 		}
 	}()
 
 	<-quit
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		slog.Error("Server forced to shutdown", logging.Error(err))
+		os.Exit(1)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Warn("Failed to flush traces", logging.Error(err))
 	}
 
-	log.Println("Server stopped")
+	slog.Info("Server stopped")
 }
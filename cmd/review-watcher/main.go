@@ -1,21 +1,46 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"product_review_hub/internal/config"
+	"product_review_hub/internal/health"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/metrics"
 	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/tracing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func main() {
-	log.Println("Starting Review Watcher service...")
-
 	cfg := config.New()
 
+	if err := logging.Setup(cfg.Log, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, "review-watcher", os.Stdout)
+	if err != nil {
+		slog.Error("Failed to configure tracing", logging.Error(err))
+		os.Exit(1)
+	}
+
+	slog.Info("Starting Review Watcher service")
+
 	// Connect to RabbitMQ
 	conn, err := rabbitmq.NewConnection(rabbitmq.Config{
 		Host:     cfg.RabbitMQ.Host,
@@ -24,7 +49,8 @@ func main() {
 		Password: cfg.RabbitMQ.Password,
 	})
 	if err != nil {
-		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
+		slog.Error("Failed to connect to RabbitMQ", logging.Error(err))
+		os.Exit(1)
 	}
 	defer conn.Close()
 
@@ -34,8 +60,41 @@ func main() {
 	// Start consuming messages
 	msgs, err := consumer.Consume()
 	if err != nil {
-		log.Fatalf("Failed to start consumer: %v", err)
+		slog.Error("Failed to start consumer", logging.Error(err))
+		os.Exit(1)
+	}
+
+	// The consumer stops for good when the broker closes its channel
+	var consuming atomic.Bool
+	consuming.Store(true)
+
+	// Serve metrics and health probes on their own port
+	critical := health.ParseCritical(cfg.Health.Critical)
+	checker := health.NewChecker(cfg.Health.CacheTTL, cfg.Health.Timeout,
+		health.Check{Name: "rabbitmq", Critical: critical["rabbitmq"], Check: conn.Check},
+		health.Check{Name: "consumer", Critical: true, Check: func(context.Context) error {
+			if !consuming.Load() {
+				return errors.New("consumer stopped")
+			}
+			return nil
+		}},
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/livez", health.LiveHandler())
+	mux.Handle("/readyz", health.ReadyHandler(checker))
+	metricsServer := &http.Server{
+		Addr:              cfg.WatcherMetricsAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
 	}
+	go func() {
+		slog.Info("Serving metrics and health probes", slog.String("address", cfg.WatcherMetricsAddress))
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Failed to serve metrics and health probes", logging.Error(err))
+		}
+	}()
 
 	// Handle graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -44,27 +103,74 @@ func main() {
 	// Process messages
 	go func() {
 		for msg := range msgs {
-			var event rabbitmq.ReviewEvent
-			if err := json.Unmarshal(msg.Body, &event); err != nil {
-				log.Printf("Error unmarshaling message: %v", err)
-				continue
-			}
-
-			// Log the event
-			log.Printf("=== Review Event Received ===")
-			log.Printf("  Event Type: %s", event.EventType)
-			log.Printf("  Timestamp:  %s", event.Timestamp.Format("2006-01-02 15:04:05"))
-			log.Printf("  Review ID:  %s", event.Data.ReviewID)
-			log.Printf("  Product ID: %s", event.Data.ProductID)
-			if event.Data.Rating > 0 {
-				log.Printf("  Rating:     %d", event.Data.Rating)
-			}
-			log.Printf("=============================")
+			handleMessage(msg)
 		}
+		consuming.Store(false)
+		slog.Error("Consumer stopped")
 	}()
 
-	log.Println("Review Watcher is running. Press Ctrl+C to exit.")
+	slog.Info("Review Watcher is running")
 	<-quit
 
-	log.Println("Shutting down Review Watcher...")
+	slog.Info("Shutting down Review Watcher")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		slog.Warn("Failed to shut down metrics server", logging.Error(err))
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Warn("Failed to flush traces", logging.Error(err))
+	}
+}
+
+// handleMessage logs a review event in a consumer span that continues the trace of the
+// publisher, and records the event's lag and processing time.
+func handleMessage(msg amqp.Delivery) {
+	start := time.Now()
+
+	ctx := rabbitmq.ExtractTraceContext(context.Background(), msg)
+	ctx, span := tracing.StartKind(ctx, msg.RoutingKey+" process", trace.SpanKindConsumer,
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingOperationTypeProcess,
+		semconv.MessagingDestinationName(msg.Exchange),
+		semconv.MessagingRabbitMQDestinationRoutingKey(msg.RoutingKey),
+	)
+	defer span.End()
+
+	var event rabbitmq.ReviewEvent
+	if err := json.Unmarshal(msg.Body, &event); err != nil {
+		slog.WarnContext(ctx, "Failed to unmarshal message", logging.Error(err), slog.String("routing_key", msg.RoutingKey))
+		metrics.EventsConsumed.WithLabelValues(msg.RoutingKey, metrics.ResultFailure).Inc()
+		tracing.RecordError(span, err)
+		return
+	}
+
+	eventType := string(event.EventType)
+	if !event.Timestamp.IsZero() {
+		metrics.EventLag.WithLabelValues(eventType).Observe(start.Sub(event.Timestamp).Seconds())
+	}
+
+	// Fall back to the AMQP correlation ID of publishers that do not fill in the metadata
+	requestID := event.Metadata.RequestID
+	if requestID == "" {
+		requestID = msg.CorrelationId
+	}
+
+	// Log the event
+	attrs := []any{
+		slog.String("event_type", eventType),
+		slog.Time("timestamp", event.Timestamp),
+		slog.String("request_id", requestID),
+		slog.String("tenant_id", event.TenantID),
+		slog.String("review_id", event.Data.ReviewID),
+		slog.String("product_id", event.Data.ProductID),
+	}
+	if event.Data.Rating > 0 {
+		attrs = append(attrs, slog.Int("rating", event.Data.Rating))
+	}
+	slog.InfoContext(ctx, "Review event received", attrs...)
+
+	metrics.EventsConsumed.WithLabelValues(eventType, metrics.ResultSuccess).Inc()
+	metrics.EventProcessingDuration.WithLabelValues(eventType).Observe(time.Since(start).Seconds())
 }
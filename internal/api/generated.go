@@ -6,18 +6,199 @@ package api
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
+
+	"product_review_hub/internal/money"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for APIKeyScope.
+const (
+	ProductsRead  APIKeyScope = "products:read"
+	ProductsWrite APIKeyScope = "products:write"
+	ReviewsRead   APIKeyScope = "reviews:read"
+	ReviewsWrite  APIKeyScope = "reviews:write"
+	StockWrite    APIKeyScope = "stock:write"
 )
 
+// APIKey defines model for APIKey.
+type APIKey struct {
+	// CreatedAt Time the key was issued
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt Time the key stops working, if any
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// Id Unique identifier for the API key
+	Id string `json:"id"`
+
+	// LastUsedAt Approximate time the key was last used, recorded at most once a minute
+	LastUsedAt *time.Time `json:"last_used_at"`
+
+	// Name Name identifying the client the key was issued to
+	Name string `json:"name"`
+
+	// Prefix Leading characters of the key, for recognising it
+	Prefix string `json:"prefix"`
+
+	// RevokedAt Time the key was revoked, if it was
+	RevokedAt *time.Time `json:"revoked_at"`
+
+	// Scopes Operations the key may call
+	Scopes []APIKeyScope `json:"scopes"`
+
+	// UserId ID of the user the key acts as
+	UserId string `json:"user_id"`
+}
+
+// APIKeyCreate defines model for APIKeyCreate.
+type APIKeyCreate struct {
+	// ExpiresAt Time the key stops working; must be in the future. Keys without it do not expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Name Name identifying the client the key is issued to
+	Name string `json:"name"`
+
+	// Scopes Operations the key may call
+	Scopes []APIKeyScope `json:"scopes"`
+
+	// UserId ID of the user the key acts as
+	UserId string `json:"user_id"`
+}
+
+// APIKeyIssued defines model for APIKeyIssued.
+type APIKeyIssued struct {
+	// CreatedAt Time the key was issued
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt Time the key stops working, if any
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// Id Unique identifier for the API key
+	Id string `json:"id"`
+
+	// Key The plaintext key. It is shown only once and cannot be recovered.
+	Key string `json:"key"`
+
+	// LastUsedAt Approximate time the key was last used, recorded at most once a minute
+	LastUsedAt *time.Time `json:"last_used_at"`
+
+	// Name Name identifying the client the key was issued to
+	Name string `json:"name"`
+
+	// Prefix Leading characters of the key, for recognising it
+	Prefix string `json:"prefix"`
+
+	// RevokedAt Time the key was revoked, if it was
+	RevokedAt *time.Time `json:"revoked_at"`
+
+	// Scopes Operations the key may call
+	Scopes []APIKeyScope `json:"scopes"`
+
+	// UserId ID of the user the key acts as
+	UserId string `json:"user_id"`
+}
+
+// APIKeyRotate defines model for APIKeyRotate.
+type APIKeyRotate struct {
+	// GracePeriodSeconds How long the old key keeps working, in seconds (at most 7 days)
+	GracePeriodSeconds *int `json:"grace_period_seconds,omitempty"`
+}
+
+// APIKeyScope Permission granted to an API key. `products:read` and `reviews:read` apply to the public
+// read endpoints, `products:write` to catalog changes, `stock:write` to stock changes and
+// `reviews:write` to the reviews and review photos of the key's user.
+type APIKeyScope string
+
+// AttributeFacet defines model for AttributeFacet.
+type AttributeFacet struct {
+	// Key Attribute key
+	Key    string       `json:"key"`
+	Values []FacetCount `json:"values"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	// AverageRating Average rating of all reviews for products in the category and all of its descendants
+	AverageRating *float32 `json:"average_rating"`
+
+	// Description Detailed description of the category
+	Description *string `json:"description"`
+
+	// Id Unique identifier for the category
+	Id string `json:"id"`
+
+	// Name Name of the category
+	Name string `json:"name"`
+
+	// ParentId ID of the parent category, null for root categories
+	ParentId *string `json:"parent_id"`
+
+	// ProductCount Number of distinct products in the category and all of its descendants
+	ProductCount int `json:"product_count"`
+
+	// ReviewCount Number of reviews for products in the category and all of its descendants
+	ReviewCount int `json:"review_count"`
+}
+
+// CategoryCreate defines model for CategoryCreate.
+type CategoryCreate struct {
+	// Description Detailed description of the category
+	Description *string `json:"description,omitempty"`
+
+	// Name Name of the category
+	Name string `json:"name"`
+
+	// ParentId ID of the parent category, omit to create a root category
+	ParentId *string `json:"parent_id,omitempty"`
+}
+
+// CategoryUpdate defines model for CategoryUpdate.
+type CategoryUpdate struct {
+	// Description Detailed description of the category
+	Description *string `json:"description,omitempty"`
+
+	// Name Name of the category
+	Name string `json:"name"`
+
+	// ParentId ID of the parent category, omit to make the category a root category
+	ParentId *string `json:"parent_id,omitempty"`
+}
+
+// CurrencyCode ISO 4217 currency code. Products created without a currency use USD.
+type CurrencyCode = string
+
+// CurrencyPrice defines model for CurrencyPrice.
+type CurrencyPrice struct {
+	// Currency ISO 4217 currency code. Products created without a currency use USD.
+	Currency CurrencyCode `json:"currency"`
+
+	// Price Price of the product in the currency
+	Price money.Amount `json:"price"`
+}
+
+// CurrencyPriceUpdate defines model for CurrencyPriceUpdate.
+type CurrencyPriceUpdate struct {
+	// Price Price of the product in the currency (must be greater than 0 and at most 99999999.99)
+	Price money.Amount `json:"price"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Details List of validation errors (if applicable)
@@ -27,51 +208,294 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// FacetCount defines model for FacetCount.
+type FacetCount struct {
+	// Count Number of matching products with this value
+	Count int `json:"count"`
+
+	// Value Tag or attribute value
+	Value string `json:"value"`
+}
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	Status string `json:"status"`
 }
 
+// PriceHistoryEntry defines model for PriceHistoryEntry.
+type PriceHistoryEntry struct {
+	// Currency ISO 4217 currency code. Products created without a currency use USD.
+	Currency CurrencyCode `json:"currency"`
+
+	// EffectiveFrom Time the price takes effect
+	EffectiveFrom time.Time `json:"effective_from"`
+
+	// EffectiveTo Time the price stops being in effect, null if it stays until the next change
+	EffectiveTo *time.Time `json:"effective_to"`
+
+	// Id Unique identifier for the price change
+	Id string `json:"id"`
+
+	// IsCurrent Whether this is the price currently in effect
+	IsCurrent bool `json:"is_current"`
+
+	// Price Price of the product in its currency while the change is in effect
+	Price money.Amount `json:"price"`
+
+	// ProductId ID of the product
+	ProductId string `json:"product_id"`
+}
+
+// Problem RFC 9457 problem details
+type Problem struct {
+	// Detail Explanation specific to this occurrence
+	Detail string `json:"detail"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI identifying the problem type
+	Type string `json:"type"`
+}
+
 // Product defines model for Product.
 type Product struct {
+	// Attributes Typed key/value attributes of the product. Values must be strings, numbers or booleans.
+	Attributes ProductAttributes `json:"attributes"`
+
 	// AverageRating Average rating of the product based on all reviews
 	AverageRating *float32 `json:"average_rating"`
 
+	// Currency ISO 4217 currency code. Products created without a currency use USD.
+	Currency CurrencyCode `json:"currency"`
+
 	// Description Detailed description of the product
 	Description string `json:"description"`
 
 	// Id Unique identifier for the product
 	Id string `json:"id"`
 
+	// Images Images of the product in display order
+	Images []ProductImage `json:"images"`
+
+	// InStock Whether the product has stock that is not reserved
+	InStock bool `json:"in_stock"`
+
 	// Name Name of the product
 	Name string `json:"name"`
 
-	// Price Price of the product in USD
-	Price float32 `json:"price"`
+	// Price Price of the product in the currency given by currency
+	Price money.Amount `json:"price"`
+
+	// Tags Free-form tags of the product
+	Tags ProductTags `json:"tags"`
+
+	// Variants Variants of the product
+	Variants []ProductVariant `json:"variants"`
+}
+
+// ProductAttributes Typed key/value attributes of the product. Values must be strings, numbers or booleans.
+type ProductAttributes map[string]interface{}
+
+// ProductCategoriesUpdate defines model for ProductCategoriesUpdate.
+type ProductCategoriesUpdate struct {
+	// CategoryIds IDs of the categories the product belongs to
+	CategoryIds []string `json:"category_ids"`
 }
 
 // ProductCreate defines model for ProductCreate.
 type ProductCreate struct {
+	// Attributes Typed key/value attributes of the product. Values must be strings, numbers or booleans.
+	Attributes *ProductAttributes `json:"attributes,omitempty"`
+
+	// Currency ISO 4217 currency code. Products created without a currency use USD.
+	Currency *CurrencyCode `json:"currency,omitempty"`
+
 	// Description Detailed description of the product
 	Description string `json:"description"`
 
 	// Name Name of the product
 	Name string `json:"name"`
 
-	// Price Price of the product in USD (must be greater than 0)
-	Price float32 `json:"price"`
+	// Price Price of the product in its currency (must be greater than 0 and at most 99999999.99)
+	Price money.Amount `json:"price"`
+
+	// Tags Free-form tags of the product
+	Tags *ProductTags `json:"tags,omitempty"`
+}
+
+// ProductFacets defines model for ProductFacets.
+type ProductFacets struct {
+	Attributes []AttributeFacet `json:"attributes"`
+	Tags       []FacetCount     `json:"tags"`
+}
+
+// ProductImage defines model for ProductImage.
+type ProductImage struct {
+	// AltText Alternative text of the image
+	AltText *string `json:"alt_text"`
+
+	// ContentType MIME type of the image
+	ContentType string `json:"content_type"`
+
+	// CreatedAt Upload time
+	CreatedAt time.Time `json:"created_at"`
+
+	// Height Height of the image in pixels
+	Height int `json:"height"`
+
+	// Id Unique identifier for the image
+	Id string `json:"id"`
+
+	// Position Display position of the image, starting at 1
+	Position int `json:"position"`
+
+	// ProductId ID of the product
+	ProductId string `json:"product_id"`
+
+	// Size Size of the image in bytes
+	Size int64 `json:"size"`
+
+	// ThumbnailUrl URL of a thumbnail that fits within 320x320 pixels
+	ThumbnailUrl string `json:"thumbnail_url"`
+
+	// Url URL of the image
+	Url string `json:"url"`
+
+	// Width Width of the image in pixels
+	Width int `json:"width"`
+}
+
+// ProductImageOrder defines model for ProductImageOrder.
+type ProductImageOrder struct {
+	// ImageIds IDs of all images of the product in the new display order
+	ImageIds []string `json:"image_ids"`
+}
+
+// ProductImageUpload defines model for ProductImageUpload.
+type ProductImageUpload struct {
+	// AltText Alternative text of the image (at most 255 characters)
+	AltText *string `json:"alt_text,omitempty"`
+
+	// File JPEG, PNG or GIF image of at most 5 MB
+	File openapi_types.File `json:"file"`
+}
+
+// ProductStock defines model for ProductStock.
+type ProductStock struct {
+	// Available Units that can still be reserved or sold (quantity minus reserved)
+	Available int `json:"available"`
+
+	// LowStock Whether the available quantity has reached the low-stock threshold
+	LowStock bool `json:"low_stock"`
+
+	// LowStockThreshold Available quantity at or below which stock is low (0 disables the check)
+	LowStockThreshold int `json:"low_stock_threshold"`
+
+	// ProductId ID of the product
+	ProductId string `json:"product_id"`
+
+	// Quantity Quantity on hand, including reserved units
+	Quantity int `json:"quantity"`
+
+	// Reserved Units held for pending orders
+	Reserved int `json:"reserved"`
+
+	// UpdatedAt Time of the last stock change
+	UpdatedAt *time.Time `json:"updated_at"`
 }
 
+// ProductTags Free-form tags of the product
+type ProductTags = []string
+
 // ProductUpdate defines model for ProductUpdate.
 type ProductUpdate struct {
+	// Attributes Typed key/value attributes of the product. Values must be strings, numbers or booleans.
+	Attributes *ProductAttributes `json:"attributes,omitempty"`
+
+	// Currency ISO 4217 currency code. Products created without a currency use USD.
+	Currency *CurrencyCode `json:"currency,omitempty"`
+
 	// Description Detailed description of the product
 	Description string `json:"description"`
 
 	// Name Name of the product
 	Name string `json:"name"`
 
-	// Price Price of the product in USD (must be greater than 0)
-	Price float32 `json:"price"`
+	// Price Price of the product in its currency (must be greater than 0 and at most 99999999.99)
+	Price money.Amount `json:"price"`
+
+	// Tags Free-form tags of the product
+	Tags *ProductTags `json:"tags,omitempty"`
+}
+
+// ProductVariant defines model for ProductVariant.
+type ProductVariant struct {
+	// Active Whether the variant is available for sale
+	Active bool `json:"active"`
+
+	// Id Unique identifier for the variant
+	Id string `json:"id"`
+
+	// Options Option values distinguishing the variant, e.g. size and color
+	Options VariantOptions `json:"options"`
+
+	// Price Price override for the variant, null to use the product price
+	Price *money.Amount `json:"price"`
+
+	// ProductId ID of the parent product
+	ProductId string `json:"product_id"`
+
+	// Sku Stock keeping unit, unique across all variants of the tenant
+	Sku string `json:"sku"`
+}
+
+// ProductVariantCreate defines model for ProductVariantCreate.
+type ProductVariantCreate struct {
+	// Active Whether the variant is available for sale, defaults to true
+	Active *bool `json:"active,omitempty"`
+
+	// Options Option values distinguishing the variant, e.g. size and color
+	Options *VariantOptions `json:"options,omitempty"`
+
+	// Price Price override for the variant (must be greater than 0 and at most 99999999.99)
+	Price *money.Amount `json:"price,omitempty"`
+
+	// Sku Stock keeping unit, unique across all variants of the tenant
+	Sku string `json:"sku"`
+}
+
+// ProductVariantUpdate defines model for ProductVariantUpdate.
+type ProductVariantUpdate struct {
+	// Active Whether the variant is available for sale
+	Active bool `json:"active"`
+
+	// Options Option values distinguishing the variant, e.g. size and color
+	Options *VariantOptions `json:"options,omitempty"`
+
+	// Price Price override for the variant (must be greater than 0 and at most 99999999.99), omit to use the product price
+	Price *money.Amount `json:"price,omitempty"`
+
+	// Sku Stock keeping unit, unique across all variants of the tenant
+	Sku string `json:"sku"`
+}
+
+// PublicUser Public profile of a user
+type PublicUser struct {
+	// CreatedAt Registration time
+	CreatedAt time.Time `json:"created_at"`
+
+	// FirstName First name of the user
+	FirstName string `json:"first_name"`
+
+	// Id Unique identifier for the user
+	Id string `json:"id"`
+
+	// LastName Last name of the user
+	LastName string `json:"last_name"`
 }
 
 // Review defines model for Review.
@@ -88,11 +512,20 @@ type Review struct {
 	// LastName Last name of the review author
 	LastName *string `json:"last_name"`
 
+	// Photos Photos attached to the review in upload order
+	Photos []ReviewPhoto `json:"photos"`
+
 	// ProductId ID of the product being reviewed
 	ProductId string `json:"product_id"`
 
 	// Rating Rating given to the product (1-5 stars)
 	Rating int `json:"rating"`
+
+	// UserId ID of the author, null for reviews written before user accounts existed
+	UserId *string `json:"user_id"`
+
+	// VariantId ID of the reviewed product variant, null if the review is for the product as a whole
+	VariantId *string `json:"variant_id"`
 }
 
 // ReviewCreate defines model for ReviewCreate.
@@ -100,14 +533,47 @@ type ReviewCreate struct {
 	// Comment Optional text comment for the review
 	Comment *string `json:"comment,omitempty"`
 
-	// FirstName First name of the review author
-	FirstName *string `json:"first_name,omitempty"`
-
-	// LastName Last name of the review author
-	LastName *string `json:"last_name,omitempty"`
-
 	// Rating Rating given to the product (1-5 stars)
 	Rating int `json:"rating"`
+
+	// VariantId Optional ID of the reviewed product variant
+	VariantId *string `json:"variant_id,omitempty"`
+}
+
+// ReviewPhoto defines model for ReviewPhoto.
+type ReviewPhoto struct {
+	// ContentType MIME type of the photo
+	ContentType string `json:"content_type"`
+
+	// CreatedAt Upload time
+	CreatedAt time.Time `json:"created_at"`
+
+	// Height Height of the photo in pixels
+	Height int `json:"height"`
+
+	// Id Unique identifier for the photo
+	Id string `json:"id"`
+
+	// ReviewId ID of the review
+	ReviewId string `json:"review_id"`
+
+	// Size Size of the photo in bytes
+	Size int64 `json:"size"`
+
+	// ThumbnailUrl URL of a thumbnail that fits within 320x320 pixels
+	ThumbnailUrl string `json:"thumbnail_url"`
+
+	// Url URL of the photo
+	Url string `json:"url"`
+
+	// Width Width of the photo in pixels
+	Width int `json:"width"`
+}
+
+// ReviewPhotoUpload defines model for ReviewPhotoUpload.
+type ReviewPhotoUpload struct {
+	// File JPEG, PNG or GIF photo of at most 5 MB
+	File openapi_types.File `json:"file"`
 }
 
 // ReviewUpdate defines model for ReviewUpdate.
@@ -115,14 +581,71 @@ type ReviewUpdate struct {
 	// Comment Optional text comment for the review
 	Comment *string `json:"comment,omitempty"`
 
-	// FirstName First name of the review author
-	FirstName *string `json:"first_name,omitempty"`
-
-	// LastName Last name of the review author
-	LastName *string `json:"last_name,omitempty"`
-
 	// Rating Rating given to the product (1-5 stars)
 	Rating int `json:"rating"`
+
+	// VariantId Optional ID of the reviewed product variant
+	VariantId *string `json:"variant_id,omitempty"`
+}
+
+// ScheduledPriceCreate defines model for ScheduledPriceCreate.
+type ScheduledPriceCreate struct {
+	// EffectiveFrom Time the price takes effect
+	EffectiveFrom time.Time `json:"effective_from"`
+
+	// EffectiveTo Time the price stops being in effect (must be after effective_from and in the future)
+	EffectiveTo *time.Time `json:"effective_to,omitempty"`
+
+	// Price Scheduled price in the product currency (must be greater than 0 and at most 99999999.99)
+	Price money.Amount `json:"price"`
+}
+
+// StockAdjustment defines model for StockAdjustment.
+type StockAdjustment struct {
+	// Delta Change of the quantity on hand (must not be 0)
+	Delta int `json:"delta"`
+}
+
+// StockQuantity defines model for StockQuantity.
+type StockQuantity struct {
+	// Quantity Number of units (must be greater than 0)
+	Quantity int `json:"quantity"`
+}
+
+// StockSettings defines model for StockSettings.
+type StockSettings struct {
+	// LowStockThreshold Available quantity at or below which stock is low (0 disables the check)
+	LowStockThreshold int `json:"low_stock_threshold"`
+}
+
+// User defines model for User.
+type User struct {
+	// CreatedAt Registration time
+	CreatedAt time.Time `json:"created_at"`
+
+	// Email Email address of the user (lowercase)
+	Email string `json:"email"`
+
+	// FirstName First name of the user
+	FirstName string `json:"first_name"`
+
+	// Id Unique identifier for the user
+	Id string `json:"id"`
+
+	// LastName Last name of the user
+	LastName string `json:"last_name"`
+}
+
+// UserCreate defines model for UserCreate.
+type UserCreate struct {
+	// Email Email address of the user
+	Email string `json:"email"`
+
+	// FirstName First name of the user
+	FirstName string `json:"first_name"`
+
+	// LastName Last name of the user
+	LastName string `json:"last_name"`
 }
 
 // ValidationError defines model for ValidationError.
@@ -134,6 +657,36 @@ type ValidationError struct {
 	Message string `json:"message"`
 }
 
+// VariantOptions Option values distinguishing the variant, e.g. size and color
+type VariantOptions map[string]string
+
+// CategoryFilter defines model for CategoryFilter.
+type CategoryFilter = string
+
+// CurrencyParam ISO 4217 currency code. Products created without a currency use USD.
+type CurrencyParam = CurrencyCode
+
+// TagFilter defines model for TagFilter.
+type TagFilter = []string
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// TooManyRequests RFC 9457 problem details
+type TooManyRequests = Problem
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// GetCategoriesParams defines parameters for GetCategories.
+type GetCategoriesParams struct {
+	// Limit Maximum number of categories to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of categories to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // GetProductsParams defines parameters for GetProducts.
 type GetProductsParams struct {
 	// Limit Maximum number of products to return
@@ -141,6 +694,44 @@ type GetProductsParams struct {
 
 	// Offset Number of products to skip
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Category Only return products assigned to this category or any of its descendants
+	Category *CategoryFilter `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Only return products carrying all of the given tags
+	Tag *TagFilter `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Currency Currency to return prices in. Uses the product's price entry for the currency when
+	// one exists, otherwise converts from the product currency using the exchange-rate table.
+	Currency *CurrencyParam `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// InStock Only return products with (true) or without (false) stock that is not reserved
+	InStock *bool `form:"in_stock,omitempty" json:"in_stock,omitempty"`
+}
+
+// GetProductFacetsParams defines parameters for GetProductFacets.
+type GetProductFacetsParams struct {
+	// Category Only return products assigned to this category or any of its descendants
+	Category *CategoryFilter `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Only return products carrying all of the given tags
+	Tag *TagFilter `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// GetProductByIdParams defines parameters for GetProductById.
+type GetProductByIdParams struct {
+	// Currency Currency to return prices in. Uses the product's price entry for the currency when
+	// one exists, otherwise converts from the product currency using the exchange-rate table.
+	Currency *CurrencyParam `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// GetProductPriceHistoryParams defines parameters for GetProductPriceHistory.
+type GetProductPriceHistoryParams struct {
+	// Limit Maximum number of entries to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of entries to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
 // GetProductReviewsParams defines parameters for GetProductReviews.
@@ -150,37 +741,160 @@ type GetProductReviewsParams struct {
 
 	// Offset Number of reviews to skip
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// HasMedia Only return reviews with (true) or without (false) photos
+	HasMedia *bool `form:"has_media,omitempty" json:"has_media,omitempty"`
+}
+
+// GetUserReviewsParams defines parameters for GetUserReviews.
+type GetUserReviewsParams struct {
+	// Limit Maximum number of reviews to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of reviews to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = APIKeyCreate
+
+// RotateAPIKeyJSONRequestBody defines body for RotateAPIKey for application/json ContentType.
+type RotateAPIKeyJSONRequestBody = APIKeyRotate
+
+// CreateCategoryJSONRequestBody defines body for CreateCategory for application/json ContentType.
+type CreateCategoryJSONRequestBody = CategoryCreate
+
+// UpdateCategoryJSONRequestBody defines body for UpdateCategory for application/json ContentType.
+type UpdateCategoryJSONRequestBody = CategoryUpdate
+
 // CreateProductJSONRequestBody defines body for CreateProduct for application/json ContentType.
 type CreateProductJSONRequestBody = ProductCreate
 
 // UpdateProductJSONRequestBody defines body for UpdateProduct for application/json ContentType.
 type UpdateProductJSONRequestBody = ProductUpdate
 
+// UpdateProductCategoriesJSONRequestBody defines body for UpdateProductCategories for application/json ContentType.
+type UpdateProductCategoriesJSONRequestBody = ProductCategoriesUpdate
+
+// CreateProductImageMultipartRequestBody defines body for CreateProductImage for multipart/form-data ContentType.
+type CreateProductImageMultipartRequestBody = ProductImageUpload
+
+// ReorderProductImagesJSONRequestBody defines body for ReorderProductImages for application/json ContentType.
+type ReorderProductImagesJSONRequestBody = ProductImageOrder
+
+// SetProductCurrencyPriceJSONRequestBody defines body for SetProductCurrencyPrice for application/json ContentType.
+type SetProductCurrencyPriceJSONRequestBody = CurrencyPriceUpdate
+
 // CreateProductReviewJSONRequestBody defines body for CreateProductReview for application/json ContentType.
 type CreateProductReviewJSONRequestBody = ReviewCreate
 
 // UpdateProductReviewJSONRequestBody defines body for UpdateProductReview for application/json ContentType.
 type UpdateProductReviewJSONRequestBody = ReviewUpdate
 
+// CreateReviewPhotoMultipartRequestBody defines body for CreateReviewPhoto for multipart/form-data ContentType.
+type CreateReviewPhotoMultipartRequestBody = ReviewPhotoUpload
+
+// CreateScheduledPriceJSONRequestBody defines body for CreateScheduledPrice for application/json ContentType.
+type CreateScheduledPriceJSONRequestBody = ScheduledPriceCreate
+
+// UpdateProductStockJSONRequestBody defines body for UpdateProductStock for application/json ContentType.
+type UpdateProductStockJSONRequestBody = StockSettings
+
+// AdjustProductStockJSONRequestBody defines body for AdjustProductStock for application/json ContentType.
+type AdjustProductStockJSONRequestBody = StockAdjustment
+
+// ReleaseProductStockJSONRequestBody defines body for ReleaseProductStock for application/json ContentType.
+type ReleaseProductStockJSONRequestBody = StockQuantity
+
+// ReserveProductStockJSONRequestBody defines body for ReserveProductStock for application/json ContentType.
+type ReserveProductStockJSONRequestBody = StockQuantity
+
+// CreateProductVariantJSONRequestBody defines body for CreateProductVariant for application/json ContentType.
+type CreateProductVariantJSONRequestBody = ProductVariantCreate
+
+// UpdateProductVariantJSONRequestBody defines body for UpdateProductVariant for application/json ContentType.
+type UpdateProductVariantJSONRequestBody = ProductVariantUpdate
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = UserCreate
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List API keys
+	// (GET /api/v1/api-keys)
+	ListAPIKeys(w http.ResponseWriter, r *http.Request)
+	// Issue an API key
+	// (POST /api/v1/api-keys)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key
+	// (DELETE /api/v1/api-keys/{keyId})
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request, keyId string)
+	// Rotate an API key
+	// (POST /api/v1/api-keys/{keyId}/rotate)
+	RotateAPIKey(w http.ResponseWriter, r *http.Request, keyId string)
+	// Get list of categories
+	// (GET /api/v1/categories)
+	GetCategories(w http.ResponseWriter, r *http.Request, params GetCategoriesParams)
+	// Create a new category
+	// (POST /api/v1/categories)
+	CreateCategory(w http.ResponseWriter, r *http.Request)
+	// Delete category
+	// (DELETE /api/v1/categories/{categoryId})
+	DeleteCategory(w http.ResponseWriter, r *http.Request, categoryId string)
+	// Get category by ID
+	// (GET /api/v1/categories/{categoryId})
+	GetCategoryById(w http.ResponseWriter, r *http.Request, categoryId string)
+	// Update category
+	// (PUT /api/v1/categories/{categoryId})
+	UpdateCategory(w http.ResponseWriter, r *http.Request, categoryId string)
 	// Get list of products
 	// (GET /api/v1/products)
 	GetProducts(w http.ResponseWriter, r *http.Request, params GetProductsParams)
 	// Create a new product
 	// (POST /api/v1/products)
 	CreateProduct(w http.ResponseWriter, r *http.Request)
+	// Get product facets
+	// (GET /api/v1/products/facets)
+	GetProductFacets(w http.ResponseWriter, r *http.Request, params GetProductFacetsParams)
 	// Delete product
 	// (DELETE /api/v1/products/{productId})
 	DeleteProduct(w http.ResponseWriter, r *http.Request, productId string)
 	// Get product by ID
 	// (GET /api/v1/products/{productId})
-	GetProductById(w http.ResponseWriter, r *http.Request, productId string)
+	GetProductById(w http.ResponseWriter, r *http.Request, productId string, params GetProductByIdParams)
 	// Update product
 	// (PUT /api/v1/products/{productId})
 	UpdateProduct(w http.ResponseWriter, r *http.Request, productId string)
+	// Get categories of a product
+	// (GET /api/v1/products/{productId}/categories)
+	GetProductCategories(w http.ResponseWriter, r *http.Request, productId string)
+	// Set categories of a product
+	// (PUT /api/v1/products/{productId}/categories)
+	UpdateProductCategories(w http.ResponseWriter, r *http.Request, productId string)
+	// Get product images
+	// (GET /api/v1/products/{productId}/images)
+	GetProductImages(w http.ResponseWriter, r *http.Request, productId string)
+	// Upload a product image
+	// (POST /api/v1/products/{productId}/images)
+	CreateProductImage(w http.ResponseWriter, r *http.Request, productId string)
+	// Reorder product images
+	// (PUT /api/v1/products/{productId}/images/order)
+	ReorderProductImages(w http.ResponseWriter, r *http.Request, productId string)
+	// Delete a product image
+	// (DELETE /api/v1/products/{productId}/images/{imageId})
+	DeleteProductImage(w http.ResponseWriter, r *http.Request, productId string, imageId string)
+	// Get product price history
+	// (GET /api/v1/products/{productId}/price-history)
+	GetProductPriceHistory(w http.ResponseWriter, r *http.Request, productId string, params GetProductPriceHistoryParams)
+	// Get product currency prices
+	// (GET /api/v1/products/{productId}/prices)
+	GetProductCurrencyPrices(w http.ResponseWriter, r *http.Request, productId string)
+	// Remove product price in a currency
+	// (DELETE /api/v1/products/{productId}/prices/{currency})
+	DeleteProductCurrencyPrice(w http.ResponseWriter, r *http.Request, productId string, currency CurrencyCode)
+	// Set product price in a currency
+	// (PUT /api/v1/products/{productId}/prices/{currency})
+	SetProductCurrencyPrice(w http.ResponseWriter, r *http.Request, productId string, currency CurrencyCode)
 	// Get reviews for a product
 	// (GET /api/v1/products/{productId}/reviews)
 	GetProductReviews(w http.ResponseWriter, r *http.Request, productId string, params GetProductReviewsParams)
@@ -193,6 +907,57 @@ type ServerInterface interface {
 	// Update a review
 	// (PUT /api/v1/products/{productId}/reviews/{reviewId})
 	UpdateProductReview(w http.ResponseWriter, r *http.Request, productId string, reviewId string)
+	// Attach a photo to a review
+	// (POST /api/v1/products/{productId}/reviews/{reviewId}/photos)
+	CreateReviewPhoto(w http.ResponseWriter, r *http.Request, productId string, reviewId string)
+	// Delete a review photo
+	// (DELETE /api/v1/products/{productId}/reviews/{reviewId}/photos/{photoId})
+	DeleteReviewPhoto(w http.ResponseWriter, r *http.Request, productId string, reviewId string, photoId string)
+	// Schedule a price change
+	// (POST /api/v1/products/{productId}/scheduled-prices)
+	CreateScheduledPrice(w http.ResponseWriter, r *http.Request, productId string)
+	// Cancel a scheduled price change
+	// (DELETE /api/v1/products/{productId}/scheduled-prices/{priceId})
+	DeleteScheduledPrice(w http.ResponseWriter, r *http.Request, productId string, priceId string)
+	// Get product stock
+	// (GET /api/v1/products/{productId}/stock)
+	GetProductStock(w http.ResponseWriter, r *http.Request, productId string)
+	// Update stock settings
+	// (PUT /api/v1/products/{productId}/stock)
+	UpdateProductStock(w http.ResponseWriter, r *http.Request, productId string)
+	// Adjust stock quantity
+	// (POST /api/v1/products/{productId}/stock/adjust)
+	AdjustProductStock(w http.ResponseWriter, r *http.Request, productId string)
+	// Release reserved stock
+	// (POST /api/v1/products/{productId}/stock/release)
+	ReleaseProductStock(w http.ResponseWriter, r *http.Request, productId string)
+	// Reserve stock
+	// (POST /api/v1/products/{productId}/stock/reserve)
+	ReserveProductStock(w http.ResponseWriter, r *http.Request, productId string)
+	// Get product variants
+	// (GET /api/v1/products/{productId}/variants)
+	GetProductVariants(w http.ResponseWriter, r *http.Request, productId string)
+	// Create a product variant
+	// (POST /api/v1/products/{productId}/variants)
+	CreateProductVariant(w http.ResponseWriter, r *http.Request, productId string)
+	// Delete a product variant
+	// (DELETE /api/v1/products/{productId}/variants/{variantId})
+	DeleteProductVariant(w http.ResponseWriter, r *http.Request, productId string, variantId string)
+	// Get product variant by ID
+	// (GET /api/v1/products/{productId}/variants/{variantId})
+	GetProductVariantById(w http.ResponseWriter, r *http.Request, productId string, variantId string)
+	// Update a product variant
+	// (PUT /api/v1/products/{productId}/variants/{variantId})
+	UpdateProductVariant(w http.ResponseWriter, r *http.Request, productId string, variantId string)
+	// Create a user
+	// (POST /api/v1/users)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Get a user
+	// (GET /api/v1/users/{userId})
+	GetUserById(w http.ResponseWriter, r *http.Request, userId string)
+	// Get reviews of a user
+	// (GET /api/v1/users/{userId}/reviews)
+	GetUserReviews(w http.ResponseWriter, r *http.Request, userId string, params GetUserReviewsParams)
 	// Health check endpoint
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
@@ -202,7 +967,61 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
-// Get list of products
+// List API keys
+// (GET /api/v1/api-keys)
+func (_ Unimplemented) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Issue an API key
+// (POST /api/v1/api-keys)
+func (_ Unimplemented) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key
+// (DELETE /api/v1/api-keys/{keyId})
+func (_ Unimplemented) RevokeAPIKey(w http.ResponseWriter, r *http.Request, keyId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate an API key
+// (POST /api/v1/api-keys/{keyId}/rotate)
+func (_ Unimplemented) RotateAPIKey(w http.ResponseWriter, r *http.Request, keyId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get list of categories
+// (GET /api/v1/categories)
+func (_ Unimplemented) GetCategories(w http.ResponseWriter, r *http.Request, params GetCategoriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new category
+// (POST /api/v1/categories)
+func (_ Unimplemented) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete category
+// (DELETE /api/v1/categories/{categoryId})
+func (_ Unimplemented) DeleteCategory(w http.ResponseWriter, r *http.Request, categoryId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get category by ID
+// (GET /api/v1/categories/{categoryId})
+func (_ Unimplemented) GetCategoryById(w http.ResponseWriter, r *http.Request, categoryId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update category
+// (PUT /api/v1/categories/{categoryId})
+func (_ Unimplemented) UpdateCategory(w http.ResponseWriter, r *http.Request, categoryId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get list of products
 // (GET /api/v1/products)
 func (_ Unimplemented) GetProducts(w http.ResponseWriter, r *http.Request, params GetProductsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -214,6 +1033,12 @@ func (_ Unimplemented) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get product facets
+// (GET /api/v1/products/facets)
+func (_ Unimplemented) GetProductFacets(w http.ResponseWriter, r *http.Request, params GetProductFacetsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete product
 // (DELETE /api/v1/products/{productId})
 func (_ Unimplemented) DeleteProduct(w http.ResponseWriter, r *http.Request, productId string) {
@@ -222,7 +1047,7 @@ func (_ Unimplemented) DeleteProduct(w http.ResponseWriter, r *http.Request, pro
 
 // Get product by ID
 // (GET /api/v1/products/{productId})
-func (_ Unimplemented) GetProductById(w http.ResponseWriter, r *http.Request, productId string) {
+func (_ Unimplemented) GetProductById(w http.ResponseWriter, r *http.Request, productId string, params GetProductByIdParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -232,6 +1057,66 @@ func (_ Unimplemented) UpdateProduct(w http.ResponseWriter, r *http.Request, pro
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get categories of a product
+// (GET /api/v1/products/{productId}/categories)
+func (_ Unimplemented) GetProductCategories(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set categories of a product
+// (PUT /api/v1/products/{productId}/categories)
+func (_ Unimplemented) UpdateProductCategories(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get product images
+// (GET /api/v1/products/{productId}/images)
+func (_ Unimplemented) GetProductImages(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload a product image
+// (POST /api/v1/products/{productId}/images)
+func (_ Unimplemented) CreateProductImage(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reorder product images
+// (PUT /api/v1/products/{productId}/images/order)
+func (_ Unimplemented) ReorderProductImages(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a product image
+// (DELETE /api/v1/products/{productId}/images/{imageId})
+func (_ Unimplemented) DeleteProductImage(w http.ResponseWriter, r *http.Request, productId string, imageId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get product price history
+// (GET /api/v1/products/{productId}/price-history)
+func (_ Unimplemented) GetProductPriceHistory(w http.ResponseWriter, r *http.Request, productId string, params GetProductPriceHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get product currency prices
+// (GET /api/v1/products/{productId}/prices)
+func (_ Unimplemented) GetProductCurrencyPrices(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove product price in a currency
+// (DELETE /api/v1/products/{productId}/prices/{currency})
+func (_ Unimplemented) DeleteProductCurrencyPrice(w http.ResponseWriter, r *http.Request, productId string, currency CurrencyCode) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set product price in a currency
+// (PUT /api/v1/products/{productId}/prices/{currency})
+func (_ Unimplemented) SetProductCurrencyPrice(w http.ResponseWriter, r *http.Request, productId string, currency CurrencyCode) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get reviews for a product
 // (GET /api/v1/products/{productId}/reviews)
 func (_ Unimplemented) GetProductReviews(w http.ResponseWriter, r *http.Request, productId string, params GetProductReviewsParams) {
@@ -256,47 +1141,1395 @@ func (_ Unimplemented) UpdateProductReview(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Attach a photo to a review
+// (POST /api/v1/products/{productId}/reviews/{reviewId}/photos)
+func (_ Unimplemented) CreateReviewPhoto(w http.ResponseWriter, r *http.Request, productId string, reviewId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a review photo
+// (DELETE /api/v1/products/{productId}/reviews/{reviewId}/photos/{photoId})
+func (_ Unimplemented) DeleteReviewPhoto(w http.ResponseWriter, r *http.Request, productId string, reviewId string, photoId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Schedule a price change
+// (POST /api/v1/products/{productId}/scheduled-prices)
+func (_ Unimplemented) CreateScheduledPrice(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a scheduled price change
+// (DELETE /api/v1/products/{productId}/scheduled-prices/{priceId})
+func (_ Unimplemented) DeleteScheduledPrice(w http.ResponseWriter, r *http.Request, productId string, priceId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get product stock
+// (GET /api/v1/products/{productId}/stock)
+func (_ Unimplemented) GetProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update stock settings
+// (PUT /api/v1/products/{productId}/stock)
+func (_ Unimplemented) UpdateProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Adjust stock quantity
+// (POST /api/v1/products/{productId}/stock/adjust)
+func (_ Unimplemented) AdjustProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Release reserved stock
+// (POST /api/v1/products/{productId}/stock/release)
+func (_ Unimplemented) ReleaseProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reserve stock
+// (POST /api/v1/products/{productId}/stock/reserve)
+func (_ Unimplemented) ReserveProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get product variants
+// (GET /api/v1/products/{productId}/variants)
+func (_ Unimplemented) GetProductVariants(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a product variant
+// (POST /api/v1/products/{productId}/variants)
+func (_ Unimplemented) CreateProductVariant(w http.ResponseWriter, r *http.Request, productId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a product variant
+// (DELETE /api/v1/products/{productId}/variants/{variantId})
+func (_ Unimplemented) DeleteProductVariant(w http.ResponseWriter, r *http.Request, productId string, variantId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get product variant by ID
+// (GET /api/v1/products/{productId}/variants/{variantId})
+func (_ Unimplemented) GetProductVariantById(w http.ResponseWriter, r *http.Request, productId string, variantId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a product variant
+// (PUT /api/v1/products/{productId}/variants/{variantId})
+func (_ Unimplemented) UpdateProductVariant(w http.ResponseWriter, r *http.Request, productId string, variantId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a user
+// (POST /api/v1/users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a user
+// (GET /api/v1/users/{userId})
+func (_ Unimplemented) GetUserById(w http.ResponseWriter, r *http.Request, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get reviews of a user
+// (GET /api/v1/users/{userId}/reviews)
+func (_ Unimplemented) GetUserReviews(w http.ResponseWriter, r *http.Request, userId string, params GetUserReviewsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Health check endpoint
 // (GET /health)
 func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAPIKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAPIKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeAPIKey(w, r, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RotateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateAPIKey(w, r, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCategories operation middleware
+func (siw *ServerInterfaceWrapper) GetCategories(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCategoriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCategories(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCategory operation middleware
+func (siw *ServerInterfaceWrapper) CreateCategory(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCategory(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCategory operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "categoryId" -------------
+	var categoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "categoryId", chi.URLParam(r, "categoryId"), &categoryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "categoryId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCategory(w, r, categoryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCategoryById operation middleware
+func (siw *ServerInterfaceWrapper) GetCategoryById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "categoryId" -------------
+	var categoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "categoryId", chi.URLParam(r, "categoryId"), &categoryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "categoryId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCategoryById(w, r, categoryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCategory operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "categoryId" -------------
+	var categoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "categoryId", chi.URLParam(r, "categoryId"), &categoryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "categoryId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCategory(w, r, categoryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProducts operation middleware
+func (siw *ServerInterfaceWrapper) GetProducts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "in_stock" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "in_stock", r.URL.Query(), &params.InStock)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "in_stock", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProducts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateProduct operation middleware
+func (siw *ServerInterfaceWrapper) CreateProduct(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProduct(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductFacets operation middleware
+func (siw *ServerInterfaceWrapper) GetProductFacets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductFacetsParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductFacets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProduct operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProduct(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductById operation middleware
+func (siw *ServerInterfaceWrapper) GetProductById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductByIdParams
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductById(w, r, productId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateProduct operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateProduct(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductCategories operation middleware
+func (siw *ServerInterfaceWrapper) GetProductCategories(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductCategories(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateProductCategories operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProductCategories(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateProductCategories(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductImages operation middleware
+func (siw *ServerInterfaceWrapper) GetProductImages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductImages(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateProductImage operation middleware
+func (siw *ServerInterfaceWrapper) CreateProductImage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProductImage(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReorderProductImages operation middleware
+func (siw *ServerInterfaceWrapper) ReorderProductImages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReorderProductImages(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProductImage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductImage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "imageId", chi.URLParam(r, "imageId"), &imageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProductImage(w, r, productId, imageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductPriceHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetProductPriceHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductPriceHistoryParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductPriceHistory(w, r, productId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductCurrencyPrices operation middleware
+func (siw *ServerInterfaceWrapper) GetProductCurrencyPrices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductCurrencyPrices(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProductCurrencyPrice operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductCurrencyPrice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "currency" -------------
+	var currency CurrencyCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProductCurrencyPrice(w, r, productId, currency)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetProductCurrencyPrice operation middleware
+func (siw *ServerInterfaceWrapper) SetProductCurrencyPrice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "currency" -------------
+	var currency CurrencyCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetProductCurrencyPrice(w, r, productId, currency)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetProductReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductReviewsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "has_media" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "has_media", r.URL.Query(), &params.HasMedia)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "has_media", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductReviews(w, r, productId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateProductReview operation middleware
+func (siw *ServerInterfaceWrapper) CreateProductReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"customer"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProductReview(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProductReview operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reviewId" -------------
+	var reviewId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"customer", "moderator", "admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProductReview(w, r, productId, reviewId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateProductReview operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProductReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reviewId" -------------
+	var reviewId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"customer", "admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateProductReview(w, r, productId, reviewId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateReviewPhoto operation middleware
+func (siw *ServerInterfaceWrapper) CreateReviewPhoto(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reviewId" -------------
+	var reviewId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"customer", "admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateReviewPhoto(w, r, productId, reviewId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteReviewPhoto operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReviewPhoto(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reviewId" -------------
+	var reviewId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "photoId" -------------
+	var photoId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "photoId", chi.URLParam(r, "photoId"), &photoId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "photoId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"customer", "moderator", "admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteReviewPhoto(w, r, productId, reviewId, photoId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateScheduledPrice operation middleware
+func (siw *ServerInterfaceWrapper) CreateScheduledPrice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateScheduledPrice(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteScheduledPrice operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScheduledPrice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "priceId" -------------
+	var priceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "priceId", chi.URLParam(r, "priceId"), &priceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "priceId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteScheduledPrice(w, r, productId, priceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProductStock operation middleware
+func (siw *ServerInterfaceWrapper) GetProductStock(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProductStock(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
-
-// GetProducts operation middleware
-func (siw *ServerInterfaceWrapper) GetProducts(w http.ResponseWriter, r *http.Request) {
+// UpdateProductStock operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProductStock(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetProductsParams
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "productId" -------------
+	var productId string
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
-	}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"stock:write"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProducts(w, r, params)
+		siw.Handler.UpdateProductStock(w, r, productId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -306,11 +2539,30 @@ func (siw *ServerInterfaceWrapper) GetProducts(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// CreateProduct operation middleware
-func (siw *ServerInterfaceWrapper) CreateProduct(w http.ResponseWriter, r *http.Request) {
+// AdjustProductStock operation middleware
+func (siw *ServerInterfaceWrapper) AdjustProductStock(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"stock:write"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateProduct(w, r)
+		siw.Handler.AdjustProductStock(w, r, productId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -320,8 +2572,8 @@ func (siw *ServerInterfaceWrapper) CreateProduct(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteProduct operation middleware
-func (siw *ServerInterfaceWrapper) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+// ReleaseProductStock operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseProductStock(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -334,8 +2586,16 @@ func (siw *ServerInterfaceWrapper) DeleteProduct(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"stock:write"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteProduct(w, r, productId)
+		siw.Handler.ReleaseProductStock(w, r, productId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -345,8 +2605,8 @@ func (siw *ServerInterfaceWrapper) DeleteProduct(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetProductById operation middleware
-func (siw *ServerInterfaceWrapper) GetProductById(w http.ResponseWriter, r *http.Request) {
+// ReserveProductStock operation middleware
+func (siw *ServerInterfaceWrapper) ReserveProductStock(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -359,8 +2619,16 @@ func (siw *ServerInterfaceWrapper) GetProductById(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"stock:write"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProductById(w, r, productId)
+		siw.Handler.ReserveProductStock(w, r, productId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -370,8 +2638,8 @@ func (siw *ServerInterfaceWrapper) GetProductById(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateProduct operation middleware
-func (siw *ServerInterfaceWrapper) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+// GetProductVariants operation middleware
+func (siw *ServerInterfaceWrapper) GetProductVariants(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -384,8 +2652,14 @@ func (siw *ServerInterfaceWrapper) UpdateProduct(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateProduct(w, r, productId)
+		siw.Handler.GetProductVariants(w, r, productId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -395,8 +2669,8 @@ func (siw *ServerInterfaceWrapper) UpdateProduct(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetProductReviews operation middleware
-func (siw *ServerInterfaceWrapper) GetProductReviews(w http.ResponseWriter, r *http.Request) {
+// CreateProductVariant operation middleware
+func (siw *ServerInterfaceWrapper) CreateProductVariant(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -409,27 +2683,58 @@ func (siw *ServerInterfaceWrapper) GetProductReviews(w http.ResponseWriter, r *h
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetProductReviewsParams
+	ctx := r.Context()
 
-	// ------------- Optional query parameter "limit" -------------
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProductVariant(w, r, productId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProductVariant operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductVariant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Path parameter "variantId" -------------
+	var variantId string
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindStyledParameterWithOptions("simple", "variantId", chi.URLParam(r, "variantId"), &variantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variantId", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProductReviews(w, r, productId, params)
+		siw.Handler.DeleteProductVariant(w, r, productId, variantId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -439,8 +2744,8 @@ func (siw *ServerInterfaceWrapper) GetProductReviews(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// CreateProductReview operation middleware
-func (siw *ServerInterfaceWrapper) CreateProductReview(w http.ResponseWriter, r *http.Request) {
+// GetProductVariantById operation middleware
+func (siw *ServerInterfaceWrapper) GetProductVariantById(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -453,8 +2758,23 @@ func (siw *ServerInterfaceWrapper) CreateProductReview(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Path parameter "variantId" -------------
+	var variantId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variantId", chi.URLParam(r, "variantId"), &variantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variantId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateProductReview(w, r, productId)
+		siw.Handler.GetProductVariantById(w, r, productId, variantId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -464,8 +2784,8 @@ func (siw *ServerInterfaceWrapper) CreateProductReview(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteProductReview operation middleware
-func (siw *ServerInterfaceWrapper) DeleteProductReview(w http.ResponseWriter, r *http.Request) {
+// UpdateProductVariant operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProductVariant(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -478,17 +2798,25 @@ func (siw *ServerInterfaceWrapper) DeleteProductReview(w http.ResponseWriter, r
 		return
 	}
 
-	// ------------- Path parameter "reviewId" -------------
-	var reviewId string
+	// ------------- Path parameter "variantId" -------------
+	var variantId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "variantId", chi.URLParam(r, "variantId"), &variantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variantId", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"products:write"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteProductReview(w, r, productId, reviewId)
+		siw.Handler.UpdateProductVariant(w, r, productId, variantId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -498,31 +2826,86 @@ func (siw *ServerInterfaceWrapper) DeleteProductReview(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateProductReview operation middleware
-func (siw *ServerInterfaceWrapper) UpdateProductReview(w http.ResponseWriter, r *http.Request) {
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserById operation middleware
+func (siw *ServerInterfaceWrapper) GetUserById(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "productId" -------------
-	var productId string
+	// ------------- Path parameter "userId" -------------
+	var userId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "productId", chi.URLParam(r, "productId"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "productId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "reviewId" -------------
-	var reviewId string
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserById(w, r, userId)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"reviews:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserReviewsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateProductReview(w, r, productId, reviewId)
+		siw.Handler.GetUserReviews(w, r, userId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -659,12 +3042,42 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/api-keys", wrapper.ListAPIKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/api-keys", wrapper.CreateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/api-keys/{keyId}", wrapper.RevokeAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/api-keys/{keyId}/rotate", wrapper.RotateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/categories", wrapper.GetCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/categories", wrapper.CreateCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/categories/{categoryId}", wrapper.DeleteCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/categories/{categoryId}", wrapper.GetCategoryById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/categories/{categoryId}", wrapper.UpdateCategory)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/products", wrapper.GetProducts)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/products", wrapper.CreateProduct)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/facets", wrapper.GetProductFacets)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/products/{productId}", wrapper.DeleteProduct)
 	})
@@ -674,6 +3087,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/products/{productId}", wrapper.UpdateProduct)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/categories", wrapper.GetProductCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/products/{productId}/categories", wrapper.UpdateProductCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/images", wrapper.GetProductImages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/images", wrapper.CreateProductImage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/products/{productId}/images/order", wrapper.ReorderProductImages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/products/{productId}/images/{imageId}", wrapper.DeleteProductImage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/price-history", wrapper.GetProductPriceHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/prices", wrapper.GetProductCurrencyPrices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/products/{productId}/prices/{currency}", wrapper.DeleteProductCurrencyPrice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/products/{productId}/prices/{currency}", wrapper.SetProductCurrencyPrice)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/products/{productId}/reviews", wrapper.GetProductReviews)
 	})
@@ -686,6 +3129,57 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/products/{productId}/reviews/{reviewId}", wrapper.UpdateProductReview)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/reviews/{reviewId}/photos", wrapper.CreateReviewPhoto)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/products/{productId}/reviews/{reviewId}/photos/{photoId}", wrapper.DeleteReviewPhoto)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/scheduled-prices", wrapper.CreateScheduledPrice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/products/{productId}/scheduled-prices/{priceId}", wrapper.DeleteScheduledPrice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/stock", wrapper.GetProductStock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/products/{productId}/stock", wrapper.UpdateProductStock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/stock/adjust", wrapper.AdjustProductStock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/stock/release", wrapper.ReleaseProductStock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/stock/reserve", wrapper.ReserveProductStock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/variants", wrapper.GetProductVariants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/products/{productId}/variants", wrapper.CreateProductVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/products/{productId}/variants/{variantId}", wrapper.DeleteProductVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/products/{productId}/variants/{variantId}", wrapper.GetProductVariantById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/products/{productId}/variants/{variantId}", wrapper.UpdateProductVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/users", wrapper.CreateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/users/{userId}", wrapper.GetUserById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/users/{userId}/reviews", wrapper.GetUserReviews)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
 	})
@@ -696,40 +3190,178 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbW2/bOBb+K2e5+9ACSmx3ksVEbzPNzjaLzqyRQXcfimJAS0cWpxSpkpRTo/B/H5DU",
-	"1ZJvqZ24QJ+q6MJzeM53bh/rLySSWS4FCqNJ+IXoKMWMust/KSXVPepcCo32Rq5kjsowdI9jNJTx8lJH",
-	"iuWGSUFC8pZpAzKBBeUspvYmoF1KwwuWAM1zziI64/iSBIQZzNwS/1CYkJD8fdSoMyp1Gf2vXsipRFYB",
-	"McscSUioUnRp/3YC+qq49yFDrekcwT+bMTGHh5QaeEBh4EFJMScBwc80y7lddKpkXEQGhDSQyELEpJan",
-	"jWJiTlargCj8VDCFMQnfl9I/1K/J2Z8YGavXG6TcpJuNqA01hbtq5MuPOwWWnw1JLLXvi6ILVHSOfyhq",
-	"7JI9W/3kn4N/bh1oUoS8NMaMaoxBCqCcg8IFwwfdttrV5XVAEqkyakhIEi6pIQHJ6GeWFRkJrwOSMeGv",
-	"xwERBecWAiQ0qsB6F6LIZuj821FtXdNbhzyMoXV7Td+OQ9+weXrxqaCcmSU8MIUctYYUaZynUqCGB2ZS",
-	"EJJphIiKCDl3aOu7ISAs7uvzTrBPBQKLURiWMFSQSLVRG3vvYvLqh6HVBc2wv/5vNMNt+/t/taU39ZaG",
-	"Fs8ViwZWn9rb6+5mAt79ftuWcnNzeXNj/454odkCf63c6T3Yd33L3WvuXQMzswHmdt71e6XxFpS/VkjN",
-	"YG46D/hkTLxFMTcpCScndvcOUYc7H15khTYwQ5g7K1tEUwHjl0+DiscB4l0enx4QU4UZK7LNWKCRYQt8",
-	"XkjAVMlng8Xk1bng4t6VqoFqWJh0qGNoW9qXOShfbdv7PzIVcCutIhtKWWPiSGYZCtMX9V93QTkY/Gyg",
-	"fK0uHV54R+q/rcErd/wNbF7iS1DoP40xvtxHn8Mq2IAaChcXV9f/HC4xTrc/hmTc3fZ6CrSNhpeA8d5F",
-	"clMDc+8blzlboAAjO6JeTC6uQRuqdAen1xtalCZUmDA431S0Wtut1doMwk3F6mhQfG7onaendvplU834",
-	"JvwiZVyZLoBZYT8qeGzT8wyNQXVqv1ydwi/rA1/PNQlDHm/3jHvF1iYDia/uzTzaTWdejwEzlXPjUMPQ",
-	"6xPWh90BEVAVzhmaB0QBE6Aihuud057fbaNP32T2CyYSOTDVTe8cfjIq6NwqUfmzmeEMM52510cFvClm",
-	"8NP0jgRkgUr71SaX48uxNY3MUdCckZD84G4FJKcmdb4Z0ZyNFpNRKcjdm+MA2O/RFEpooJBb1ajBGHjJ",
-	"Hdgps1rA91QmRaaAdoZUq72FhbP7XWzjAc20kmt1UjRDg0qT8P26+F89bMH3GVZoLdBIUE45Ys1KQvKp",
-	"QLWsppSQcJYx27V4gsLvLKEFNyScjFsBMRmPd4VED8KD2uiPLN+gi0wSjRuUGQ82Vo30DxZnnppwbno1",
-	"Htt/IilMmZ5KtsYqN/pT++a5EbQXe1MREj3WZrU+59fMUQ2dVUCuDtRpmypdRmtAgTvh4hicjaEFn1VA",
-	"rp9WEYPKFgKNaoGqzCn2PV1kGVVLj/U6XtoWy6UeiDbffdhoE/jQ7ult9tJLbTDrRZP/ZloPHDYpoTY/",
-	"y3h5NFN0J/lVN/fZFnbVA+nk2MKHPFBlw8gpFoMuogi1TgrOl/vjsqwBTnHmwTWtRq4F5QV2mNT3dWUr",
-	"J5pWDfJ3YHj4IjaUSw60VT1bsMmY1kzMf6kK51bh5YzVyLY3gGmoPbND3io4dkQykRcGYmroWcaih283",
-	"uNwr69Vw9KW8uotX3vwczWCLYe+74lgCMVEyawUrvEipisF///ISqmp6Nb4BVicESKmuSv1lL769kCa+",
-	"t9bL/vxmZCm+Kk22B2gqU71Rsh7SbXSstz79mnQ1xE94Dbz4oeC82j843WWJ5T73fzwoD659Nb55nKav",
-	"qbALeQvULnGtEn5m2jSjtT7iHl5LkXAWGbgYQthZhuZtx0JWxR3dqM2TfM2kzOi13tNPW/ahLIwLi6oW",
-	"N531pt7056ULisPDTaFRDBcnDrjxU9bXqv58U1F7lr1gTast4e7WNYLFAM4926GBiiZPdKBuK5its/DC",
-	"plNQmHMaYYbCvOxB2i/2NQWk8OTLsfF8sj61JIv26lOfNI68IR/bp560W/se14+Oaw+3vTvKUVV7HsO5",
-	"lN86uoiCzjFiCYta502bytl9XfAOi/9jRn2wm96p9ncW7E5LmW+Z3CmP1w7gdlqt4tlQO98T1Fc1Ht3E",
-	"0Wq092GiyhOUPZNOh5G6r85Dni/tnKjZ6BwYPjEnVoV0Hw/lwcDRGbH7+jhqKytVH9Q0vNSOg5XjsmKb",
-	"5Z8RM/Y9lX09h9dJSYe2XqMv/mJvfq+Sto3e287cnUEeDDZLK/e3gyysjHZ8rrDMWkehCnV5FG0t9Js0",
-	"vzgst/PGlmhyOduqsvXDUtv2d8ePQ6kqr5x3RJbUXRUjB3EaQ0F8MKXxrUTWNhbl8ZF1qr7meTiUnX3N",
-	"1zEoZ9TXnFWP8T1lPgdh1KRM27ak7lcoLVKox+T436mQE4bf2i9hBrb3O6oFi9w5s1d4ubY3vwREKUYf",
-	"AUWcSyZKTtRbZCg5v5UR5RDjArnM3X+n8++SgBSKk5CkxuThaMTte6nUJvxx/OOYrD6s/goAAP//rHXG",
-	"QRs1AAA=",
+	"H4sIAAAAAAAC/+19C3PbRpLwX8HptmrtOkqiZMmv1FadYsexkjjRSvZmdy2fBBJDEhEIMABomevyf79+",
+	"zAwGwOBBiZQoiV/dt5FBYB49/e6e7q8b/Wg8iUIRpsnGy68bEzd2xyIVMf3rlZuKYRTP3vgBPMInnkj6",
+	"sT9J/SjceLnxWxjMnFik0zh0JnHkTftp4rhJ4g9D4Tlp5KQjP3H6chQnih03hP8MHB/ew6FE6Lk4c2fD",
+	"x/H+nIp4Bv8IYQ3wT/UhPEn6IzF2cQXpbIK/JWnsh8ONb986G6+mcSzC/uwI115epPoZ16PX6vdF4vjh",
+	"lvMhgT/SkVDr/2vCvzoAEVjyANaMv/bVIJcjEZ6GADBHfPGTNOk4EfweX/oJvBSFn0UMWxvE0dgcNPt8",
+	"msCq6SfxpT9yw6HYjGGXTur2ArF1GlZBQn6fg8RfYjGA3/57OzvCbf412VabfhV5goD03h3OdYp9N45n",
+	"uFY3CPDEcMlD/7MIYalDPDDxZRLg4C/TeCrsq4Y3cwv2UzFOLGfYUQ9gTneG/07SWYAPAPxjWn4sEtgi",
+	"nBV+/iaKe77niRD/AUBPYev4pzuZBD4gDWxr+48kop/FF3c8wbHgzziOYPsbh2EyHQz8vg+fORMRj33A",
+	"WBicJm4F3R9wpGO5JFgffJgH6XtEGYCciJ3A7V8whsVRIJAGDo4OnQsxc5J+NIGn4s+pHwO59Gb0FjyL",
+	"aQu4nPdR9A5I5hjeEQlTaMV+4eAAg8b/o/bdbiNH/FXDFgBVhfCQonEXiK6BP/ZThRZxNE3FlvMLPgPy",
+	"j4kQBv5wiruC3fALQPreaYhLntFDCYUO/WOawP9EcYfozQXymo2jaUKwEURk+NLhkeN6HmBCwoQyEq4n",
+	"GdUxLIrm36T/LeO4giCic3Qp13Xph150aeMuPsB4CLSCYMnGPooA3LPy4AiqMkQ6jtgabjnn+99d/u1p",
+	"95y2tq+3RAt42nUSAcDykgYWl63hGN7xQ3xevcdADFJgbgbjSufeK2C3sMDxhJfrTMPUD2iCwRQYBO8e",
+	"eL372fUD5GWOO4R1Ns4ngMluHgysfKk8Vyi+pAqCNBsfZv0sOM+H0J2moyj2/wNvX4lpHMD38IV8U1Pt",
+	"gnlGTwD5gMSJLoDRGqwC9kpcKhx24GA/u4Hv4c/Ag3ERHfzbi0CQhRHAxcN1DmZARyyjkIsjgeVJ5vff",
+	"f980NiXyTKOIgrReuRX8HRb2syBSAMYDqJz6zJr7sYCxvDPXgjvv/bGgY8QdXbogf5NkSqeHbB6/2PDg",
+	"480U3tvolIUE7zZpHjtJo0niXEbxBQNsgGpH1TQh4C8irBJkpWl9rzzdh9AHJFSg9uHIlKIgT4wEpESi",
+	"jSe23QRukp7BsdhhdTABuH7xx6QbFOGGn+KJwsHHQCMxsmY3dcYRPI5C0F1cwJYQWNCV98wCvLioX+Gp",
+	"Ri+lx/QDkqPlcwUszkHhoA9fg36ZhgguQQdfmngCFOR/KU/9CyAuzgg6U+z2UT9VvJZkCEIfQTEMfVKw",
+	"QASYU0/i0dmTi/cvvvx9t2ebNRafgeJaYq18mfAKmB48ujKYSQNILBqZ0gESPfPYnZE4RgVRqVF17IYJ",
+	"9AQnsClYyA/ObJh9+FoBlmSymt5lzT4H1Z3dMiwJmJI1vvyItJPNJdFKH7Lef8dkG5/0mFHvD9FPcbW8",
+	"mVf0UpnnXI0vfOeMp0AvPaEk5WAKGjDoMTATvOSDuJimeMBeRGyVZ9lqza2uTkL+tSjo5nAKeMwhf7Zz",
+	"awhWwi25/WosOmShg5I/CH6DvX5ss2n4voh2F6JCFZwELmofoKrAK1vOIakqySi6BJGOphaz6NAD2IeI",
+	"WT1BvAtMR+FtVfKtXy6fff61OzneT/7V3xm/GT33fhJ77r+nP/q/pT//+c/Z90/DRmjhksuQ+aRhcxyl",
+	"VgobAs8VZ/DAj7wzpbHS3gfuNABS6BaVmbfRpRNEEr+jwKMzvhAiJ5dDpf06j5T4euZ47ix5bELhydMu",
+	"DD92QRpOwbx/2t17Tg9AC6YH3Y5Ns6w4fUbe0rEdaSvQgb3CMOS9AAVKinNQ45Vl/BK4kHdO53cOosAX",
+	"l/oR2Tbk9QAkmPZArTwN8SdHhN4kgtWBIZONcxkD0Z3j+6B+uUFEwi0cCnwJOFX/wniD/q1+Z0tKT569",
+	"RaYHP6X18d/OZBSlkSkv/5oQ7bEVJUIE4seN3P6ISZvrRMrK1rRBIlNv3fgn//zJwpcOUvizB0rJG8Cl",
+	"tIxjVnLSH5V0qn4URLGN/4FyPOURW/E0Ws2rCGyMspi0UI8e38ZglLusvDkXiNsdijPkwDbT7YB/d/h3",
+	"PCp0u6izRO1GO2aUXaecanjQ0kdT9qppeO1t7RqCaxBELmpImqj28/RUobgApvSQvArEXtzMa5GCEQgk",
+	"ZDxW2Ge49LKz/J38H7iRS/grAAPfQUMF8DYk7WDBKrp1DU/mlOF1+3lrLr6s4rpokDfIRn5JD99xEAas",
+	"5kaRfu6LgsBsAyyJSWd9Qvry7uiQcR0e2Y799Lq4t7Nb5tCKYzSvYoFE8PyZVVSUFFatppqAKqy4jv6r",
+	"FNWbpZqlYDNwiV9EOExHptJ3XdyO0HmEcpDgBtarieOzEobX6zi0y7rT+TDxbuB0Mqh1nGQi3As0WQlN",
+	"+304KkW7izqkg6nnR8s+nzHsokB3SzoqM3pRXunJb87e7s6zLKrSh/e2nCMdu2BrUhtxrhl/Ec6Hk9d5",
+	"NRseILm7aSpinOD/Ph5s/tvd/M+nr0++/cV2SDrihJEiixNMRWvmCtIgw5HDFZRTikepw5ERpZx7t5+D",
+	"/PMXWy9emGaq6PtjN9goyvHOxpfNYbQpH45hcbOtg7FkdvqnTR/WHbPC5iJeab4o+eFo2ttGdhqHbrBN",
+	"o7B30zxpY5m8ybpTp/1WUek1YOQ8Uhb/kBAEtQFQ8btMltIAeSH/H4DwsQWo4ks/mCb+Z/FOqUssZS3A",
+	"thgoNw/4amjn/dEWbogMz+JH+MVPKM5BTmh2hpOPHIw4dLSyNx30j8dtvQr/0APRkmzeKumELy6F3nfG",
+	"wE5Rd+bfeqg/X47gOC8p7BGDEZojdsklyKczAJh7jWyKZ7fB0DAeykygSbEBfOmPcLVaq0F+xSFzMjKa",
+	"dSh+reyEcIcUa9fGU2m4jVg071t9Va3wgJAL0lE1EiWpm06TXERlI7ponFh+ZpuR6PwtoCBImx8wQr84",
+	"7isGA5gESPsMw/c1rkTOD0hBGCYOf5QD7W53d29zZ2dz98X7bvcl/d+/28c49CLSqHEJ7M/sCfJ4h3It",
+	"0lRg5zRAclYKoLEbwbLm3c3ubvOaF2yN8VYsa7J5/2Dw5EyGNMuT/D4SmIvBJOQn5vD8STDL4GTONXCD",
+	"JNtJL4oC4YZXkMhoexiZIqAwsgCizZFf1zb7sxWQ15m106Ab8ltzKnq+4VA6k/9AwHZMxaBAf7mjtrMC",
+	"zl0ox8HfvHJe7O0/c2ROhKNkWccq4yxy5cskcEOWbaC79wFh+zqbKerLNeex9TiXFlGI8++Mu4nOosCg",
+	"HaYWAS7s7Brx/7InX3PPgm/1/fsjh38kzTfn59l9YRMUqZ8GFjw+GQGmOMl0PHbjmXHEBDUaxdzi+yhy",
+	"MBvF0ekonapMnhL5Hx+Woh6V87g9UNpf9uAImkWF/Jj3p0HWUUdbgTeEwmUnnZKXSYu0GRziIPsAxp3f",
+	"x2dyj56bgMUCCGc4/vIOvP1FOvCuKiSvbCTbGMdbfzja/HMK+l86szkxWCEKI8qtc4HiYFM01QKETnk1",
+	"+AxkoNURCPx4aAuqHdJziyTw/AR4CKY8esS5W2nCEq1oUJsa7Idn5ISvk3zZKkZuIuMGKarDPmeHxCIR",
+	"8WfS/vTWczhiSL9mX4QNjr+rg2xwg17DkONExN7Mav6+uCOWWmeDMinb4cR7fJU0/th3Za5uHnD/kL+U",
+	"j2Ye3JOjNMZCTD+puQ6rZJcZowZ/NTZioLWmsxq2fZBj0q7n+TivGxwZrJwPuqA6w3gUhdwmsyYzjooA",
+	"23L+QREenR7AKJugXo04kaBpJYkkyfmRvm70yIU0OxtFU0yyegL41ItdMDE5dk+2VEDpZD1MC934Vr3P",
+	"V9rDX+UJUU43UKhsnOl1UnAY+vlEZ9gaBmiTQpLBR4qEPMMjaJuuW/T1mOuqOckqL/k1xfBdl2wNHtxF",
+	"8uQmZ/E1jJ/ruNruMQO3OcDtDLSGcMjrlDQRTruMnnxQ3qJzqC0uOphelgk1O2aNqLzhID3DPBuLrh3Q",
+	"WaE16VAmjkRXki/VcRrQFB087zZeDplCfGY3eN4dvvuBLJvqmenJ9h8TMbSpR3WJtB8moP57jvTHtHMs",
+	"jQSwJMtYb+l5bpVIzBP/iwgKUdxu12Zazqd4l8GwY1UOo8Sv4MRSsVZv5FbeQbM4JvMKmMxObvm2tS/U",
+	"5wHWp/8fm5ENT0vw7c3SfOx+7/nuzpMnxmnCMp/ubViN+RHwthDk0dk0Dmy29i+UQOLo99gAGPjSxQzT",
+	"P9ntfoH/bznmje2x8Hx3Wzmlt3e2nwx23Rf9rtjr7XtPxbPB8+6LHXe396S/5+2Lp4NnZzTR1h8TKyLX",
+	"rdGOEfOvoGruS99Dbl6ylvBxG5R/2u22yxjI+bZwx8VTKvALiStqhZo8DcxvTIo1eeNvZGaWGCTtrVY/",
+	"RG+DX2XEssf4smTMFpRFpIXdayiM2SqbtsmMb2GCIEs73N3fN1LLH9twaeDbPGg/Hf3wY8c5+vVHtAp+",
+	"PHwjB0bIyqH3nXffm1y654cuRcrrHVs0XQ08TpQjoJhoJu/fWJlymjArAJUTOKUPZ0+5p+wRwB0kmKr5",
+	"CBRX4NugueItgkS/kFPSdq3CIIgu27gosltCeqoRZda7oEjwNTMYaVN5L2ABI1hYK4+9XsFZ9p3FFVea",
+	"H6CCdh3YRJfoue+PpO/ET3ApzqMuUgF+kkiXvuhf5ACy070BCaOWWx7s72ojIBJB1cbrCSHoz3RpQp/w",
+	"FFEgd4r79tQs6SOqwKGRACyhfCwR0gTEGHIDW8edkiVbc81CgoPut5gpr1cMRZVi4QaT1pA0ttsxyMeO",
+	"SSaG1xDne6k057f4JhZiE/dBV2hrzv7jhjIZ4WkvmIo0ikBKmBy2wXQravFyXVWuhPtgch/FYuxPx9XW",
+	"tkvBpds1ujFHaXUN753dteVdK3SVd7RMQIRb9UJPejzzt2SRiyZuINq44+eztOR0OYx8ZpMpEY3VIk2H",
+	"xvtNvt2Ipp9FHKM9XViPTE9II0rFMzFZOY4z4bRXFRevD2+tRJyccyjbm44XU4vlSCIQb83QFV6QvR38",
+	"Xzxwtx9HCV2AVoDVAgWsjOK5vz95e3j8fvP4h9eb764QrMfFZXjSUdjeTCiVLt7rkkvHkVePEorLx9NW",
+	"BHSzmH4tVixx/w5w4hvF3FrBWcwig4U1o2ilSrRIjl5praw4Rmap383MeneNsAtA2HrmSncKPyS2Yhn8",
+	"G54Peg7YASiLPrSv0HAshj4sjjOf5nIvD/w4Sc/sevIb/M0JDW1ZLiyD2k/RaAFZHaVh7SmEVHnBvtRf",
+	"3KaVvo5EOwlqQMScstGtd0zIa8snHo+tWY/MFdyA/VvyNQ0TpoXcFn7EBShK/i8HY5VUAIo/9fgScmP8",
+	"Y74Tl1dRuQqL7egXnFVq2TY82tzbf3pdjKjeCaNG8/07uo5roWC+pgu2uPSCmRd60c6bcthnrpQixiYa",
+	"2uYXmMs9JTONeUH5DKLa5KmqhLhjToSTVcWi3FSPdjb3KZiTvwu+X5HyZo3utChBwKdo3q6Utw3xHnOK",
+	"OUYCnspSBW6fMuETrqpTAACxmsazlwKkYVUKwhoaedvJzyGinxSz2hwXsMi5HEV5JYSsv/k8ZmVTQJ6l",
+	"xuJqDlal+98wH1sZbKw7er31ZhxosucLJyh3W31MzBospzRXdJuwYeWj27TKeUJ9c6eVlsCwU1FuCPXT",
+	"NkxgEUFnve1S0Hl350l3b291gs6S+27vbL/o7fR3vSdib7DvPu096z/3XojuIAv6Xi/mXD6nuRdwnZBz",
+	"HR7utg05Z0h0zYhzK32U2ERV+LVlaJS3vcTQKC+1Ml9ycYInijRf7ji9KX40DTw0rHsCM0CXLXj27rTg",
+	"OUH1dhoIj7wVlVW97sd1vMzn4mKVSye/LfK85OqPPb7yvby2YSQNfrlsOX2pVPF13JfP7vJt6dJVNCsS",
+	"o8PowPsDYKS4SvFyWZC6lmrUfBdQEtufhai9BLqsCtZtzLgorJ/nrFzu343sgfxiq/MKsvvKlDxQhRX5",
+	"ldazpMKi9dyV6z4Bpoop+OV132q2R0MFMnOPtnXatqt8ezfhssNCwrbLj/hYFVrOlep7hMV2476bFJjU",
+	"H9Eo3PIi8b/y0Vafrm+ufYSNpjUfwdV9hYgulfJzzuNdpSNdOvQbAG+DdbFMhUULFjbuY2aJ0CvSMOLk",
+	"lqyKRt5XqdwsJdDIahe2fJlSmkyxRIdlCn3LCRTXSwEK6A7J9/0WSrigfCi1HjvIcmGryvtaloRRm5rK",
+	"ZSwSWZdsOPWTkbpLrB1kVHIdLR0usCmLBBpXtNQFLC6AwcbzxjvLTSw0rQUoQyAuUGMay7DgxP9ZzLBu",
+	"tkXO6GLdsngr1mF2scaHLvHao6rcrjfG1hO6XvvY9YTMUVK1UNGHp7D7NLQWV6YdYglXKirap/JnWY1X",
+	"WU9UdRXgqo9cHPU7esJ/n4ZktaudqrrmZAFhJf/sTV2pNRTCS3D16IvOJsRSE7LqJdtE7mlIRXeptC2Z",
+	"NWONbVzBnGugxvFMGh4wIM1l9MHgguVZS4l/bgKUN38WhpXIR4InyNXT1eHwv94oWfjT7+83SlVKT3b3",
+	"n6ImcEx/yL4l8OaWg4Vcz5Np7xyOzvXHqpxEZichwFVlddoJPjzHHg/J+WnIHwXYIQR/8GPq/gDq0zkd",
+	"/nnHOR9HHgIvivEffQBMNBbx+WOeWsI8dzhYTLR8OjyuamtA1USDIN9JguFJIQGKPxNgMgCO0nTCurAf",
+	"DiI7XqP4HLuhOzQK1ihnOQx/Gv4WYgWeSRDNxlw8DIu7xp9hJwLvvQeoYMUC1Gl0oD/iwGny2CAC4ukM",
+	"Kv5VlcMRp+H5Pzff07PNw9fnDuOEo+1zbgcwAnvkO6MZC/wxk3cclVUtx5UHyGAA4sJi+0Ac5/yzOnAc",
+	"nj87l7km53KArNJYJ1du1bhnCVPQSk5DuVM6Ll1yaMv5EF6EWBDY/DkWyHlk3TJnr9vtMF7h+jTdywYZ",
+	"5uDq/Sd8zrLWgy50xF4R5+20h9Vs8eqtiBM+2Z2t7laX8xBgpImPdTDpEVVDGxHH24bn25938D+bQM9c",
+	"D1hYNVHsIMOBcskKk3wWMtVNpz3JxgUO37sKxSWeIAljSjSSeHvoyXpTXLs32Sj0gdntdls0c7D0n2lV",
+	"77l4aaEklVQlLLVZ/Gavu1M1gV76dq4bBX30pPmjrOkNfLE/586v1ZjiUFrNTNCx1CVMAUlFtE3u+3GD",
+	"2NzGp2+gF8j6IgpiJrgmQLQWJziiemKUX2asLwpTzJTAm16J0ZyI6ho7pSrcQD3IPRFBCeu4EBDVc1EQ",
+	"/k4+xvx2NxlRzW5kWR5TVR4pWemWqMKKEWDw95E3W9ix5GreFzwV6EX5ViKGnQXPLSulWzCioOkkU6pp",
+	"iZ1gZozPN4qdrEz44QSUDlB33Rukw73u3tXayaDlZpSgW1wXmfLAd5RXEPYZPIAGKQqj7a/wv4feN+lw",
+	"E6mwySWUO0mumrtF9fbTvBBWPdywaD9+AIMQt9FyjJRbxHxUh0scgmfVHMLsq/exOuiXdY8h7Zc8mVr3",
+	"pa1uFPlAXeOoTyUesVdttqh9rQQ1q0Udvr4L1KxWuwyCto59R2maiaItUW/HWTuKWj2BLqkqOCmrwUnQ",
+	"oEB9oEO2RUfZVFr/nFGijNEkC7s6Um8F0BSwo4zZvGLL+X6mks5zTS183RHI8ccURU5FMPtO2fPUOsPh",
+	"1hnAY9AmNppgGKUZ5SsC22EkkbynSYpOwtYUDNgfUW6vTSPh1h1X5DfUGpOBvUjOsyylSLYp+fbt220q",
+	"Qcdg7cLZkrm7VojuHAvd67642kqpXaq8TKhsGaJQvEtNmLnoroBGF0DFarLmf3dZHBC0qsSB0eOi0eXg",
+	"TNAzRUXfA2mVG94YJRLQC8deb3c4jMXQ5XSoPCP9URi1t5o46TvOwpClwQrT6n7DFU19qVpornel7qe0",
+	"Y3Y82sm1O7IGVKujtvn1JBf+pGI10WCAHT+ty2kKdn66CceM7q4zh2vGwKHbYsMEZsfAogftJUJUNWMo",
+	"xb5TBQ4BxGgh6GrPEbtLlEpodLCQeU4Bhi8wdxsUL/Qhu8VmFxWenlfZz8tQawqNY27Y25MRVvlU1W+6",
+	"p8bVVBspRmXpAmpk+0aFS40i9rLlwEcdTFX3tHXUkx6wIFQNeD/p/gBGjNZAvym72o9cVTS8dsKsQ4o5",
+	"awFLsma7ZDs0rWFx2sCt6nZ3R8uoZjKyP12ey7xSfYdMplGhi2x/VS80uJ1e03NkRRprHo3cGOtZ4A8U",
+	"+mLtBTRRdadDv4rlcHRfCLxROu1layh7m3gyg0u1tP/0fKAbyI1YDcBsz4v3P2kWwwuwsZjVNl30BpZh",
+	"u9gHn8t4IdADQqmoZY4HZpugXqR8BhlekOqsHSQKI4k2YMQTEyuvPmwOuRfKL19F4QDAkjqbLUnrXvO5",
+	"1/lTwL02mFUoqIPiuZVsKHJKUeSMLp0hRNNYiBrTavY9s5IrsClsnuCLz8tmVN2bVa+UKrJmd7bB74ch",
+	"o7G4p+IaoMTZ7r95bMMYnuk8AVJNRtD9nEcoI4EitCPycYnmeLTraQZct23xBLc8Q0reAWplSN0wpcsi",
+	"eNczpEx6LGZ5vnS0DWOKvazZ+Dj6rA1gYNoiGKAYLPZNvSdGy5rNrYDiweRoN60mhlY6t5MXc82K7fPQ",
+	"zevmeu1whmLWHpRScFUicoBZgs7Ax0KxnC9b6JuHzdKRB5/j863Tabf7pH8hZvSH+Bv/m17kJ+enYdHl",
+	"B3ya/PWEY3IcykP+G8x4TpbgRLBSpQOX6O+nDoEkCWaUp5ulOfHCOo7nDwaCXBMUhaD0WoQJfWkLE4Ic",
+	"0mbA3L5tDemV8Gybq1mSX7tjp7EMbJrlvyH82WjxxXt32P5l3RIVH22UoUGxKD6KAh08QmH3GBm76oL7",
+	"iKpQPa7vCWSDoNGfpSTQdS2rm4kBqN5dc4QAMpt1RQIAeCbTMJlO8L4icBxdPXUtFO+v7h9YsLFNCKNQ",
+	"jT2ZJUAmFUGKI11schmqdb5rzw2HKDTdl49MpbkvLkDhM+Hq5t718QJ5ZdiIFdCtavs12eZoxc1GR9aR",
+	"iTsUmZhoIihrz9sD3RSoVommlg5lFQqblKbukHLj8O+CCuy44yjr2kmfnIa6f3WmlrIWnWw5B0CDE776",
+	"xOqs/AUz7s6HmQ56blwQOQ3batglkVqv6cp+SSV1d5mq3TIdivltWTCUfnFksTJ18Vm20pUn4STc7umh",
+	"pUSsNZ+b1nyUBjNQ6GphXl/lX61DqnpQmaQvFaOGCKvZGVXdXawIpWa6VEt/qRq7IZCqN7r4OKpShO5q",
+	"GFWtfxkEZh37yhmg+aCmOvl8TFO1cF5KLNOCyA8hcDnJrP92ccvc0aCDO+8X5Hvqyj9CnWekmZY14K5S",
+	"KuYLYBrcoT5+eTX+MLcj6QbUkzoyzEU7bxBnP9xRt8uNs8b7pXrMH2/NMY75w63X0R7qgq3X0B6W5hC6",
+	"nVBrCyZzvUDrg459rvnNgiOfta4bw/qZ69IL8KKK1u7YkCSR1WzSqDP3BZhSD/q5OdlyrZ8VvV2yJur7",
+	"m7Xlq2pMhhFiVSfktVDp+xTFu2jVZFqvS6wSLS4vuKQ3uTytYuHcIFt0dppLTO5SSTTYMNnM79rbLd1K",
+	"6ay1mLUWswgt5qSODzapNNxevFVkKutE7pqR72L/8Sp95ZBnuve6irndefQVeRJr0r3vDo/soO05JtzI",
+	"AR2l7frXc0U1avjiY5Zwmi+R5FDLOQm7rdPwwOgCAu8PRYj0Kkv/aTon9WcyoU49sjg+/qZ9MBkzMGi1",
+	"uhhbjihWVkUaT4PUh7Wl21ike5NE6bxaEm1R9uK4nTwcyXksmE0Hy43Kbtv18o4zaQg5O7mMO0Y/7l8U",
+	"O75SbhQ7XTPHYqBs58nVVsroIL5QjTSiN5n6yyWagQapBcwCFVXFWNIocgI35gO9x94lapXl5hl/W5Vs",
+	"m/UpLK9lM2RB6eMzy6lfinFaVDWWEqoeLyXAUTwNCwDPJNEBkvTTAGtW94WtaB7NsWLq3NLMXdrgb3QI",
+	"t2XozqtK8pnIdFQ/ptgIo9Hte9ZVRWjGU20M1+Lgmt8/JDtW8peSntySX36l/7ZNUgoNXdoI8A25f7hO",
+	"SUD1JKnPQrp9nbZTPZsvF2eZS4Jr8alOLObvaqITr34Z1GwZ+b7nBM2t+1B6/ubIx+Les1ZeKU7o71Pj",
+	"rrzGo0vX64ZhDoX7qJI9mKqHlNsMH02wa40coWMOykm5XI5cNoxDIznRPdrkR/UZznRV4a3c0krxifIl",
+	"Qtju6lTHMxZzl0vjmef/A2xpNt8FOcRESRAKImu96N47CXPn3pJvJu0ZZkLBz2JrcmBz1LtEpd9xSBR1",
+	"JSzGc1nH5HQKIy/k/mckmPudKy1BdfCU50A6L19tXyc9PhjyLmBBWwLf/qo+bGiqgFVETP1I3lBVX3e4",
+	"iDmFBWB/adZuFuspoMd/6Pphvd2Tp4CV0mvU0mB7XlVlHEVrdRO1YQCvcIq2xfRypw4aFhd7uXMl9fLb",
+	"WMqdq5op7rEDBPGhoAIA5bo5yVDvDuaPConLuTGkiDfluuzJpDiAasV0GvphklInwYGVW1xiAxaTr6jb",
+	"zVTF12YVndgVhjX7WFLFLRPIt1R2K68oNZI66qUrlhR+l2uCrPW0RaZZ1bLmJhVOXVu7Sikt1cmSW+0l",
+	"E9H3B37f4IZVdtmxviu30n4ntb+V8DsZi1le6arqUlFq+oZKUZNRlEZJxeJGbnJG7Y5WoCwUo+A8NrJx",
+	"a3ZdAmHN6K9hkEtMqrTH83zVvD3QovwTf1zBk50Iew6NXKzfWe5JzRo3I8tfk9NQlQZK3Qus1KJS97h9",
+	"dZ+KhWw5B/xvrJAop3aN2VTeRlMOniTHe5i4wTu7nRpYisnZOpHRUS28AtYx3RVrrELFV8pydajkLTNV",
+	"BKsn0ksBWLdDwa39BVfBqp7/4VXCegDVN/4VTZ2R+xl4W4A8dyY5Fd6hwn7OBoddYAs2YotYbqNx0r3d",
+	"F81n9j6K3rnhTLWhvRtWTR+oORqDdvmpWgrWVzHLibPWF0jk2Ntf+Y/WRYLUbPU1gkgvTrWo7GCf8chD",
+	"2QarxIVisWBQtUkoeirpgMduqB60AnKwJnFHgqehYJGC+eKTeKTUWkYWz1yiLpkOQKnCRqvHUSCsXUwO",
+	"jZewPh6JJ1gB4S5wtQNahPVT5FbYVxp5H2hnEbGMCFHMH8xUB/pMsC+QZaEyCKQduP2LRB44n58TY5MO",
+	"zHxP2ixrTqFCUJXI+muUviF+b4OMRSqQ+oSz1n4oEcf8bvGyCsATl+a5U1wa9CHFx+DvSpdULed+ned3",
+	"c9VQsTF7SwmVEgcuMV0u5FHDdHMXpe8K062r83J1prssk+d2PPuNJs9CmmmsgsmzyubHWpqupelaml5R",
+	"gn7IC6+rWDvb0g+PmDDvdV769JrXeSlyT+MQHm+KEKPWhOEofCbOD/+EmZDNcdwfhK7L9dJgBQnRK+YY",
+	"Ys5Pgmt7/B3Ngl+dhlhRIEyZGfKF4MDHtVAGtXOg0Ab1ADb69T4YKrXqw2lIvV7S1O2P1PuVzksmhCN8",
+	"a7W1h5vSGa51V9mA5u1cVTaP08aZCKEZNR7kTeW1VL89qV4vfxe2Q9vQV3a7ysGU/xN9oeaFbrrLLQXV",
+	"wndQNanRyUDPfOXL6swRbvCy+pGSqbnL6g/Af3xVXeqABbkrtRHk39dXq+At/G/70vNao8qMeeudzqu5",
+	"lqWWUuFYfog6Ss1cEwkI2774UJdQZp/Of+21vr8SmU94iQIZNjEpz/GwXcuSlhv5uL4Zu5ldSLNbxSfy",
+	"Te4XgtmU1PMQ818SfdfWuLp7RvYvmLEdzjrPfmApnYi0cxrGWEmCm04yb4XVR9NEzpAbL42qjU21OO/2",
+	"k9OX5DfO7/C22saV7sXafFTZ9W7j4vW6bPg6QfD2MsElGmrWxeg5P3vEH+G/DdrtKzfsiyApzMb8Em0u",
+	"BDrnDUq+ORNphYa6QoytRnHMyDwH3orJCYCL1yRPrItAYwAOI7iDt/aKG1oGH6gA2kIbO/EJVFKD8kaY",
+	"FLHgdLPcxHVz3t/Ol+oMKki1mRNSy+o25Qq4IXYAil1QqGd3ZLbUxpsRsejjbXpPfsMRCefPqRumsHv8",
+	"urtVc1XmRLbRvruVC1rUkuNN2kg3D2dzh2vl5r6XI2BybLxcDDb9pmpQDyc4igIvX268NvdnRehrCcYU",
+	"bgyAhCkmyS31Wmqg60Subt13ac1jbkJ9IC5Rm3OR5PCypcaw7Xp/TNmdVHFBS9aBQwaSCX7MTgipxA7Y",
+	"WKnbccTWcItyH+HfPuwWCx09ghF9dA3xbUtc+ibe8XQehdiLCX/g1Aw97tjlnsBeHE2cngDuKH2iBEJP",
+	"v1hWOg5oGw+CL/JWMZl0NTkjY9SaI97va0m5aIvWdha01F+xqU8YTYcjZxpq6peKUiTL/Nxfhs4ULver",
+	"eF5bhh6LQLiJqOboP/pYyUpDdRpiCLfnMmjdz64fuL1AihODsbuGp8bemeaYZ34QTPjv2amsIAuWOLBm",
+	"wQ+hL7s8bGccxYKLUFl1pgVu4424FLFkHBg213PR7Lpc1f1l0JLTOXnR1J5D01fVHPptFHiJhK9kj3V8",
+	"GXsKYT5lFVem2dZceQW4ssKWNVdeK8bXVYwLHOE+M1uaoS2P/ezGPnCBdg2l1cv5rhVmBWFM5MDLGqk/",
+	"FjWhjn+oWR9KN0a54XmKUOmTWfOb+x7+MI+6TeEn+b7z6OTnD4/rIyC50ksKC+9v0yy5w9tKKMvTehmT",
+	"5E/XLMa0Vm3uhGqjDpsS/yn9GMhV52pQJYRF3oY50GyhacL7mxaiSgkVGGt7NWj7q/yr9Y0P+f6Wo3Qa",
+	"Br8q7Ndnr0NPN6RqqAq0Ciy6JjVOoVhDXSANxMXnxSmquqv9vdT6l8G/rGNf12Emrx7lmEuhjEuyhD1g",
+	"XpsiIgyelgjpYfUv+5wZENJMa7Ctvp8R9a0yF1ku7+jegl6nqsGs+VB57HtpsqHT4/D1XJWv1Jc5H0q5",
+	"8lV9LtvdURPqKlldg9SXbUHeTkmr9pxmnUf3ADSwtQV503qXLshUZ0Fiud+aq6WZry5fPv2HMeoGFAKe",
+	"hj4wsI6D4HDRd953E7HpAwBCzgEMLEl7PO6HhKKWy+CAOPTteM5oU5aj/0D15lfZWXZFyqaNZVQmEDWW",
+	"SdjTdvOtCl2XPTpTxpACEW5/xf9IX01z59FpL+AGCVTDjBQw/J4rjkmYeB5geYJXuqOsJwomcWGqxhAA",
+	"xRRpC27hoc5ndk2ZmC2qEe9rdUwgAl0tmRribu8aRLEMqVweeOUQHW2LRiy/VgunrPxKQtnlsHG0XrSU",
+	"iqOEo7xKQur+5VRurwrh527wtFCcX7d2upWW4tdsqbTa9sEDZEML6WGk5SlzsJFwAxzga6XD8i2/sUSx",
+	"xTPU3hgHWMi2o7zgWYEx8xAODNi/cEToTSJfWeYMRxu3+yXqA5w9vMwaTcaU2kTvwl6ncYD90dJ08nJ7",
+	"O8D3RmBFvHzefd7d+Pbp2/8D5Zh3d3NJAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Scopes that can be granted to an API key.
+const (
+	ScopeProductsRead  = "products:read"
+	ScopeProductsWrite = "products:write"
+	ScopeStockWrite    = "stock:write"
+	ScopeReviewsRead   = "reviews:read"
+	ScopeReviewsWrite  = "reviews:write"
+)
+
+// Scopes lists every scope that can be granted to an API key.
+var Scopes = []string{ScopeProductsRead, ScopeProductsWrite, ScopeStockWrite, ScopeReviewsRead, ScopeReviewsWrite}
+
+const (
+	// APIKeyPrefix starts every API key so that leaked keys are easy to recognise.
+	APIKeyPrefix = "prh_"
+	// apiKeyBytes is the amount of randomness in a key.
+	apiKeyBytes = 32
+	// displayPrefixLen is the length of the key prefix stored for identification.
+	displayPrefixLen = len(APIKeyPrefix) + 8
+)
+
+// IsValidScope reports whether scope can be granted to an API key.
+func IsValidScope(scope string) bool {
+	return containsString(Scopes, scope)
+}
+
+// GenerateAPIKey returns a new random API key.
+func GenerateAPIKey() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAPIKey returns the hex SHA-256 hash under which a key is stored. Keys carry 256 bits of
+// randomness, so a fast unsalted hash is sufficient.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyDisplayPrefix returns the leading characters of a key that are kept for identification.
+func APIKeyDisplayPrefix(key string) string {
+	if len(key) <= displayPrefixLen {
+		return key
+	}
+	return key[:displayPrefixLen]
+}
+
+// LooksLikeAPIKey reports whether key has the format of a generated key.
+func LooksLikeAPIKey(key string) bool {
+	return strings.HasPrefix(key, APIKeyPrefix) && len(key) > displayPrefixLen
+}
@@ -0,0 +1,54 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"product_review_hub/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	t.Run("generate unique prefixed keys", func(t *testing.T) {
+		first, err := auth.GenerateAPIKey()
+		require.NoError(t, err)
+		second, err := auth.GenerateAPIKey()
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(first, auth.APIKeyPrefix))
+		assert.True(t, auth.LooksLikeAPIKey(first))
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestHashAPIKey(t *testing.T) {
+	t.Run("hash deterministically", func(t *testing.T) {
+		hash := auth.HashAPIKey("prh_key")
+		assert.Len(t, hash, 64)
+		assert.Equal(t, hash, auth.HashAPIKey("prh_key"))
+		assert.NotEqual(t, hash, auth.HashAPIKey("prh_other"))
+	})
+}
+
+func TestAPIKeyDisplayPrefix(t *testing.T) {
+	t.Run("keep leading characters", func(t *testing.T) {
+		assert.Equal(t, "prh_abcdefgh", auth.APIKeyDisplayPrefix("prh_abcdefghijklmnop"))
+		assert.Equal(t, "prh_abc", auth.APIKeyDisplayPrefix("prh_abc"))
+	})
+}
+
+func TestIsValidScope(t *testing.T) {
+	assert.True(t, auth.IsValidScope(auth.ScopeReviewsWrite))
+	assert.False(t, auth.IsValidScope("admin"))
+}
+
+func TestPrincipal_HasScopes(t *testing.T) {
+	principal := &auth.Principal{Scopes: []string{auth.ScopeProductsRead, auth.ScopeReviewsWrite}}
+
+	assert.True(t, principal.HasScopes(auth.ScopeProductsRead))
+	assert.True(t, principal.HasScopes(auth.ScopeProductsRead, auth.ScopeReviewsWrite))
+	assert.False(t, principal.HasScopes(auth.ScopeProductsRead, auth.ScopeProductsWrite))
+	assert.True(t, principal.HasScopes())
+}
@@ -0,0 +1,58 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"product_review_hub/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+
+	t.Run("load RSA signing keys by key ID", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		jwks := `{"keys": [
+			{"kty": "RSA", "kid": "key-1", "use": "sig", "alg": "RS256", "n": "` + n + `", "e": "` + e + `"},
+			{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "` + n + `", "e": "` + e + `"},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": "AA", "y": "AA"}
+		]}`
+		require.NoError(t, os.WriteFile(path, []byte(jwks), 0o600))
+
+		keys, err := auth.LoadJWKS(path)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.True(t, key.PublicKey.Equal(keys["key-1"]))
+
+		verifier, err := auth.NewVerifier(auth.Options{Keys: keys})
+		require.NoError(t, err)
+		_, err = verifier.Verify(signRS256(t, key, "key-1", validClaims()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("reject key set without RSA keys", func(t *testing.T) {
+		_, err := auth.ParseJWKS([]byte(`{"keys": []}`))
+		assert.ErrorIs(t, err, auth.ErrNoRSAKeys)
+	})
+
+	t.Run("reject invalid key", func(t *testing.T) {
+		_, err := auth.ParseJWKS([]byte(`{"keys": [{"kty": "RSA", "kid": "bad", "n": "", "e": "AQAB"}]}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for missing file", func(t *testing.T) {
+		_, err := auth.LoadJWKS(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, badly signed or not yet valid.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrExpiredToken is returned for tokens whose "exp" claim has passed.
+var ErrExpiredToken = errors.New("token has expired")
+
+// ErrNoKeys is returned by NewVerifier when neither a secret nor RSA keys are configured.
+var ErrNoKeys = errors.New("no JWT verification keys configured")
+
+// leeway absorbs clock skew between the token issuer and this service.
+const leeway = 30 * time.Second
+
+// Options configures a Verifier.
+type Options struct {
+	// Secret is the HS256 shared secret; empty disables HS256 tokens.
+	Secret []byte
+	// Keys are the RS256 public keys by key ID; empty disables RS256 tokens.
+	Keys map[string]*rsa.PublicKey
+	// Issuer, when set, must match the "iss" claim.
+	Issuer string
+	// Audience, when set, must be one of the "aud" claim values.
+	Audience string
+}
+
+// Claims are the registered and custom JWT claims used by the API.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Roles     []string `json:"roles"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	// Tenant, when set, is the only tenant the token is valid for.
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// Audience is the "aud" claim, which may be a single string or an array of strings.
+type Audience []string
+
+// UnmarshalJSON accepts both forms of the claim.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Verifier validates HS256 and RS256 signed JWTs.
+type Verifier struct {
+	secret   []byte
+	keys     map[string]*rsa.PublicKey
+	issuer   string
+	audience string
+}
+
+// NewVerifier creates a Verifier. At least one of a secret or RSA keys is required.
+func NewVerifier(opts Options) (*Verifier, error) {
+	if len(opts.Secret) == 0 && len(opts.Keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	return &Verifier{
+		secret:   opts.Secret,
+		keys:     opts.Keys,
+		issuer:   opts.Issuer,
+		audience: opts.Audience,
+	}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the signature and claims of a compact JWT and returns its principal.
+// The algorithm is taken from the token header, but only HS256 and RS256 with a configured
+// key are accepted, so "none" and algorithm-confusion tokens are rejected.
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err := v.verifySignature(hdr, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	if err := v.validateClaims(&claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &Principal{Subject: claims.Subject, Roles: claims.Roles, Tenant: claims.Tenant}, nil
+}
+
+// verifySignature checks the signature of signingInput with the key selected by the header.
+func (v *Verifier) verifySignature(hdr header, signingInput string, signature []byte) error {
+	switch hdr.Alg {
+	case "HS256":
+		if len(v.secret) == 0 {
+			return fmt.Errorf("%w: HS256 tokens are not accepted", ErrInvalidToken)
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	case "RS256":
+		key, err := v.rsaKey(hdr.Kid)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, hdr.Alg)
+	}
+}
+
+// rsaKey returns the key with the given ID. Tokens without a key ID are accepted only when
+// the key set holds a single key.
+func (v *Verifier) rsaKey(kid string) (*rsa.PublicKey, error) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+// validateClaims checks the time, issuer, audience and subject claims.
+func (v *Verifier) validateClaims(claims *Claims, now time.Time) error {
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+	if now.Add(-leeway).Unix() >= claims.ExpiresAt {
+		return ErrExpiredToken
+	}
+	if claims.NotBefore != 0 && now.Add(leeway).Unix() < claims.NotBefore {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.audience != "" && !containsString(claims.Audience, v.audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	if claims.Subject == "" {
+		return fmt.Errorf("%w: missing sub claim", ErrInvalidToken)
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a token.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("test-secret")
+
+func segment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, key []byte, claims any) string {
+	t.Helper()
+	input := segment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims any) string {
+	t.Helper()
+	input := segment(t, map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() auth.Claims {
+	return auth.Claims{
+		Subject:   "42",
+		Roles:     []string{auth.RoleCustomer},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("require at least one key", func(t *testing.T) {
+		_, err := auth.NewVerifier(auth.Options{})
+		assert.ErrorIs(t, err, auth.ErrNoKeys)
+	})
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	verifier, err := auth.NewVerifier(auth.Options{Secret: secret})
+	require.NoError(t, err)
+
+	t.Run("accept valid token", func(t *testing.T) {
+		principal, err := verifier.Verify(signHS256(t, secret, validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "42", principal.Subject)
+		assert.True(t, principal.HasRole(auth.RoleCustomer))
+		assert.False(t, principal.HasRole(auth.RoleAdmin))
+		assert.Empty(t, principal.Tenant)
+	})
+
+	t.Run("carry tenant claim", func(t *testing.T) {
+		claims := validClaims()
+		claims.Tenant = "shop-a"
+		principal, err := verifier.Verify(signHS256(t, secret, claims))
+		require.NoError(t, err)
+		assert.Equal(t, "shop-a", principal.Tenant)
+	})
+
+	t.Run("reject wrong secret", func(t *testing.T) {
+		_, err := verifier.Verify(signHS256(t, []byte("other"), validClaims()))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject tampered claims", func(t *testing.T) {
+		token := signHS256(t, secret, validClaims())
+		admin := validClaims()
+		admin.Roles = []string{auth.RoleAdmin}
+		forged := signHS256(t, secret, admin)
+
+		// Header and signature of the original token around the claims of another one
+		parts, forgedParts := strings.Split(token, "."), strings.Split(forged, ".")
+		_, err := verifier.Verify(parts[0] + "." + forgedParts[1] + "." + parts[2])
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject expired token", func(t *testing.T) {
+		claims := validClaims()
+		claims.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.ErrorIs(t, err, auth.ErrExpiredToken)
+	})
+
+	t.Run("reject token without exp", func(t *testing.T) {
+		claims := validClaims()
+		claims.ExpiresAt = 0
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject token that is not valid yet", func(t *testing.T) {
+		claims := validClaims()
+		claims.NotBefore = time.Now().Add(time.Hour).Unix()
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject unsigned token", func(t *testing.T) {
+		token := segment(t, map[string]string{"alg": "none"}) + "." + segment(t, validClaims()) + "."
+		_, err := verifier.Verify(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject malformed token", func(t *testing.T) {
+		_, err := verifier.Verify("not-a-token")
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
+
+func TestVerifier_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := auth.NewVerifier(auth.Options{Keys: map[string]*rsa.PublicKey{"key-1": &key.PublicKey}})
+	require.NoError(t, err)
+
+	t.Run("accept valid token", func(t *testing.T) {
+		principal, err := verifier.Verify(signRS256(t, key, "key-1", validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "42", principal.Subject)
+	})
+
+	t.Run("accept token without key ID for a single key", func(t *testing.T) {
+		_, err := verifier.Verify(signRS256(t, key, "", validClaims()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("reject unknown key ID", func(t *testing.T) {
+		_, err := verifier.Verify(signRS256(t, key, "key-2", validClaims()))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject token signed by another key", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		_, err = verifier.Verify(signRS256(t, other, "key-1", validClaims()))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject HS256 token when no secret is configured", func(t *testing.T) {
+		_, err := verifier.Verify(signHS256(t, secret, validClaims()))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
+
+func TestVerifier_IssuerAndAudience(t *testing.T) {
+	verifier, err := auth.NewVerifier(auth.Options{Secret: secret, Issuer: "https://id.example.com", Audience: "reviews"})
+	require.NoError(t, err)
+
+	t.Run("accept matching claims", func(t *testing.T) {
+		claims := map[string]any{
+			"sub": "42",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"iss": "https://id.example.com",
+			"aud": []string{"catalog", "reviews"},
+		}
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.NoError(t, err)
+	})
+
+	t.Run("accept single audience string", func(t *testing.T) {
+		claims := map[string]any{"sub": "42", "exp": time.Now().Add(time.Hour).Unix(), "iss": "https://id.example.com", "aud": "reviews"}
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.NoError(t, err)
+	})
+
+	t.Run("reject other issuer", func(t *testing.T) {
+		claims := map[string]any{"sub": "42", "exp": time.Now().Add(time.Hour).Unix(), "iss": "https://evil.example.com", "aud": "reviews"}
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("reject other audience", func(t *testing.T) {
+		claims := map[string]any{"sub": "42", "exp": time.Now().Add(time.Hour).Unix(), "iss": "https://id.example.com", "aud": "billing"}
+		_, err := verifier.Verify(signHS256(t, secret, claims))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
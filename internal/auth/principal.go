@@ -0,0 +1,59 @@
+package auth
+
+import "context"
+
+// Roles recognised by the API.
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleCustomer  = "customer"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject is the "sub" claim of the token, the ID of the user. For API keys it is the
+	// ID of the user the key was issued to.
+	Subject string
+	Roles   []string
+	// APIKeyID is the ID of the API key the request was made with, zero for bearer tokens.
+	APIKeyID int64
+	// Scopes are the scopes granted to the API key.
+	Scopes []string
+	// Tenant is the tenant the token was issued for, empty for tokens valid for every tenant.
+	Tenant string
+}
+
+// HasRole reports whether the principal has at least one of the given roles.
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, have := range p.Roles {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasScopes reports whether the principal has all of the given scopes.
+func (p *Principal) HasScopes(scopes ...string) bool {
+	for _, want := range scopes {
+		if !containsString(p.Scopes, want) {
+			return false
+		}
+	}
+	return true
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,198 @@
+// Package breaker implements a circuit breaker for calls to unreliable dependencies.
+//
+// A breaker starts closed and lets calls through, each bounded by a timeout. After a number of
+// consecutive failures it opens and rejects calls immediately with ErrOpen. Once the cooldown
+// has passed it lets a single probe call through (half-open): success closes it again, failure
+// reopens it for another cooldown.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"product_review_hub/internal/metrics"
+)
+
+// ErrOpen is returned for calls rejected by an open breaker.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a breaker.
+type State int
+
+const (
+	// Closed lets calls through.
+	Closed State = iota
+	// HalfOpen lets a single probe call through.
+	HalfOpen
+	// Open rejects calls.
+	Open
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Config holds breaker configuration.
+type Config struct {
+	// Failures is the number of consecutive failures that opens the breaker.
+	Failures int
+	// Cooldown is how long the breaker stays open before it probes the dependency.
+	Cooldown time.Duration
+	// Timeout bounds each call; calls that exceed it count as failures. Zero disables it.
+	Timeout time.Duration
+}
+
+// Breaker is a circuit breaker. A nil Breaker lets every call through without a timeout.
+type Breaker struct {
+	name string
+	cfg  Config
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+// New creates a closed breaker. The name identifies it in logs, metrics and health checks.
+func New(name string, cfg Config) *Breaker {
+	if cfg.Failures < 1 {
+		cfg.Failures = 1
+	}
+	b := &Breaker{name: name, cfg: cfg, now: time.Now}
+	metrics.BreakerState.WithLabelValues(name).Set(float64(Closed))
+	return b
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state. An open breaker whose cooldown has passed is reported as
+// half-open, since the next call will probe.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.cfg.Cooldown {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// Do calls fn with a context bounded by the timeout unless the breaker rejects the call, and
+// records its outcome. Errors of fn are returned as is; rejected calls return ErrOpen.
+func (b *Breaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b == nil {
+		return fn(ctx)
+	}
+
+	if !b.allow() {
+		metrics.BreakerRejections.WithLabelValues(b.name).Inc()
+		return fmt.Errorf("%s: %w", b.name, ErrOpen)
+	}
+
+	callCtx := ctx
+	if b.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, b.cfg.Timeout)
+		defer cancel()
+	}
+
+	err := fn(callCtx)
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up, which says nothing about the dependency
+		b.abandon()
+		return err
+	}
+	b.record(ctx, err)
+	return err
+}
+
+// Check reports an error while the breaker is open. It is the health check of the breaker.
+func (b *Breaker) Check(_ context.Context) error {
+	if b.State() == Open {
+		return fmt.Errorf("%s: %w", b.name, ErrOpen)
+	}
+	return nil
+}
+
+// allow reports whether a call may go through, moving an open breaker whose cooldown has
+// passed to half-open for a single probe.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Closed:
+		return true
+	case Open:
+		if b.now().Sub(b.openedAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.setState(HalfOpen)
+		return true
+	default:
+		// A probe is already in flight
+		return false
+	}
+}
+
+// record records the outcome of a call.
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		if b.state != Closed {
+			slog.InfoContext(ctx, "Circuit breaker closed", slog.String("breaker", b.name))
+			b.setState(Closed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.cfg.Failures {
+		if b.state != Open {
+			slog.WarnContext(ctx, "Circuit breaker opened",
+				slog.String("breaker", b.name),
+				slog.Int("failures", b.failures),
+				slog.Duration("cooldown", b.cfg.Cooldown),
+			)
+		}
+		b.openedAt = b.now()
+		b.setState(Open)
+	}
+}
+
+// abandon forgets a call whose caller gave up. An abandoned probe leaves the breaker open with
+// its cooldown passed, so that the next call probes again.
+func (b *Breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == HalfOpen {
+		b.setState(Open)
+	}
+}
+
+// setState changes the state and its metric. The caller holds mu.
+func (b *Breaker) setState(state State) {
+	b.state = state
+	metrics.BreakerState.WithLabelValues(b.name).Set(float64(state))
+}
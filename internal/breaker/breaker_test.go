@@ -0,0 +1,119 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errDown = errors.New("connection refused")
+
+func fail(context.Context) error { return errDown }
+
+func succeed(context.Context) error { return nil }
+
+// newTestBreaker creates a breaker with a clock that tests advance by hand.
+func newTestBreaker(cfg Config) (*Breaker, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := New("test", cfg)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("open after consecutive failures", func(t *testing.T) {
+		b, _ := newTestBreaker(Config{Failures: 3, Cooldown: time.Minute})
+
+		assert.ErrorIs(t, b.Do(ctx, fail), errDown)
+		assert.ErrorIs(t, b.Do(ctx, fail), errDown)
+		assert.Equal(t, Closed, b.State())
+		assert.ErrorIs(t, b.Do(ctx, fail), errDown)
+		assert.Equal(t, Open, b.State())
+
+		called := false
+		err := b.Do(ctx, func(context.Context) error {
+			called = true
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrOpen)
+		assert.False(t, called)
+		assert.ErrorIs(t, b.Check(ctx), ErrOpen)
+	})
+
+	t.Run("reset failures on success", func(t *testing.T) {
+		b, _ := newTestBreaker(Config{Failures: 2, Cooldown: time.Minute})
+
+		assert.Error(t, b.Do(ctx, fail))
+		assert.NoError(t, b.Do(ctx, succeed))
+		assert.Error(t, b.Do(ctx, fail))
+		assert.Equal(t, Closed, b.State())
+	})
+
+	t.Run("close after a successful probe", func(t *testing.T) {
+		b, now := newTestBreaker(Config{Failures: 1, Cooldown: time.Minute})
+
+		assert.Error(t, b.Do(ctx, fail))
+		*now = now.Add(time.Minute)
+		assert.Equal(t, HalfOpen, b.State())
+		assert.NoError(t, b.Check(ctx))
+
+		assert.NoError(t, b.Do(ctx, succeed))
+		assert.Equal(t, Closed, b.State())
+	})
+
+	t.Run("reopen after a failed probe", func(t *testing.T) {
+		b, now := newTestBreaker(Config{Failures: 3, Cooldown: time.Minute})
+
+		for i := 0; i < 3; i++ {
+			assert.Error(t, b.Do(ctx, fail))
+		}
+		*now = now.Add(time.Minute)
+		assert.ErrorIs(t, b.Do(ctx, fail), errDown)
+		assert.Equal(t, Open, b.State())
+		assert.ErrorIs(t, b.Do(ctx, succeed), ErrOpen)
+	})
+
+	t.Run("let a single probe through", func(t *testing.T) {
+		b, now := newTestBreaker(Config{Failures: 1, Cooldown: time.Minute})
+
+		assert.Error(t, b.Do(ctx, fail))
+		*now = now.Add(time.Minute)
+		err := b.Do(ctx, func(ctx context.Context) error {
+			assert.ErrorIs(t, b.Do(ctx, succeed), ErrOpen)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, Closed, b.State())
+	})
+
+	t.Run("fail calls that exceed the timeout", func(t *testing.T) {
+		b, _ := newTestBreaker(Config{Failures: 1, Cooldown: time.Minute, Timeout: 10 * time.Millisecond})
+
+		err := b.Do(ctx, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, Open, b.State())
+	})
+
+	t.Run("ignore calls the caller gave up on", func(t *testing.T) {
+		b, _ := newTestBreaker(Config{Failures: 1, Cooldown: time.Minute})
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.ErrorIs(t, b.Do(cancelled, func(ctx context.Context) error { return ctx.Err() }), context.Canceled)
+		assert.Equal(t, Closed, b.State())
+	})
+
+	t.Run("let every call through when nil", func(t *testing.T) {
+		var b *Breaker
+		assert.ErrorIs(t, b.Do(ctx, fail), errDown)
+		assert.NoError(t, b.Do(ctx, succeed))
+	})
+}
@@ -3,183 +3,697 @@ package cache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
+	"product_review_hub/internal/breaker"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/metrics"
 	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/tracing"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
 	// DefaultTTL is the default cache expiration time.
 	DefaultTTL = 5 * time.Minute
 
-	// Key prefixes
-	reviewsKeyPrefix = "reviews:product:"
-	ratingKeyPrefix  = "rating:product:"
+	// APIKeyTTL bounds how long a revoked API key can stay usable if its invalidation fails.
+	APIKeyTTL = time.Minute
+
+	// Key prefixes. Reviews, ratings, products and the categories version are stored under the
+	// prefix of their tenant, API keys are shared by all tenants.
+	tenantKeyPrefix         = "tenant:"
+	reviewsKeyPrefix        = "reviews:product:"
+	reviewsVersionKeyPrefix = "reviews:version:product:"
+	ratingKeyPrefix         = "rating:product:"
+	productKeyPrefix        = "product:"
+	productVersionKeyPrefix = "product:version:"
+	productsKeyPrefix       = "products:"
+	productsVersionKey      = "products:version"
+	categoriesVersionKey    = "categories:version"
+	apiKeyKeyPrefix         = "apikey:"
+
+	// invalidationChannel is the pub/sub channel of product invalidations.
+	invalidationChannel = "cache:invalidate"
+	// listenRetryDelay is how long Listen waits before retrying its subscription.
+	listenRetryDelay = time.Second
+
+	// Cache names of the lookup metrics.
+	reviewsCache  = "reviews"
+	ratingCache   = "rating"
+	productCache  = "product"
+	productsCache = "products"
 )
 
-// Service provides caching operations.
+// errDetached is returned for commands of a service without a Redis client.
+var errDetached = errors.New("cache is detached")
+
+// Service provides caching operations. A service without a Redis client is detached: lookups
+// miss and writes are skipped until a client is attached, so the API can start while Redis is
+// unavailable.
+//
+// Redis commands go through a circuit breaker when the service has one. While it is open,
+// lookups miss and writes are skipped without waiting on Redis, and invalidations fail.
+//
+// Ratings and reviews can also be kept in a small in-process tier in front of Redis, so that
+// hot products are served without a round-trip. InvalidateProductCache drops the product from
+// the tier of every instance through Redis pub/sub; entries are only kept for a few seconds,
+// which bounds their staleness when an invalidation message is lost.
 type Service struct {
-	client *redis.Client
-	ttl    time.Duration
+	client  atomic.Pointer[redis.Client]
+	ttl     time.Duration
+	breaker *breaker.Breaker
+	local   *localCache
+	// group coalesces concurrent loads of the same key.
+	group singleflight.Group
 }
 
-// NewService creates a new cache service.
+// Config holds cache service configuration.
+type Config struct {
+	// TTL is how long Redis entries are fresh.
+	TTL time.Duration
+	// Breaker, if set, is the circuit breaker of Redis commands.
+	Breaker *breaker.Breaker
+	// LocalSize is the maximum number of entries of the in-process tier. Zero disables it.
+	LocalSize int
+	// LocalTTL is how long entries are kept in the in-process tier.
+	LocalTTL time.Duration
+}
+
+// NewService creates a new cache service. A nil client creates a detached service.
 func NewService(client *redis.Client) *Service {
-	return &Service{
-		client: client,
-		ttl:    DefaultTTL,
-	}
+	return NewServiceWithTTL(client, DefaultTTL)
 }
 
 // NewServiceWithTTL creates a new cache service with custom TTL.
 func NewServiceWithTTL(client *redis.Client, ttl time.Duration) *Service {
-	return &Service{
-		client: client,
-		ttl:    ttl,
+	return NewServiceWithConfig(client, Config{TTL: ttl})
+}
+
+// NewServiceWithConfig creates a new cache service with the given configuration.
+func NewServiceWithConfig(client *redis.Client, cfg Config) *Service {
+	s := &Service{
+		ttl:     cfg.TTL,
+		breaker: cfg.Breaker,
+		local:   newLocalCache(cfg.LocalSize, cfg.LocalTTL),
 	}
+	s.client.Store(client)
+	return s
+}
+
+// Attach attaches the Redis client of a detached service.
+func (s *Service) Attach(client *redis.Client) {
+	s.client.Store(client)
+}
+
+// Attached reports whether the service has a Redis client.
+func (s *Service) Attached() bool {
+	return s.client.Load() != nil
+}
+
+// tenantPrefix generates the key prefix of the tenant of ctx.
+func tenantPrefix(ctx context.Context) string {
+	return tenantKeyPrefix + tenant.ID(ctx) + ":"
+}
+
+// productTag generates the tag of the local entries of a product, which is also the payload of
+// its invalidation messages.
+func productTag(ctx context.Context, productID int64) string {
+	return fmt.Sprintf("%sproduct:%d", tenantPrefix(ctx), productID)
+}
+
+// productsTag generates the tag of the local entries of the product lists of the tenant of ctx.
+func productsTag(ctx context.Context) string {
+	return tenantPrefix(ctx) + "products"
+}
+
+// categoriesTag generates the tag of the local entry of the categories version of the tenant of
+// ctx.
+func categoriesTag(ctx context.Context) string {
+	return tenantPrefix(ctx) + "categories"
+}
+
+// productKey generates a cache key for a representation, e.g. a currency, of the given version
+// of a product's payload.
+func productKey(ctx context.Context, productID, version int64, representation string) string {
+	return fmt.Sprintf("%s%s%d:v:%d:%s", tenantPrefix(ctx), productKeyPrefix, productID, version, representation)
+}
+
+// productVersionKey generates the key of the version counter of a product's payload.
+func productVersionKey(ctx context.Context, productID int64) string {
+	return fmt.Sprintf("%s%s%d", tenantPrefix(ctx), productVersionKeyPrefix, productID)
 }
 
-// reviewsKey generates a cache key for reviews list.
-func reviewsKey(productID int64, limit, offset int) string {
-	return fmt.Sprintf("%s%d:limit:%d:offset:%d", reviewsKeyPrefix, productID, limit, offset)
+// productsKey generates a cache key for a product list page of the given versions of the
+// tenant's product lists and categories. The query is hashed to bound the key length.
+func productsKey(ctx context.Context, version, categoriesVersion int64, query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return fmt.Sprintf("%s%sv:%d.%d:%s", tenantPrefix(ctx), productsKeyPrefix, version, categoriesVersion, hex.EncodeToString(sum[:16]))
 }
 
-// reviewsPatternKey generates a pattern key for all reviews of a product.
-func reviewsPatternKey(productID int64) string {
-	return fmt.Sprintf("%s%d:*", reviewsKeyPrefix, productID)
+// reviewsKey generates a cache key for a reviews page of the given version of a product's
+// reviews.
+func reviewsKey(ctx context.Context, productID, version int64, limit, offset int) string {
+	return fmt.Sprintf("%s%s%d:v:%d:limit:%d:offset:%d", tenantPrefix(ctx), reviewsKeyPrefix, productID, version, limit, offset)
+}
+
+// reviewsVersionKey generates the key of the version counter of a product's reviews.
+func reviewsVersionKey(ctx context.Context, productID int64) string {
+	return fmt.Sprintf("%s%s%d", tenantPrefix(ctx), reviewsVersionKeyPrefix, productID)
 }
 
 // ratingKey generates a cache key for product rating.
-func ratingKey(productID int64) string {
-	return fmt.Sprintf("%s%d", ratingKeyPrefix, productID)
+func ratingKey(ctx context.Context, productID int64) string {
+	return fmt.Sprintf("%s%s%d", tenantPrefix(ctx), ratingKeyPrefix, productID)
 }
 
-// GetReviews retrieves reviews from cache.
-func (s *Service) GetReviews(ctx context.Context, productID int64, limit, offset int) ([]models.Review, error) {
-	key := reviewsKey(productID, limit, offset)
-	data, err := s.client.Get(ctx, key).Bytes()
-	if err != nil {
-		if err == redis.Nil {
-			return nil, nil // Cache miss
+// apiKeyKey generates a cache key for an API key looked up by its hash.
+func apiKeyKey(hash string) string {
+	return apiKeyKeyPrefix + hash
+}
+
+// Reviews returns a reviews page of a product, loading it with load on a miss. Loads are
+// protected from stampedes as described for fetch.
+//
+// Cached pages are keyed by the version of the product's reviews, which InvalidateReviews
+// increments, so invalidation does not have to find the pages.
+func (s *Service) Reviews(ctx context.Context, productID int64, limit, offset int, load func(ctx context.Context) ([]models.Review, error)) ([]models.Review, error) {
+	ctx, span := tracing.Start(ctx, "cache.Service.Reviews")
+	defer span.End()
+
+	key := func(version int64) string {
+		return reviewsKey(ctx, productID, version, limit, offset)
+	}
+	data, _, err := s.versioned(ctx, reviewsCache, reviewsVersionKey(ctx, productID), productTag(ctx, productID), key, func(ctx context.Context) ([]byte, error) {
+		reviews, err := load(ctx)
+		if err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("failed to get reviews from cache: %w", err)
+		return json.Marshal(reviews)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var reviews []models.Review
 	if err := json.Unmarshal(data, &reviews); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal reviews: %w", err)
 	}
-
 	return reviews, nil
 }
 
-// SetReviews stores reviews in cache.
-func (s *Service) SetReviews(ctx context.Context, productID int64, limit, offset int, reviews []models.Review) error {
-	key := reviewsKey(productID, limit, offset)
-	data, err := json.Marshal(reviews)
-	if err != nil {
-		return fmt.Errorf("failed to marshal reviews: %w", err)
-	}
+// InvalidateReviews invalidates all cached reviews pages of a product by incrementing the
+// version of its reviews. Pages of older versions are no longer read and expire with their
+// TTL.
+func (s *Service) InvalidateReviews(ctx context.Context, productID int64) error {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateReviews")
+	defer span.End()
 
-	if err := s.client.Set(ctx, key, data, s.ttl).Err(); err != nil {
-		return fmt.Errorf("failed to set reviews in cache: %w", err)
+	err := s.incrVersion(ctx, reviewsVersionKey(ctx, productID))
+	s.local.invalidate(productTag(ctx, productID))
+	if err != nil && !errors.Is(err, errDetached) {
+		return fmt.Errorf("failed to increment reviews version: %w", err)
 	}
-
 	return nil
 }
 
-// InvalidateReviews removes all cached reviews for a product.
-func (s *Service) InvalidateReviews(ctx context.Context, productID int64) error {
-	pattern := reviewsPatternKey(productID)
-	return s.deleteByPattern(ctx, pattern)
-}
-
 // GetRating retrieves product rating from cache.
 func (s *Service) GetRating(ctx context.Context, productID int64) (*float64, bool, error) {
-	key := ratingKey(productID)
-	data, err := s.client.Get(ctx, key).Bytes()
+	ctx, span := tracing.Start(ctx, "cache.Service.GetRating")
+	defer span.End()
+
+	key := ratingKey(ctx, productID)
+	if data, ok := s.local.get(key); ok {
+		if rating, err := decodeRating(data); err == nil {
+			s.countLocal(ratingCache, metrics.CacheHit)
+			return rating, true, nil
+		}
+	}
+	s.countLocal(ratingCache, metrics.CacheMiss)
+
+	data, err := s.get(ctx, key)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, false, nil // Cache miss
+		if skipped(err) {
+			countLookup(ratingCache, metrics.CacheSkipped)
+			return nil, false, nil // Treated as a miss
 		}
+		countLookup(ratingCache, metrics.CacheError)
 		return nil, false, fmt.Errorf("failed to get rating from cache: %w", err)
 	}
+	if data == nil {
+		countLookup(ratingCache, metrics.CacheMiss)
+		return nil, false, nil // Cache miss
+	}
 
-	// Handle null rating (product has no reviews)
-	if string(data) == "null" {
-		return nil, true, nil
+	rating, err := decodeRating(data)
+	if err != nil {
+		countLookup(ratingCache, metrics.CacheError)
+		return nil, false, err
 	}
 
-	var rating float64
-	if err := json.Unmarshal(data, &rating); err != nil {
-		return nil, false, fmt.Errorf("failed to unmarshal rating: %w", err)
+	countLookup(ratingCache, metrics.CacheHit)
+	s.local.set(key, productTag(ctx, productID), data)
+	return rating, true, nil
+}
+
+// GetRatings retrieves the ratings of several products from cache with a single MGET. The
+// result only holds the cached products; a nil rating means the product has no reviews.
+func (s *Service) GetRatings(ctx context.Context, productIDs []int64) (map[int64]*float64, error) {
+	ctx, span := tracing.Start(ctx, "cache.Service.GetRatings")
+	defer span.End()
+
+	ratings := make(map[int64]*float64, len(productIDs))
+	if len(productIDs) == 0 {
+		return ratings, nil
+	}
+
+	// Look up the local tier first and the remaining products in Redis
+	var missing []int64
+	var keys []string
+	for _, id := range productIDs {
+		key := ratingKey(ctx, id)
+		if data, ok := s.local.get(key); ok {
+			if rating, err := decodeRating(data); err == nil {
+				s.countLocal(ratingCache, metrics.CacheHit)
+				ratings[id] = rating
+				continue
+			}
+		}
+		s.countLocal(ratingCache, metrics.CacheMiss)
+		missing = append(missing, id)
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return ratings, nil
 	}
 
-	return &rating, true, nil
+	var values []interface{}
+	err := s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		var err error
+		values, err = client.MGet(ctx, keys...).Result()
+		return err
+	})
+	if err != nil {
+		if skipped(err) {
+			countLookups(ratingCache, metrics.CacheSkipped, len(keys))
+			return ratings, nil // Treated as misses
+		}
+		countLookups(ratingCache, metrics.CacheError, len(keys))
+		return nil, fmt.Errorf("failed to get ratings from cache: %w", err)
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			countLookup(ratingCache, metrics.CacheMiss)
+			continue // Cache miss
+		}
+		rating, err := decodeRating([]byte(data))
+		if err != nil {
+			// A corrupt entry is a miss; the caller overwrites it
+			countLookup(ratingCache, metrics.CacheError)
+			continue
+		}
+		countLookup(ratingCache, metrics.CacheHit)
+		ratings[missing[i]] = rating
+		s.local.set(keys[i], productTag(ctx, missing[i]), []byte(data))
+	}
+
+	return ratings, nil
 }
 
 // SetRating stores product rating in cache.
 func (s *Service) SetRating(ctx context.Context, productID int64, rating *float64) error {
-	key := ratingKey(productID)
+	ctx, span := tracing.Start(ctx, "cache.Service.SetRating")
+	defer span.End()
+
 	data, err := json.Marshal(rating)
 	if err != nil {
 		return fmt.Errorf("failed to marshal rating: %w", err)
 	}
 
-	if err := s.client.Set(ctx, key, data, s.ttl).Err(); err != nil {
+	key := ratingKey(ctx, productID)
+	s.local.set(key, productTag(ctx, productID), data)
+	if err := s.set(ctx, key, data, s.ttl); err != nil && !skipped(err) {
 		return fmt.Errorf("failed to set rating in cache: %w", err)
 	}
 
 	return nil
 }
 
+// SetRatings stores the ratings of several products in cache with a single pipeline.
+func (s *Service) SetRatings(ctx context.Context, ratings map[int64]*float64) error {
+	ctx, span := tracing.Start(ctx, "cache.Service.SetRatings")
+	defer span.End()
+
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	entries := make(map[string][]byte, len(ratings))
+	for id, rating := range ratings {
+		data, err := json.Marshal(rating)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rating: %w", err)
+		}
+		key := ratingKey(ctx, id)
+		entries[key] = data
+		s.local.set(key, productTag(ctx, id), data)
+	}
+
+	err := s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for key, data := range entries {
+				pipe.Set(ctx, key, data, s.ttl)
+			}
+			return nil
+		})
+		return err
+	})
+	if err != nil && !skipped(err) {
+		return fmt.Errorf("failed to set ratings in cache: %w", err)
+	}
+
+	return nil
+}
+
 // InvalidateRating removes cached rating for a product.
 func (s *Service) InvalidateRating(ctx context.Context, productID int64) error {
-	key := ratingKey(productID)
-	if err := s.client.Del(ctx, key).Err(); err != nil {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateRating")
+	defer span.End()
+
+	err := s.del(ctx, ratingKey(ctx, productID))
+	s.local.invalidate(productTag(ctx, productID))
+	if err != nil && !errors.Is(err, errDetached) {
 		return fmt.Errorf("failed to delete rating from cache: %w", err)
 	}
 	return nil
 }
 
-// InvalidateProductCache removes all cached data for a product (reviews and rating).
+// GetAPIKey retrieves an API key by its hash from cache.
+func (s *Service) GetAPIKey(ctx context.Context, hash string) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "cache.Service.GetAPIKey")
+	defer span.End()
+
+	data, err := s.get(ctx, apiKeyKey(hash))
+	if err != nil {
+		if skipped(err) {
+			return nil, nil // Treated as a miss
+		}
+		return nil, fmt.Errorf("failed to get api key from cache: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Cache miss
+	}
+
+	var key models.APIKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// SetAPIKey stores an API key in cache under its hash.
+func (s *Service) SetAPIKey(ctx context.Context, key *models.APIKey) error {
+	ctx, span := tracing.Start(ctx, "cache.Service.SetAPIKey")
+	defer span.End()
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal api key: %w", err)
+	}
+
+	if err := s.set(ctx, apiKeyKey(key.KeyHash), data, APIKeyTTL); err != nil && !skipped(err) {
+		return fmt.Errorf("failed to set api key in cache: %w", err)
+	}
+
+	return nil
+}
+
+// InvalidateAPIKey removes a cached API key.
+func (s *Service) InvalidateAPIKey(ctx context.Context, hash string) error {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateAPIKey")
+	defer span.End()
+
+	if err := s.del(ctx, apiKeyKey(hash)); err != nil && !errors.Is(err, errDetached) {
+		return fmt.Errorf("failed to delete api key from cache: %w", err)
+	}
+	return nil
+}
+
+// Product returns the serialized payload of a product in the given representation, e.g. a
+// currency, and the time until which it is fresh, loading it with load on a miss. Loads are
+// protected from stampedes as described for fetch. Payloads are keyed by the version of the
+// product, which InvalidateProduct increments.
+func (s *Service) Product(ctx context.Context, productID int64, representation string, load LoadFunc) ([]byte, time.Time, error) {
+	ctx, span := tracing.Start(ctx, "cache.Service.Product")
+	defer span.End()
+
+	key := func(version int64) string {
+		return productKey(ctx, productID, version, representation)
+	}
+	return s.versioned(ctx, productCache, productVersionKey(ctx, productID), productTag(ctx, productID), key, load)
+}
+
+// Products returns a serialized product list page and the time until which it is fresh,
+// loading it with load on a miss. query identifies the page and must hold every parameter it
+// depends on, e.g. the canonical query string. Pages are keyed by the version of the tenant's
+// product lists, which InvalidateProducts and InvalidateProduct increment, since any product
+// change can move products between pages, and by the version of the tenant's categories, which
+// InvalidateCategories increments, since lists filter by the category tree.
+func (s *Service) Products(ctx context.Context, query string, load LoadFunc) ([]byte, time.Time, error) {
+	ctx, span := tracing.Start(ctx, "cache.Service.Products")
+	defer span.End()
+
+	categoriesVersion, err := s.version(ctx, tenantPrefix(ctx)+categoriesVersionKey, categoriesTag(ctx))
+	if err != nil {
+		return s.uncached(ctx, productsCache, tenantPrefix(ctx)+categoriesVersionKey, productsKey(ctx, noVersion, noVersion, query), err, load)
+	}
+	key := func(version int64) string {
+		return productsKey(ctx, version, categoriesVersion, query)
+	}
+	return s.versioned(ctx, productsCache, tenantPrefix(ctx)+productsVersionKey, productsTag(ctx), key, load)
+}
+
+// InvalidateProduct invalidates the cached payloads of a product and the product lists of its
+// tenant, in this and the other instances.
+func (s *Service) InvalidateProduct(ctx context.Context, productID int64) {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateProduct")
+	defer span.End()
+
+	err := s.incrVersion(ctx, productVersionKey(ctx, productID), tenantPrefix(ctx)+productsVersionKey)
+	s.local.invalidate(productTag(ctx, productID))
+	s.local.invalidate(productsTag(ctx))
+	if err != nil && !errors.Is(err, errDetached) {
+		slog.WarnContext(ctx, "Failed to invalidate product cache", logging.Error(err), slog.Int64("product_id", productID))
+	}
+	s.publishInvalidation(ctx, productTag(ctx, productID), productsTag(ctx))
+}
+
+// InvalidateProducts invalidates the cached product lists of the tenant of ctx, in this and the
+// other instances.
+func (s *Service) InvalidateProducts(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateProducts")
+	defer span.End()
+
+	err := s.incrVersion(ctx, tenantPrefix(ctx)+productsVersionKey)
+	s.local.invalidate(productsTag(ctx))
+	if err != nil && !errors.Is(err, errDetached) {
+		slog.WarnContext(ctx, "Failed to invalidate product lists cache", logging.Error(err))
+	}
+	s.publishInvalidation(ctx, productsTag(ctx))
+}
+
+// InvalidateCategories invalidates the cached product lists of the tenant of ctx, which filter by
+// the tenant's category tree, in this and the other instances.
+func (s *Service) InvalidateCategories(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateCategories")
+	defer span.End()
+
+	err := s.incrVersion(ctx, tenantPrefix(ctx)+categoriesVersionKey)
+	s.local.invalidate(categoriesTag(ctx))
+	if err != nil && !errors.Is(err, errDetached) {
+		slog.WarnContext(ctx, "Failed to invalidate categories cache", logging.Error(err))
+	}
+	s.publishInvalidation(ctx, categoriesTag(ctx))
+}
+
+// InvalidateProductCache removes all cached data for a product (reviews, rating and payload)
+// and the product lists of its tenant, which include the rating, in this and the other
+// instances.
 func (s *Service) InvalidateProductCache(ctx context.Context, productID int64) {
+	ctx, span := tracing.Start(ctx, "cache.Service.InvalidateProductCache")
+	defer span.End()
+
 	// Invalidate reviews cache
 	if err := s.InvalidateReviews(ctx, productID); err != nil {
-		log.Printf("Failed to invalidate reviews cache for product %d: %v", productID, err)
+		slog.WarnContext(ctx, "Failed to invalidate reviews cache", logging.Error(err), slog.Int64("product_id", productID))
 	}
 
 	// Invalidate rating cache
 	if err := s.InvalidateRating(ctx, productID); err != nil {
-		log.Printf("Failed to invalidate rating cache for product %d: %v", productID, err)
+		slog.WarnContext(ctx, "Failed to invalidate rating cache", logging.Error(err), slog.Int64("product_id", productID))
 	}
+
+	// Invalidate the product payload and lists; this also tells the other instances to drop
+	// the product from their in-process tier
+	s.InvalidateProduct(ctx, productID)
 }
 
-// deleteByPattern deletes all keys matching the pattern using SCAN.
-func (s *Service) deleteByPattern(ctx context.Context, pattern string) error {
-	var cursor uint64
-	for {
-		keys, nextCursor, err := s.client.Scan(ctx, cursor, pattern, 100).Result()
-		if err != nil {
-			return fmt.Errorf("failed to scan keys: %w", err)
+// publishInvalidation tells the other instances to drop the entries of tags from their
+// in-process tier.
+func (s *Service) publishInvalidation(ctx context.Context, tags ...string) {
+	if s.local == nil {
+		return
+	}
+	err := s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, tag := range tags {
+				pipe.Publish(ctx, invalidationChannel, tag)
+			}
+			return nil
+		})
+		return err
+	})
+	if err != nil && !errors.Is(err, errDetached) {
+		slog.WarnContext(ctx, "Failed to publish cache invalidation", logging.Error(err), slog.Any("tags", tags))
+	}
+}
+
+// ClearLocal drops all entries of the in-process tier, e.g. after Redis was flushed.
+func (s *Service) ClearLocal() {
+	s.local.clear()
+}
+
+// Listen drops the entries of the in-process tier invalidated by other instances until ctx is
+// done. It waits for a Redis client to be attached, and returns at once without an in-process
+// tier. Invalidations published while the subscription is down are lost, so the whole tier is
+// dropped whenever it is (re)established.
+func (s *Service) Listen(ctx context.Context) {
+	if s.local == nil {
+		return
+	}
+
+	var client *redis.Client
+	for client = s.client.Load(); client == nil; client = s.client.Load() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
 		}
+	}
 
-		if len(keys) > 0 {
-			if err := s.client.Del(ctx, keys...).Err(); err != nil {
-				return fmt.Errorf("failed to delete keys: %w", err)
+	pubsub := client.Subscribe(ctx, invalidationChannel)
+	defer pubsub.Close()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			// The subscription reconnects on the next receive
+			slog.WarnContext(ctx, "Cache invalidation subscription failed", logging.Error(err))
+			s.local.clear()
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(listenRetryDelay):
+			}
+			continue
 		}
 
-		cursor = nextCursor
-		if cursor == 0 {
-			break
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			s.local.clear()
+		case *redis.Message:
+			s.local.invalidate(msg.Payload)
 		}
 	}
+}
+
+// countLocal counts a lookup of the named cache in the in-process tier, if there is one.
+func (s *Service) countLocal(cache, result string) {
+	if s.local != nil {
+		metrics.CacheLocalRequests.WithLabelValues(cache, result).Inc()
+	}
+}
 
-	return nil
+// countLookup counts a lookup of the named cache with its result.
+func countLookup(cache, result string) {
+	metrics.CacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// countLookups counts n lookups of the named cache with the same result.
+func countLookups(cache, result string, n int) {
+	metrics.CacheRequests.WithLabelValues(cache, result).Add(float64(n))
+}
+
+// decodeRating decodes a cached rating; "null" is the rating of a product without reviews.
+func decodeRating(data []byte) (*float64, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+	var rating float64
+	if err := json.Unmarshal(data, &rating); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rating: %w", err)
+	}
+	return &rating, nil
+}
+
+// skipped reports whether err means that a command was not sent to Redis, because the service
+// is detached or its circuit breaker is open. Lookups are then treated as misses and writes
+// are dropped.
+func skipped(err error) bool {
+	return errors.Is(err, errDetached) || errors.Is(err, breaker.ErrOpen)
+}
+
+// do runs a Redis command through the circuit breaker.
+func (s *Service) do(ctx context.Context, cmd func(ctx context.Context, client *redis.Client) error) error {
+	client := s.client.Load()
+	if client == nil {
+		return errDetached
+	}
+	return s.breaker.Do(ctx, func(ctx context.Context) error {
+		return cmd(ctx, client)
+	})
+}
+
+// get returns the value of a key, or nil if the key does not exist.
+func (s *Service) get(ctx context.Context, key string) ([]byte, error) {
+	var data []byte
+	err := s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		var err error
+		data, err = client.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return nil // A miss is not a failure of Redis
+		}
+		return err
+	})
+	return data, err
+}
+
+// set stores the value of a key with a TTL.
+func (s *Service) set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	return s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		return client.Set(ctx, key, data, ttl).Err()
+	})
+}
+
+// del deletes keys.
+func (s *Service) del(ctx context.Context, keys ...string) error {
+	return s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		return client.Del(ctx, keys...).Err()
+	})
 }
@@ -0,0 +1,349 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/metrics"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultStaleTTL is how long an entry is served stale after its TTL while it is refreshed
+	// in the background.
+	DefaultStaleTTL = time.Minute
+
+	// lockTTL bounds how long an instance may hold the lock of a key while it loads the value.
+	lockTTL = 5 * time.Second
+	// lockWait is how long an instance that did not get the lock waits for the holder to store
+	// the value before loading it itself.
+	lockWait = time.Second
+	// lockPoll is how often a waiting instance checks for the value.
+	lockPoll = 50 * time.Millisecond
+	// refreshTimeout bounds a background refresh.
+	refreshTimeout = 10 * time.Second
+
+	lockKeyPrefix = "lock:"
+)
+
+// unlockScript deletes a lock only if it still holds the token of its owner, so that an
+// instance whose lock expired does not release the lock of another.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// noVersion is the version of loads made without reading the version counter.
+const noVersion int64 = -1
+
+// LoadFunc loads the value of a cache entry from the source of truth.
+type LoadFunc func(ctx context.Context) ([]byte, error)
+
+// fetch returns the value of key and the time until which it is fresh, loading it with load on
+// a miss. Fresh values are also kept in the in-process tier under tag. It protects the source
+// of truth from stampedes on hot keys:
+//
+//   - concurrent misses of the same key in this process share a single load;
+//   - across instances, only the holder of a short Redis lock loads the value while the others
+//     wait briefly for it to be stored;
+//   - entries are kept for DefaultStaleTTL past their TTL and served stale while a single
+//     background refresh replaces them, so hot keys do not expire under load.
+//
+// Errors of load are returned as is. Cache failures are logged and fall back to load.
+func (s *Service) fetch(ctx context.Context, cache, key, tag string, load LoadFunc) ([]byte, time.Time, error) {
+	if data, ok := s.local.get(key); ok {
+		if value, freshUntil, err := decodeEntry(data); err == nil && time.Now().Before(freshUntil) {
+			s.countLocal(cache, metrics.CacheHit)
+			return value, freshUntil, nil
+		}
+	}
+	s.countLocal(cache, metrics.CacheMiss)
+
+	data, err := s.get(ctx, key)
+	if err != nil {
+		if skipped(err) {
+			countLookup(cache, metrics.CacheSkipped)
+		} else {
+			countLookup(cache, metrics.CacheError)
+			slog.WarnContext(ctx, "Failed to get cache entry", logging.Error(err), slog.String("key", key))
+		}
+		data, err := s.coalesce(ctx, key, s.loadEntry(load))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return decodeEntry(data)
+	}
+
+	if data != nil {
+		value, freshUntil, err := decodeEntry(data)
+		if err == nil {
+			if time.Now().Before(freshUntil) {
+				countLookup(cache, metrics.CacheHit)
+				s.local.set(key, tag, data)
+			} else {
+				countLookup(cache, metrics.CacheStale)
+				s.refresh(ctx, key, load)
+			}
+			return value, freshUntil, nil
+		}
+		// A corrupt entry is a miss; loading overwrites it
+		slog.WarnContext(ctx, "Failed to decode cache entry", logging.Error(err), slog.String("key", key))
+	}
+
+	countLookup(cache, metrics.CacheMiss)
+	data, err = s.coalesce(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return s.fill(ctx, key, load)
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	s.local.set(key, tag, data)
+	return decodeEntry(data)
+}
+
+// versioned is fetch for keys that embed a version counter, which is incremented to invalidate
+// all keys of the version at once. key generates the key of a version. The version is read
+// before the value is loaded, so a value loaded before a concurrent invalidation is stored
+// under the old version and never served.
+//
+// Version keys have no TTL: a version that expired or was evicted would restart at 0 and could
+// make values cached under an old version current again.
+func (s *Service) versioned(ctx context.Context, cache, versionKey, tag string, key func(version int64) string, load LoadFunc) ([]byte, time.Time, error) {
+	version, err := s.version(ctx, versionKey, tag)
+	if err != nil {
+		return s.uncached(ctx, cache, versionKey, key(noVersion), err, load)
+	}
+	return s.fetch(ctx, cache, key(version), tag, load)
+}
+
+// uncached loads a value whose version counter at versionKey could not be read with err.
+// Without the version nothing can be read or stored, but concurrent loads of key are still
+// coalesced.
+func (s *Service) uncached(ctx context.Context, cache, versionKey, key string, err error, load LoadFunc) ([]byte, time.Time, error) {
+	if skipped(err) {
+		countLookup(cache, metrics.CacheSkipped)
+	} else {
+		countLookup(cache, metrics.CacheError)
+		slog.WarnContext(ctx, "Failed to get cache version", logging.Error(err), slog.String("key", versionKey))
+	}
+	data, err := s.coalesce(ctx, key, s.loadEntry(load))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return decodeEntry(data)
+}
+
+// version reads a version counter, also from the in-process tier. Missing counters are 0.
+func (s *Service) version(ctx context.Context, key, tag string) (int64, error) {
+	data, ok := s.local.get(key)
+	if !ok {
+		var err error
+		data, err = s.get(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		if data == nil {
+			data = []byte("0")
+		}
+		s.local.set(key, tag, data)
+	}
+	version, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse cache version: %w", err)
+	}
+	return version, nil
+}
+
+// incrVersion increments version counters, invalidating the keys of their current versions.
+func (s *Service) incrVersion(ctx context.Context, keys ...string) error {
+	return s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.Incr(ctx, key)
+			}
+			return nil
+		})
+		return err
+	})
+}
+
+// loadEntry wraps load to return the value as an entry fresh for the TTL.
+func (s *Service) loadEntry(load LoadFunc) LoadFunc {
+	return func(ctx context.Context) ([]byte, error) {
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return encodeEntry(value, time.Now().Add(s.ttl)), nil
+	}
+}
+
+// coalesce runs fn once for concurrent callers with the same key. fn runs without the
+// cancellation of the first caller, so that callers giving up do not fail the others, while
+// each caller still returns as soon as its own context is done.
+func (s *Service) coalesce(ctx context.Context, key string, fn LoadFunc) ([]byte, error) {
+	ch := s.group.DoChan(key, func() (interface{}, error) {
+		return fn(context.WithoutCancel(ctx))
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fill loads the value of a missing key under its Redis lock and stores it, returning its entry.
+// Without the lock it waits for the holder to store the value, and loads it itself if that takes
+// too long.
+func (s *Service) fill(ctx context.Context, key string, load LoadFunc) ([]byte, error) {
+	unlock, locked := s.lock(ctx, key)
+	if !locked {
+		if data, ok := s.await(ctx, key); ok {
+			return data, nil
+		}
+	}
+	defer unlock()
+
+	data, err := s.loadEntry(load)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.store(ctx, key, data)
+	return data, nil
+}
+
+// refresh reloads the value of a stale key in the background. Only one refresh of a key runs
+// per process, and only one across instances, the holder of its lock.
+func (s *Service) refresh(ctx context.Context, key string, load LoadFunc) {
+	ctx = context.WithoutCancel(ctx)
+	go s.group.Do(lockKeyPrefix+key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+		defer cancel()
+
+		unlock, locked := s.lock(ctx, key)
+		if !locked {
+			return nil, nil // Another instance is refreshing the key
+		}
+		defer unlock()
+
+		data, err := s.loadEntry(load)(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to refresh cache entry", logging.Error(err), slog.String("key", key))
+			return nil, nil
+		}
+		s.store(ctx, key, data)
+		return nil, nil
+	})
+}
+
+// lock acquires the Redis lock of key. When Redis cannot be reached it reports the lock as
+// acquired, since there is nothing to coordinate with.
+func (s *Service) lock(ctx context.Context, key string) (unlock func(), locked bool) {
+	token, err := newToken()
+	if err != nil {
+		return func() {}, true
+	}
+
+	lockKey := lockKeyPrefix + key
+	err = s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+		var err error
+		locked, err = client.SetNX(ctx, lockKey, token, lockTTL).Result()
+		return err
+	})
+	if err != nil {
+		return func() {}, true
+	}
+	if !locked {
+		return func() {}, false
+	}
+
+	return func() {
+		err := s.do(ctx, func(ctx context.Context, client *redis.Client) error {
+			return unlockScript.Run(ctx, client, []string{lockKey}, token).Err()
+		})
+		if err != nil && !skipped(err) {
+			// The lock expires with its TTL
+			slog.WarnContext(ctx, "Failed to release cache lock", logging.Error(err), slog.String("key", key))
+		}
+	}, true
+}
+
+// await waits for the holder of the lock of key to store its value and returns its entry.
+func (s *Service) await(ctx context.Context, key string) ([]byte, bool) {
+	ticker := time.NewTicker(lockPoll)
+	defer ticker.Stop()
+	deadline := time.After(lockWait)
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			return nil, false
+		case <-ctx.Done():
+			return nil, false
+		}
+
+		data, err := s.get(ctx, key)
+		if err != nil {
+			return nil, false
+		}
+		if data == nil {
+			continue
+		}
+		if _, _, err := decodeEntry(data); err != nil {
+			return nil, false
+		}
+		return data, true
+	}
+}
+
+// store stores the entry of key, kept stale for DefaultStaleTTL after its TTL.
+func (s *Service) store(ctx context.Context, key string, data []byte) {
+	if err := s.set(ctx, key, data, s.ttl+DefaultStaleTTL); err != nil && !skipped(err) {
+		slog.WarnContext(ctx, "Failed to set cache entry", logging.Error(err), slog.String("key", key))
+	}
+}
+
+// encodeEntry prefixes a value with the time until which it is fresh, in Unix milliseconds.
+func encodeEntry(value []byte, freshUntil time.Time) []byte {
+	data := strconv.AppendInt(nil, freshUntil.UnixMilli(), 10)
+	data = append(data, '|')
+	return append(data, value...)
+}
+
+// decodeEntry splits an entry into its value and the time until which it is fresh.
+func decodeEntry(data []byte) ([]byte, time.Time, error) {
+	i := bytes.IndexByte(data, '|')
+	if i < 0 {
+		return nil, time.Time{}, errors.New("missing freshness prefix")
+	}
+	ms, err := strconv.ParseInt(string(data[:i]), 10, 64)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid freshness prefix: %w", err)
+	}
+	return data[i+1:], time.UnixMilli(ms), nil
+}
+
+// newToken generates a random lock token.
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		freshUntil := time.UnixMilli(1700000000123)
+		value, decodedFreshUntil, err := decodeEntry(encodeEntry([]byte(`[{"id":1}]`), freshUntil))
+		require.NoError(t, err)
+		assert.Equal(t, `[{"id":1}]`, string(value))
+		assert.True(t, freshUntil.Equal(decodedFreshUntil))
+	})
+
+	t.Run("reject entries without freshness", func(t *testing.T) {
+		for _, data := range []string{`[{"id":1}]`, `abc|[]`} {
+			_, _, err := decodeEntry([]byte(data))
+			assert.Error(t, err, data)
+		}
+	})
+}
+
+func TestReviewsCoalescing(t *testing.T) {
+	// A detached service cannot cache, but still coalesces concurrent loads
+	s := NewService(nil)
+
+	t.Run("share a load between concurrent callers", func(t *testing.T) {
+		var loads atomic.Int32
+		release := make(chan struct{})
+		load := func(ctx context.Context) ([]models.Review, error) {
+			loads.Add(1)
+			<-release
+			return []models.Review{{ID: 1}}, nil
+		}
+
+		const callers = 10
+		var wg sync.WaitGroup
+		results := make([][]models.Review, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				reviews, err := s.Reviews(context.Background(), 1, 10, 0, load)
+				assert.NoError(t, err)
+				results[i] = reviews
+			}(i)
+		}
+
+		// Let the callers join the load before it completes
+		require.Eventually(t, func() bool { return loads.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), loads.Load())
+		for _, reviews := range results {
+			assert.Equal(t, []models.Review{{ID: 1}}, reviews)
+		}
+	})
+
+	t.Run("load distinct pages separately", func(t *testing.T) {
+		var loads atomic.Int32
+		load := func(ctx context.Context) ([]models.Review, error) {
+			loads.Add(1)
+			return nil, nil
+		}
+
+		_, err := s.Reviews(context.Background(), 1, 10, 0, load)
+		require.NoError(t, err)
+		_, err = s.Reviews(context.Background(), 1, 10, 10, load)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), loads.Load())
+	})
+
+	t.Run("return load errors", func(t *testing.T) {
+		errLoad := errors.New("load failed")
+		_, err := s.Reviews(context.Background(), 1, 10, 0, func(ctx context.Context) ([]models.Review, error) {
+			return nil, errLoad
+		})
+		assert.ErrorIs(t, err, errLoad)
+	})
+
+	t.Run("return when the caller gives up", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := s.Reviews(ctx, 2, 10, 0, func(ctx context.Context) ([]models.Review, error) {
+			<-release
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestProductKeys(t *testing.T) {
+	ctx := context.Background()
+	shopA := tenant.WithID(ctx, "shop-a")
+
+	t.Run("key products by version and representation", func(t *testing.T) {
+		assert.Equal(t, "tenant:default:product:1:v:2:base", productKey(ctx, 1, 2, "base"))
+		assert.NotEqual(t, productKey(ctx, 1, 2, "base"), productKey(ctx, 1, 3, "base"))
+		assert.NotEqual(t, productKey(ctx, 1, 2, "base"), productKey(ctx, 1, 2, "EUR"))
+		assert.NotEqual(t, productKey(ctx, 1, 2, "base"), productKey(shopA, 1, 2, "base"))
+	})
+
+	t.Run("key product lists by versions, query and tenant", func(t *testing.T) {
+		key := productsKey(ctx, 1, 1, "limit=10&tag=a")
+		assert.Equal(t, key, productsKey(ctx, 1, 1, "limit=10&tag=a"))
+		assert.NotEqual(t, key, productsKey(ctx, 2, 1, "limit=10&tag=a"))
+		assert.NotEqual(t, key, productsKey(ctx, 1, 2, "limit=10&tag=a"))
+		assert.NotEqual(t, key, productsKey(ctx, 1, 1, "limit=10&tag=b"))
+		assert.NotEqual(t, key, productsKey(shopA, 1, 1, "limit=10&tag=a"))
+	})
+}
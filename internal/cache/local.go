@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// localCache is a bounded in-process LRU cache whose entries expire after a TTL. Each entry
+// carries a tag, e.g. its product, so that all entries of a tag can be dropped at once.
+type localCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // Most recently used first
+	entries map[string]*list.Element
+	tags    map[string]map[string]struct{}
+}
+
+// localEntry is an entry of a localCache.
+type localEntry struct {
+	key       string
+	tag       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// newLocalCache creates a local cache of at most size entries kept for ttl. It returns nil,
+// which disables the tier, when size or ttl is not positive.
+func newLocalCache(size int, ttl time.Duration) *localCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &localCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		tags:    make(map[string]map[string]struct{}),
+	}
+}
+
+// get returns the value of key unless it is missing or expired. A nil cache always misses.
+func (c *localCache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*localEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// set stores the value of key under tag, evicting the least recently used entry when the
+// cache is full.
+func (c *localCache) set(key, tag string, value []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	for c.order.Len() >= c.size {
+		c.remove(c.order.Back())
+	}
+
+	c.entries[key] = c.order.PushFront(&localEntry{
+		key:       key,
+		tag:       tag,
+		value:     value,
+		expiresAt: c.now().Add(c.ttl),
+	})
+	keys, ok := c.tags[tag]
+	if !ok {
+		keys = make(map[string]struct{})
+		c.tags[tag] = keys
+	}
+	keys[key] = struct{}{}
+}
+
+// invalidate drops all entries of tag.
+func (c *localCache) invalidate(tag string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.tags[tag] {
+		c.remove(c.entries[key])
+	}
+}
+
+// clear drops all entries.
+func (c *localCache) clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.tags = make(map[string]map[string]struct{})
+}
+
+// remove removes an entry. The caller holds mu.
+func (c *localCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*localEntry)
+	delete(c.entries, entry.key)
+	if keys := c.tags[entry.tag]; keys != nil {
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.tags, entry.tag)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCache(t *testing.T) {
+	newCache := func(size int) (*localCache, *time.Time) {
+		now := time.Unix(1700000000, 0)
+		c := newLocalCache(size, 5*time.Second)
+		c.now = func() time.Time { return now }
+		return c, &now
+	}
+
+	t.Run("disable without size or TTL", func(t *testing.T) {
+		assert.Nil(t, newLocalCache(0, time.Second))
+		assert.Nil(t, newLocalCache(10, 0))
+
+		var c *localCache
+		c.set("a", "tag", []byte("1"))
+		_, ok := c.get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("expire entries after the TTL", func(t *testing.T) {
+		c, now := newCache(10)
+		c.set("a", "tag", []byte("1"))
+
+		*now = now.Add(4 * time.Second)
+		value, ok := c.get("a")
+		require.True(t, ok)
+		assert.Equal(t, "1", string(value))
+
+		*now = now.Add(time.Second)
+		_, ok = c.get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("evict the least recently used entry", func(t *testing.T) {
+		c, _ := newCache(2)
+		c.set("a", "tag", []byte("1"))
+		c.set("b", "tag", []byte("2"))
+		_, ok := c.get("a")
+		require.True(t, ok)
+
+		c.set("c", "tag", []byte("3"))
+
+		_, ok = c.get("b")
+		assert.False(t, ok)
+		_, ok = c.get("a")
+		assert.True(t, ok)
+		_, ok = c.get("c")
+		assert.True(t, ok)
+	})
+
+	t.Run("replace entries", func(t *testing.T) {
+		c, _ := newCache(2)
+		c.set("a", "tag", []byte("1"))
+		c.set("a", "tag", []byte("2"))
+
+		value, ok := c.get("a")
+		require.True(t, ok)
+		assert.Equal(t, "2", string(value))
+		assert.Equal(t, 1, c.order.Len())
+	})
+
+	t.Run("invalidate the entries of a tag", func(t *testing.T) {
+		c, _ := newCache(10)
+		c.set("a", "product:1", []byte("1"))
+		c.set("b", "product:1", []byte("2"))
+		c.set("c", "product:2", []byte("3"))
+
+		c.invalidate("product:1")
+
+		_, ok := c.get("a")
+		assert.False(t, ok)
+		_, ok = c.get("b")
+		assert.False(t, ok)
+		_, ok = c.get("c")
+		assert.True(t, ok)
+		assert.NotContains(t, c.tags, "product:1")
+	})
+
+	t.Run("clear all entries", func(t *testing.T) {
+		c, _ := newCache(10)
+		c.set("a", "product:1", []byte("1"))
+		c.clear()
+
+		_, ok := c.get("a")
+		assert.False(t, ok)
+		assert.Empty(t, c.tags)
+	})
+}
+
+func TestLocalRatings(t *testing.T) {
+	// Without Redis, ratings are only kept in the local tier
+	s := NewServiceWithConfig(nil, Config{TTL: DefaultTTL, LocalSize: 10, LocalTTL: time.Minute})
+	ctx := context.Background()
+	rating := 4.5
+
+	require.NoError(t, s.SetRatings(ctx, map[int64]*float64{1: &rating, 2: nil}))
+
+	cached, ok, err := s.GetRating(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, rating, *cached)
+
+	ratings, err := s.GetRatings(ctx, []int64{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]*float64{1: &rating, 2: nil}, ratings)
+
+	s.InvalidateProductCache(ctx, 1)
+
+	_, ok, err = s.GetRating(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,287 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"product_review_hub/internal/metrics"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tracing"
+)
+
+// sweepInterval is how often a Memory cache drops its expired entries.
+const sweepInterval = time.Minute
+
+// Memory is an in-process cache with the entries, versions and invalidations of Service, for a
+// single instance without Redis, e.g. tests. Entries expire after their TTL; version counters,
+// like in Redis, never do.
+//
+// Unlike Service, it does not serve stale entries or coalesce concurrent loads.
+type Memory struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	versions  map[string]int64
+	lastSweep time.Time
+}
+
+// memoryEntry is an entry of a Memory cache.
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemory creates an in-memory cache whose entries are fresh for ttl.
+func NewMemory(ttl time.Duration) *Memory {
+	return &Memory{
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]memoryEntry),
+		versions: make(map[string]int64),
+	}
+}
+
+// Clear drops all entries and versions.
+func (m *Memory) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]memoryEntry)
+	m.versions = make(map[string]int64)
+}
+
+// Reviews returns a reviews page of a product, loading it with load on a miss.
+func (m *Memory) Reviews(ctx context.Context, productID int64, limit, offset int, load func(ctx context.Context) ([]models.Review, error)) ([]models.Review, error) {
+	ctx, span := tracing.Start(ctx, "cache.Memory.Reviews")
+	defer span.End()
+
+	version := m.version(reviewsVersionKey(ctx, productID))
+	data, _, err := m.fetch(ctx, reviewsCache, reviewsKey(ctx, productID, version, limit, offset), func(ctx context.Context) ([]byte, error) {
+		reviews, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(reviews)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []models.Review
+	if err := json.Unmarshal(data, &reviews); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// GetRating retrieves product rating from cache.
+func (m *Memory) GetRating(ctx context.Context, productID int64) (*float64, bool, error) {
+	data, ok := m.get(ratingKey(ctx, productID))
+	if !ok {
+		countLookup(ratingCache, metrics.CacheMiss)
+		return nil, false, nil
+	}
+
+	rating, err := decodeRating(data)
+	if err != nil {
+		countLookup(ratingCache, metrics.CacheError)
+		return nil, false, err
+	}
+	countLookup(ratingCache, metrics.CacheHit)
+	return rating, true, nil
+}
+
+// GetRatings retrieves the ratings of several products from cache. The result only holds the
+// cached products; a nil rating means the product has no reviews.
+func (m *Memory) GetRatings(ctx context.Context, productIDs []int64) (map[int64]*float64, error) {
+	ratings := make(map[int64]*float64, len(productIDs))
+	for _, id := range productIDs {
+		rating, ok, err := m.GetRating(ctx, id)
+		if err != nil || !ok {
+			continue // A corrupt entry is a miss; the caller overwrites it
+		}
+		ratings[id] = rating
+	}
+	return ratings, nil
+}
+
+// SetRating stores product rating in cache.
+func (m *Memory) SetRating(ctx context.Context, productID int64, rating *float64) error {
+	data, err := json.Marshal(rating)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rating: %w", err)
+	}
+	m.set(ratingKey(ctx, productID), data, m.ttl)
+	return nil
+}
+
+// SetRatings stores the ratings of several products in cache.
+func (m *Memory) SetRatings(ctx context.Context, ratings map[int64]*float64) error {
+	for id, rating := range ratings {
+		if err := m.SetRating(ctx, id, rating); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAPIKey retrieves an API key by its hash. It returns nil on a miss.
+func (m *Memory) GetAPIKey(ctx context.Context, hash string) (*models.APIKey, error) {
+	data, ok := m.get(apiKeyKey(hash))
+	if !ok {
+		return nil, nil // Cache miss
+	}
+
+	var key models.APIKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal api key: %w", err)
+	}
+	return &key, nil
+}
+
+// SetAPIKey stores an API key in cache under its hash.
+func (m *Memory) SetAPIKey(ctx context.Context, key *models.APIKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal api key: %w", err)
+	}
+	m.set(apiKeyKey(key.KeyHash), data, APIKeyTTL)
+	return nil
+}
+
+// InvalidateAPIKey removes a cached API key.
+func (m *Memory) InvalidateAPIKey(ctx context.Context, hash string) error {
+	m.del(apiKeyKey(hash))
+	return nil
+}
+
+// Product returns the serialized payload of a product in the given representation and the
+// time until which it is fresh, loading it with load on a miss.
+func (m *Memory) Product(ctx context.Context, productID int64, representation string, load LoadFunc) ([]byte, time.Time, error) {
+	ctx, span := tracing.Start(ctx, "cache.Memory.Product")
+	defer span.End()
+
+	version := m.version(productVersionKey(ctx, productID))
+	return m.fetch(ctx, productCache, productKey(ctx, productID, version, representation), load)
+}
+
+// Products returns a serialized product list page and the time until which it is fresh,
+// loading it with load on a miss. query identifies the page as for Service.Products.
+func (m *Memory) Products(ctx context.Context, query string, load LoadFunc) ([]byte, time.Time, error) {
+	ctx, span := tracing.Start(ctx, "cache.Memory.Products")
+	defer span.End()
+
+	version := m.version(tenantPrefix(ctx) + productsVersionKey)
+	categoriesVersion := m.version(tenantPrefix(ctx) + categoriesVersionKey)
+	return m.fetch(ctx, productsCache, productsKey(ctx, version, categoriesVersion, query), load)
+}
+
+// InvalidateProduct invalidates the cached payloads of a product and the product lists of its
+// tenant.
+func (m *Memory) InvalidateProduct(ctx context.Context, productID int64) {
+	m.incrVersion(productVersionKey(ctx, productID), tenantPrefix(ctx)+productsVersionKey)
+}
+
+// InvalidateProducts invalidates the cached product lists of the tenant of ctx.
+func (m *Memory) InvalidateProducts(ctx context.Context) {
+	m.incrVersion(tenantPrefix(ctx) + productsVersionKey)
+}
+
+// InvalidateCategories invalidates the cached product lists of the tenant of ctx.
+func (m *Memory) InvalidateCategories(ctx context.Context) {
+	m.incrVersion(tenantPrefix(ctx) + categoriesVersionKey)
+}
+
+// InvalidateProductCache removes all cached data for a product (reviews, rating and payload)
+// and the product lists of its tenant.
+func (m *Memory) InvalidateProductCache(ctx context.Context, productID int64) {
+	m.incrVersion(reviewsVersionKey(ctx, productID))
+	m.del(ratingKey(ctx, productID))
+	m.InvalidateProduct(ctx, productID)
+}
+
+// fetch returns the value of key and the time until which it is fresh, loading and storing it
+// with load on a miss. The version in key must be read before the value is loaded, so that a
+// value loaded before a concurrent invalidation is stored under the old version.
+func (m *Memory) fetch(ctx context.Context, cache, key string, load LoadFunc) ([]byte, time.Time, error) {
+	m.mu.Lock()
+	entry, ok := m.entries[key]
+	m.mu.Unlock()
+	if ok && m.now().Before(entry.expiresAt) {
+		countLookup(cache, metrics.CacheHit)
+		return entry.value, entry.expiresAt, nil
+	}
+	countLookup(cache, metrics.CacheMiss)
+
+	value, err := load(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return value, m.set(key, value, m.ttl), nil
+}
+
+// version reads a version counter. Missing counters are 0.
+func (m *Memory) version(key string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.versions[key]
+}
+
+// incrVersion increments version counters, invalidating the keys of their current versions.
+func (m *Memory) incrVersion(keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		m.versions[key]++
+	}
+}
+
+// get returns the value of key unless it is missing or expired.
+func (m *Memory) get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok || !m.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set stores the value of key for ttl and returns when it expires. It also drops the expired
+// entries every sweepInterval, since entries of old versions are never read again.
+func (m *Memory) set(key string, value []byte, ttl time.Duration) time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for k, entry := range m.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	expiresAt := now.Add(ttl)
+	m.entries[key] = memoryEntry{value: value, expiresAt: expiresAt}
+	return expiresAt
+}
+
+// del removes keys.
+func (m *Memory) del(keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+
+	newMemory := func() (*Memory, *time.Time) {
+		now := time.Unix(1700000000, 0)
+		m := NewMemory(5 * time.Second)
+		m.now = func() time.Time { return now }
+		return m, &now
+	}
+
+	// loader returns a load of value that counts its calls.
+	loader := func(value string, loads *int) LoadFunc {
+		return func(ctx context.Context) ([]byte, error) {
+			*loads++
+			return []byte(value), nil
+		}
+	}
+
+	t.Run("cache products until their TTL", func(t *testing.T) {
+		m, now := newMemory()
+		var loads int
+
+		value, freshUntil, err := m.Product(ctx, 1, "base", loader("1", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "1", string(value))
+		assert.Equal(t, now.Add(5*time.Second), freshUntil)
+
+		*now = now.Add(4 * time.Second)
+		value, _, err = m.Product(ctx, 1, "base", loader("2", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "1", string(value))
+
+		*now = now.Add(time.Second)
+		value, _, err = m.Product(ctx, 1, "base", loader("2", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "2", string(value))
+		assert.Equal(t, 2, loads)
+	})
+
+	t.Run("invalidate products and the lists of their tenant", func(t *testing.T) {
+		m, _ := newMemory()
+		shopA := tenant.WithID(ctx, "shop-a")
+		var loads int
+
+		for _, c := range []context.Context{ctx, shopA} {
+			_, _, err := m.Product(c, 1, "base", loader("1", &loads))
+			require.NoError(t, err)
+			_, _, err = m.Products(c, "limit=10", loader("[]", &loads))
+			require.NoError(t, err)
+		}
+		require.Equal(t, 4, loads)
+
+		m.InvalidateProduct(ctx, 1)
+
+		value, _, err := m.Product(ctx, 1, "base", loader("2", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "2", string(value))
+		value, _, err = m.Products(ctx, "limit=10", loader("[2]", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "[2]", string(value))
+
+		// The same product ID of another tenant is another product
+		value, _, err = m.Product(shopA, 1, "base", loader("2", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "1", string(value))
+		value, _, err = m.Products(shopA, "limit=10", loader("[2]", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(value))
+	})
+
+	t.Run("invalidate the lists of the tenant on category changes", func(t *testing.T) {
+		m, _ := newMemory()
+		shopA := tenant.WithID(ctx, "shop-a")
+		var loads int
+
+		for _, c := range []context.Context{ctx, shopA} {
+			_, _, err := m.Products(c, "category=1", loader("[]", &loads))
+			require.NoError(t, err)
+		}
+
+		m.InvalidateCategories(ctx)
+
+		value, _, err := m.Products(ctx, "category=1", loader("[1]", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "[1]", string(value))
+
+		// Categories of another tenant are other categories
+		value, _, err = m.Products(shopA, "category=1", loader("[1]", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(value))
+		assert.Equal(t, 3, loads)
+	})
+
+	t.Run("invalidate reviews, ratings and payloads of a product", func(t *testing.T) {
+		m, _ := newMemory()
+		rating := 4.5
+		var loads int
+
+		_, err := m.Reviews(ctx, 1, 10, 0, func(ctx context.Context) ([]models.Review, error) {
+			return []models.Review{{ID: 1}}, nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, m.SetRatings(ctx, map[int64]*float64{1: &rating, 2: nil}))
+		_, _, err = m.Product(ctx, 1, "base", loader("1", &loads))
+		require.NoError(t, err)
+
+		ratings, err := m.GetRatings(ctx, []int64{1, 2, 3})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]*float64{1: &rating, 2: nil}, ratings)
+
+		m.InvalidateProductCache(ctx, 1)
+
+		reviews, err := m.Reviews(ctx, 1, 10, 0, func(ctx context.Context) ([]models.Review, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, reviews)
+		_, ok, err := m.GetRating(ctx, 1)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		value, _, err := m.Product(ctx, 1, "base", loader("2", &loads))
+		require.NoError(t, err)
+		assert.Equal(t, "2", string(value))
+	})
+
+	t.Run("cache and invalidate API keys", func(t *testing.T) {
+		m, _ := newMemory()
+
+		require.NoError(t, m.SetAPIKey(ctx, &models.APIKey{ID: 1, KeyHash: "hash"}))
+		key, err := m.GetAPIKey(ctx, "hash")
+		require.NoError(t, err)
+		require.NotNil(t, key)
+		assert.Equal(t, int64(1), key.ID)
+
+		require.NoError(t, m.InvalidateAPIKey(ctx, "hash"))
+		key, err = m.GetAPIKey(ctx, "hash")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+	})
+
+	t.Run("sweep expired entries", func(t *testing.T) {
+		m, now := newMemory()
+		require.NoError(t, m.SetRating(ctx, 1, nil))
+
+		*now = now.Add(sweepInterval)
+		require.NoError(t, m.SetRating(ctx, 2, nil))
+
+		assert.Len(t, m.entries, 1)
+	})
+
+	t.Run("clear entries and versions", func(t *testing.T) {
+		m, _ := newMemory()
+		require.NoError(t, m.SetRating(ctx, 1, nil))
+		m.InvalidateProduct(ctx, 1)
+
+		m.Clear()
+
+		assert.Empty(t, m.entries)
+		assert.Empty(t, m.versions)
+	})
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"product_review_hub/internal/database"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/tracing"
 	"strconv"
 	"time"
 )
@@ -23,12 +25,96 @@ type RabbitMQConfig struct {
 	Password string
 }
 
+// AuthConfig holds JWT verification configuration.
+// At least one of JWTSecret and JWKSFile must be set.
+type AuthConfig struct {
+	// JWTSecret is the shared secret of HS256 tokens.
+	JWTSecret string
+	// JWKSFile is the path of a JSON Web Key Set with the public keys of RS256 tokens.
+	JWKSFile string
+	// Issuer, when set, must match the "iss" claim of tokens.
+	Issuer string
+	// Audience, when set, must be one of the "aud" claim values of tokens.
+	Audience string
+}
+
+// TenantConfig holds the storefronts served by the deployment.
+type TenantConfig struct {
+	// IDs is the comma-separated list of tenants besides "default", e.g. "shop-a,shop-b".
+	IDs string
+	// Hosts maps request hosts to tenants in the form "shop-a.example.com=shop-a".
+	Hosts string
+}
+
+// HealthConfig holds readiness check configuration.
+type HealthConfig struct {
+	// Critical is the comma-separated list of dependencies that make the service unready when
+	// they are down, e.g. "postgres,rabbitmq". Other dependencies only degrade the report.
+	Critical string
+	// CacheTTL is how long check results are reused.
+	CacheTTL time.Duration
+	// Timeout bounds each check.
+	Timeout time.Duration
+}
+
+// CacheConfig holds configuration of the in-process cache tier in front of Redis.
+type CacheConfig struct {
+	// LocalSize is the maximum number of entries of the tier. Zero disables it.
+	LocalSize int
+	// LocalTTL is how long entries are kept in the tier. It bounds how stale they can be when
+	// an invalidation message is lost.
+	LocalTTL time.Duration
+}
+
+// BreakerConfig holds the circuit breaker configuration of Redis and RabbitMQ.
+type BreakerConfig struct {
+	// Failures is the number of consecutive failures that opens a breaker.
+	Failures int
+	// Cooldown is how long an open breaker skips its dependency before probing it.
+	Cooldown time.Duration
+	// CacheTimeout bounds each Redis cache command.
+	CacheTimeout time.Duration
+	// PublishTimeout bounds each RabbitMQ publish.
+	PublishTimeout time.Duration
+}
+
+// DefaultRateLimits limits review and review photo creation per caller.
+const DefaultRateLimits = "POST /api/v1/products/{productId}/reviews=5/1m," +
+	"POST /api/v1/products/{productId}/reviews/{reviewId}/photos=20/1m"
+
+// DefaultIPRateLimits limits every route per client IP address, before authentication.
+const DefaultIPRateLimits = "*=600/1m"
+
 // Config holds all application configuration.
 type Config struct {
 	ServerAddress string
 	Database      database.Config
 	Redis         RedisConfig
+	Cache         CacheConfig
 	RabbitMQ      RabbitMQConfig
+	Auth          AuthConfig
+	Tenants       TenantConfig
+	Log           logging.Config
+	Tracing       tracing.Config
+	Health        HealthConfig
+	Breaker       BreakerConfig
+	// PriceSchedulerInterval is how often scheduled price changes are applied.
+	PriceSchedulerInterval time.Duration
+	// ExchangeRates is the exchange-rate table in the form "USD=1,EUR=0.92".
+	ExchangeRates string
+	// MediaDir is the directory uploaded files are stored in.
+	MediaDir string
+	// MediaBaseURL is the URL prefix of uploaded files, e.g. a CDN in front of MediaDir.
+	MediaBaseURL string
+	// WatcherMetricsAddress is the address the review watcher serves its metrics and health
+	// probes on.
+	WatcherMetricsAddress string
+	// RateLimits are the per-route rate limits in the form
+	// "POST /api/v1/products/{productId}/reviews=5/1m,*=300/1m".
+	RateLimits string
+	// IPRateLimits are the per-route rate limits per client IP address, applied before
+	// callers are authenticated, in the same form as RateLimits.
+	IPRateLimits string
 }
 
 func New() *Config {
@@ -51,12 +137,52 @@ func New() *Config {
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
+		Cache: CacheConfig{
+			LocalSize: getEnvAsInt("CACHE_LOCAL_SIZE", 10000),
+			LocalTTL:  getEnvAsDuration("CACHE_LOCAL_TTL", 5*time.Second),
+		},
 		RabbitMQ: RabbitMQConfig{
 			Host:     getEnv("RABBITMQ_HOST", "localhost"),
 			Port:     getEnv("RABBITMQ_PORT", "5672"),
 			User:     getEnv("RABBITMQ_USER", "guest"),
 			Password: getEnv("RABBITMQ_PASSWORD", "guest"),
 		},
+		Auth: AuthConfig{
+			JWTSecret: getEnv("JWT_SECRET", ""),
+			JWKSFile:  getEnv("JWT_JWKS_FILE", ""),
+			Issuer:    getEnv("JWT_ISSUER", ""),
+			Audience:  getEnv("JWT_AUDIENCE", ""),
+		},
+		Tenants: TenantConfig{
+			IDs:   getEnv("TENANTS", ""),
+			Hosts: getEnv("TENANT_HOSTS", ""),
+		},
+		Log: logging.Config{
+			Level:  getEnv("LOG_LEVEL", "info"),
+			Format: getEnv("LOG_FORMAT", logging.FormatJSON),
+		},
+		Tracing: tracing.Config{
+			Exporter:    getEnv("TRACING_EXPORTER", tracing.ExporterNone),
+			SampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Health: HealthConfig{
+			Critical: getEnv("HEALTH_CRITICAL", "postgres"),
+			CacheTTL: getEnvAsDuration("HEALTH_CACHE_TTL", 5*time.Second),
+			Timeout:  getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		},
+		Breaker: BreakerConfig{
+			Failures:       getEnvAsInt("BREAKER_FAILURES", 5),
+			Cooldown:       getEnvAsDuration("BREAKER_COOLDOWN", 10*time.Second),
+			CacheTimeout:   getEnvAsDuration("CACHE_TIMEOUT", 100*time.Millisecond),
+			PublishTimeout: getEnvAsDuration("PUBLISH_TIMEOUT", 2*time.Second),
+		},
+		PriceSchedulerInterval: getEnvAsDuration("PRICE_SCHEDULER_INTERVAL", time.Minute),
+		ExchangeRates:          getEnv("EXCHANGE_RATES", "USD=1,EUR=0.92,GBP=0.79"),
+		MediaDir:               getEnv("MEDIA_DIR", "./data/media"),
+		MediaBaseURL:           getEnv("MEDIA_BASE_URL", "/media"),
+		RateLimits:             getEnv("RATE_LIMITS", DefaultRateLimits),
+		IPRateLimits:           getEnv("IP_RATE_LIMITS", DefaultIPRateLimits),
+		WatcherMetricsAddress:  getEnv("WATCHER_METRICS_ADDRESS", ":9091"),
 	}
 }
 
@@ -76,6 +202,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -2,13 +2,20 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"product_review_hub/internal/tenant"
+
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+// uniqueViolation is the PostgreSQL error code for unique constraint violations.
+const uniqueViolation = "23505"
+
 // Config holds database configuration.
 type Config struct {
 	Host            string
@@ -74,3 +81,38 @@ func buildDSN(cfg Config) string {
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, sslMode,
 	)
 }
+
+// IsUniqueViolation reports whether err is a PostgreSQL unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// BeginTx starts a transaction bound to the tenant of ctx, or the default tenant without one.
+// The tenant is stored in the app.tenant_id setting, which the row-level security policies of
+// tenant-owned tables read.
+func BeginTx(ctx context.Context, db *sqlx.DB) (*sqlx.Tx, error) {
+	return beginTx(ctx, db, `SELECT set_config('app.tenant_id', $1, true)`, tenant.ID(ctx))
+}
+
+// BeginAllTenantsTx starts a transaction that sees the rows of every tenant, for background jobs
+// spanning all tenants. It sets the app.all_tenants setting, which the row-level security
+// policies of tenant-owned tables accept instead of a tenant.
+func BeginAllTenantsTx(ctx context.Context, db *sqlx.DB) (*sqlx.Tx, error) {
+	return beginTx(ctx, db, `SELECT set_config('app.all_tenants', $1, true)`, "on")
+}
+
+// beginTx starts a transaction and runs the set_config query with value in it.
+func beginTx(ctx context.Context, db *sqlx.DB, query, value string) (*sqlx.Tx, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, value); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to bind transaction to tenant: %w", err)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/apikeys"
+	"product_review_hub/internal/tenant"
+)
+
+const (
+	maxAPIKeyNameLength = 255
+	maxGracePeriod      = 7 * 24 * time.Hour
+)
+
+// CreateAPIKey issues a new API key. The plaintext key is returned only in this response.
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Decode request body
+	var req api.APIKeyCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	params, err := validateAPIKeyCreate(req, time.Now())
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.APIKeyRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if user exists
+	exists, err := h.UserRepo.Exists(r.Context(), tx, params.UserID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check user existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	// Generate and store key
+	plaintext, err := auth.GenerateAPIKey()
+	if err != nil {
+		responseServerError(w, r, "Failed to generate API key", err)
+		return
+	}
+	params.Prefix = auth.APIKeyDisplayPrefix(plaintext)
+	params.KeyHash = auth.HashAPIKey(plaintext)
+	// Keys are bound to the tenant they are issued in
+	params.TenantID = tenant.ID(r.Context())
+
+	key, err := h.APIKeyRepo.Create(r.Context(), tx, params)
+	if err != nil {
+		responseServerError(w, r, "Failed to create API key", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.APIKeyRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseSecretJSON(w, http.StatusCreated, apiKeyToIssuedResponse(key, plaintext))
+}
+
+// validateAPIKeyCreate validates an API key creation request.
+func validateAPIKeyCreate(req api.APIKeyCreate, now time.Time) (models.CreateAPIKeyParams, error) {
+	userID, err := parseID(req.UserId)
+	if err != nil {
+		return models.CreateAPIKeyParams{}, errValidation("user_id", "user_id must be a valid user ID")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return models.CreateAPIKeyParams{}, errValidation("name", "name is required")
+	}
+	if len(name) > maxAPIKeyNameLength {
+		return models.CreateAPIKeyParams{}, errValidation("name", "name must be at most 255 characters")
+	}
+
+	if len(req.Scopes) == 0 {
+		return models.CreateAPIKeyParams{}, errValidation("scopes", "at least one scope is required")
+	}
+	scopes := make([]string, 0, len(req.Scopes))
+	seen := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		s := string(scope)
+		if !auth.IsValidScope(s) {
+			return models.CreateAPIKeyParams{}, errValidation("scopes", "unknown scope "+strconv.Quote(s))
+		}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return models.CreateAPIKeyParams{}, errValidation("expires_at", "expires_at must be in the future")
+	}
+
+	return models.CreateAPIKeyParams{
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+// ListAPIKeys returns all API keys without their plaintext.
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	// Begin transaction
+	tx, err := h.APIKeyRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Fetch keys
+	keys, err := h.APIKeyRepo.List(r.Context(), tx)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch API keys", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.APIKeyRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	response := make([]api.APIKey, len(keys))
+	for i := range keys {
+		response[i] = apiKeyToResponse(&keys[i])
+	}
+
+	responseJSON(w, http.StatusOK, response)
+}
+
+// RevokeAPIKey revokes an API key.
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request, keyId string) {
+	// Parse API key ID
+	id, err := parseID(keyId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.APIKeyRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Revoke key
+	key, err := h.APIKeyRepo.Revoke(r.Context(), tx, id)
+	if err != nil {
+		if errors.Is(err, apikeys.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		responseServerError(w, r, "Failed to revoke API key", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.APIKeyRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	h.invalidateAPIKey(r, key)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateAPIKey issues a replacement for an API key and retires the old one, immediately or
+// after a grace period.
+func (h *Handler) RotateAPIKey(w http.ResponseWriter, r *http.Request, keyId string) {
+	// Parse API key ID
+	id, err := parseID(keyId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	// Decode optional request body
+	var req api.APIKeyRotate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	var grace time.Duration
+	if req.GracePeriodSeconds != nil {
+		grace = time.Duration(*req.GracePeriodSeconds) * time.Second
+		if grace < 0 || grace > maxGracePeriod {
+			responseError(w, http.StatusBadRequest, errValidation("grace_period_seconds", "grace_period_seconds must be between 0 and 604800").Error())
+			return
+		}
+	}
+
+	// Begin transaction
+	tx, err := h.APIKeyRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Fetch key to rotate
+	old, err := h.APIKeyRepo.GetByID(r.Context(), tx, id)
+	if err != nil {
+		if errors.Is(err, apikeys.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch API key", err)
+		return
+	}
+	now := time.Now()
+	if old.RevokedAt != nil || old.Expired(now) {
+		responseError(w, http.StatusConflict, "Only active API keys can be rotated")
+		return
+	}
+
+	// Issue replacement with the same user, tenant, name, scopes and expiry
+	plaintext, err := auth.GenerateAPIKey()
+	if err != nil {
+		responseServerError(w, r, "Failed to generate API key", err)
+		return
+	}
+	key, err := h.APIKeyRepo.Create(r.Context(), tx, models.CreateAPIKeyParams{
+		UserID:    old.UserID,
+		TenantID:  old.TenantID,
+		Name:      old.Name,
+		Prefix:    auth.APIKeyDisplayPrefix(plaintext),
+		KeyHash:   auth.HashAPIKey(plaintext),
+		Scopes:    old.Scopes,
+		ExpiresAt: old.ExpiresAt,
+	})
+	if err != nil {
+		responseServerError(w, r, "Failed to create API key", err)
+		return
+	}
+
+	// Retire old key
+	if grace > 0 {
+		old, err = h.APIKeyRepo.ExpireBy(r.Context(), tx, old.ID, now.Add(grace))
+	} else {
+		old, err = h.APIKeyRepo.Revoke(r.Context(), tx, old.ID)
+	}
+	if err != nil {
+		responseServerError(w, r, "Failed to retire API key", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.APIKeyRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	h.invalidateAPIKey(r, old)
+
+	responseSecretJSON(w, http.StatusCreated, apiKeyToIssuedResponse(key, plaintext))
+}
+
+// invalidateAPIKey drops a changed key from the lookup cache so that the change applies at once.
+func (h *Handler) invalidateAPIKey(r *http.Request, key *models.APIKey) {
+	if h.Cache == nil {
+		return
+	}
+	if err := h.Cache.InvalidateAPIKey(r.Context(), key.KeyHash); err != nil {
+		slog.WarnContext(r.Context(), "Failed to invalidate api key in cache", logging.Error(err), slog.Int64("api_key_id", key.ID))
+	}
+}
+
+// apiKeyToResponse converts a model to an API response without the key itself.
+func apiKeyToResponse(key *models.APIKey) api.APIKey {
+	scopes := make([]api.APIKeyScope, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = api.APIKeyScope(scope)
+	}
+
+	return api.APIKey{
+		Id:         strconv.FormatInt(key.ID, 10),
+		UserId:     strconv.FormatInt(key.UserID, 10),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// apiKeyToIssuedResponse converts a newly issued key to an API response carrying its plaintext.
+func apiKeyToIssuedResponse(key *models.APIKey, plaintext string) api.APIKeyIssued {
+	resp := apiKeyToResponse(key)
+	return api.APIKeyIssued{
+		Id:         resp.Id,
+		UserId:     resp.UserId,
+		Name:       resp.Name,
+		Prefix:     resp.Prefix,
+		Key:        plaintext,
+		Scopes:     resp.Scopes,
+		ExpiresAt:  resp.ExpiresAt,
+		RevokedAt:  resp.RevokedAt,
+		LastUsedAt: resp.LastUsedAt,
+		CreatedAt:  resp.CreatedAt,
+	}
+}
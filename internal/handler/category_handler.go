@@ -0,0 +1,532 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/categories"
+)
+
+const (
+	defaultCategoryLimit = 10
+	maxCategoryLimit     = 100
+)
+
+// CreateCategory creates a new category.
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req api.CategoryCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateCategoryName(req.Name); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	parentID, err := parseOptionalID(req.ParentId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("parent_id", "parent_id must be a valid category ID").Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if parent category exists
+	if parentID != nil {
+		exists, err := h.CategoryRepo.Exists(r.Context(), tx, *parentID)
+		if err != nil {
+			responseServerError(w, r, "Failed to check parent category existence", err)
+			return
+		}
+		if !exists {
+			responseError(w, http.StatusBadRequest, errValidation("parent_id", "parent category does not exist").Error())
+			return
+		}
+	}
+
+	// Create category in database
+	category, err := h.CategoryRepo.Create(r.Context(), tx, models.CreateCategoryParams{
+		ParentID:    parentID,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		responseServerError(w, r, "Failed to create category", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// A new category has no products yet, so its aggregates are empty
+	responseJSON(w, http.StatusCreated, categoryToResponse(&models.CategoryWithStats{Category: *category}))
+}
+
+// GetCategories returns a paginated list of categories.
+func (h *Handler) GetCategories(w http.ResponseWriter, r *http.Request, params api.GetCategoriesParams) {
+	// Apply pagination defaults
+	limit := defaultCategoryLimit
+	offset := 0
+
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > maxCategoryLimit {
+			limit = maxCategoryLimit
+		}
+	}
+
+	if params.Offset != nil {
+		offset = *params.Offset
+		if offset < 0 {
+			offset = 0
+		}
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Fetch categories from database
+	categoryList, err := h.CategoryRepo.List(r.Context(), tx, models.ListCategoriesParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch categories", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, categoriesToResponse(categoryList))
+}
+
+// GetCategoryById returns a category by its ID.
+func (h *Handler) GetCategoryById(w http.ResponseWriter, r *http.Request, categoryId string) {
+	// Parse category ID
+	id, err := parseID(categoryId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Fetch category from database
+	category, err := h.CategoryRepo.GetByID(r.Context(), tx, id)
+	if err != nil {
+		if errors.Is(err, categories.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch category", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, categoryToResponse(category))
+}
+
+// UpdateCategory updates an existing category.
+func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request, categoryId string) {
+	// Parse category ID
+	id, err := parseID(categoryId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	// Decode request body
+	var req api.CategoryUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateCategoryName(req.Name); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	parentID, err := parseOptionalID(req.ParentId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("parent_id", "parent_id must be a valid category ID").Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if category exists
+	exists, err := h.CategoryRepo.Exists(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to check category existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	// Check that the new parent exists and does not create a cycle
+	if parentID != nil {
+		parentExists, err := h.CategoryRepo.Exists(r.Context(), tx, *parentID)
+		if err != nil {
+			responseServerError(w, r, "Failed to check parent category existence", err)
+			return
+		}
+		if !parentExists {
+			responseError(w, http.StatusBadRequest, errValidation("parent_id", "parent category does not exist").Error())
+			return
+		}
+
+		cycle, err := h.CategoryRepo.IsInSubtree(r.Context(), tx, id, *parentID)
+		if err != nil {
+			responseServerError(w, r, "Failed to check category hierarchy", err)
+			return
+		}
+		if cycle {
+			responseError(w, http.StatusBadRequest, errValidation("parent_id", "category cannot be moved under itself or its descendants").Error())
+			return
+		}
+	}
+
+	// Update category in database
+	_, err = h.CategoryRepo.Update(r.Context(), tx, id, models.UpdateCategoryParams{
+		ParentID:    parentID,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, categories.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		responseServerError(w, r, "Failed to update category", err)
+		return
+	}
+
+	// Fetch category with aggregates for response
+	category, err := h.CategoryRepo.GetByID(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch updated category", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product lists of the tenant, since moving a category moves its
+	// products between the lists filtered by its ancestors
+	if h.Cache != nil {
+		h.Cache.InvalidateCategories(r.Context())
+	}
+
+	responseJSON(w, http.StatusOK, categoryToResponse(category))
+}
+
+// DeleteCategory deletes a category by its ID.
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request, categoryId string) {
+	// Parse category ID
+	id, err := parseID(categoryId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if category exists
+	exists, err := h.CategoryRepo.Exists(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to check category existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	// Check if category has products
+	hasProducts, err := h.CategoryRepo.HasProducts(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to check category products", err)
+		return
+	}
+	if hasProducts {
+		responseError(w, http.StatusConflict, "Cannot delete category with existing products")
+		return
+	}
+
+	// Check if category has subcategories
+	hasChildren, err := h.CategoryRepo.HasChildren(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to check subcategories", err)
+		return
+	}
+	if hasChildren {
+		responseError(w, http.StatusConflict, "Cannot delete category with existing subcategories")
+		return
+	}
+
+	// Delete category
+	err = h.CategoryRepo.Delete(r.Context(), tx, id)
+	if err != nil {
+		if errors.Is(err, categories.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		responseServerError(w, r, "Failed to delete category", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product lists of the tenant, which filter by its categories
+	if h.Cache != nil {
+		h.Cache.InvalidateCategories(r.Context())
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProductCategories returns the categories a product is assigned to.
+func (h *Handler) GetProductCategories(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Fetch categories
+	categoryList, err := h.CategoryRepo.ListByProductID(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch product categories", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, categoriesToResponse(categoryList))
+}
+
+// UpdateProductCategories replaces the set of categories a product is assigned to.
+func (h *Handler) UpdateProductCategories(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.ProductCategoriesUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	categoryIDs := make([]int64, 0, len(req.CategoryIds))
+	for _, rawID := range req.CategoryIds {
+		id, err := parseID(rawID)
+		if err != nil {
+			responseError(w, http.StatusBadRequest, errValidation("category_ids", fmt.Sprintf("invalid category ID %q", rawID)).Error())
+			return
+		}
+		categoryIDs = append(categoryIDs, id)
+	}
+
+	// Begin transaction
+	tx, err := h.CategoryRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Check that all categories exist
+	if len(categoryIDs) > 0 {
+		missing, err := h.CategoryRepo.FindMissing(r.Context(), tx, categoryIDs)
+		if err != nil {
+			responseServerError(w, r, "Failed to check categories existence", err)
+			return
+		}
+		if len(missing) > 0 {
+			responseError(w, http.StatusBadRequest, errValidation("category_ids", fmt.Sprintf("category %d does not exist", missing[0])).Error())
+			return
+		}
+	}
+
+	// Replace assignments
+	if err := h.CategoryRepo.SetProductCategories(r.Context(), tx, prodID, categoryIDs); err != nil {
+		responseServerError(w, r, "Failed to assign product categories", err)
+		return
+	}
+
+	// Fetch categories for response
+	categoryList, err := h.CategoryRepo.ListByProductID(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch product categories", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.CategoryRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProducts(r.Context())
+	}
+
+	responseJSON(w, http.StatusOK, categoriesToResponse(categoryList))
+}
+
+func validateCategoryName(name string) error {
+	if name == "" {
+		return errValidation("name", "name is required")
+	}
+	return nil
+}
+
+// parseOptionalID parses an optional string ID to *int64.
+func parseOptionalID(id *string) (*int64, error) {
+	if id == nil || *id == "" {
+		return nil, nil
+	}
+	parsed, err := parseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// categoriesToResponse converts a list of CategoryWithStats models to API response.
+func categoriesToResponse(list []models.CategoryWithStats) []api.Category {
+	response := make([]api.Category, len(list))
+	for i := range list {
+		response[i] = categoryToResponse(&list[i])
+	}
+	return response
+}
+
+// categoryToResponse converts a CategoryWithStats model to API response.
+func categoryToResponse(c *models.CategoryWithStats) api.Category {
+	var parentID *string
+	if c.ParentID != nil {
+		id := strconv.FormatInt(*c.ParentID, 10)
+		parentID = &id
+	}
+
+	var avgRating *float32
+	if c.AverageRating != nil {
+		rating := float32(*c.AverageRating)
+		avgRating = &rating
+	}
+
+	return api.Category{
+		Id:            strconv.FormatInt(c.ID, 10),
+		ParentId:      parentID,
+		Name:          c.Name,
+		Description:   c.Description,
+		ProductCount:  int(c.ProductCount),
+		ReviewCount:   int(c.ReviewCount),
+		AverageRating: avgRating,
+	}
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/repository/prices"
+	"product_review_hub/internal/repository/products"
+)
+
+// GetProductCurrencyPrices returns the prices of a product in other currencies.
+func (h *Handler) GetProductCurrencyPrices(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PriceRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Fetch currency prices
+	currencyPrices, err := h.PriceRepo.ListCurrencyPrices(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch currency prices", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PriceRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	response := make([]api.CurrencyPrice, len(currencyPrices))
+	for i := range currencyPrices {
+		response[i] = currencyPriceToResponse(&currencyPrices[i])
+	}
+
+	responseJSON(w, http.StatusOK, response)
+}
+
+// SetProductCurrencyPrice sets the price of a product in a currency other than its own.
+func (h *Handler) SetProductCurrencyPrice(w http.ResponseWriter, r *http.Request, productId string, currency api.CurrencyCode) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.CurrencyPriceUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	code, err := h.parseSupportedCurrency(currency)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validatePrice(req.Price); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PriceRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists and uses another currency
+	product, err := h.ProductRepo.GetByID(r.Context(), tx, prodID)
+	if err != nil {
+		if errors.Is(err, products.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch product", err)
+		return
+	}
+	if product.Currency == code {
+		responseError(w, http.StatusBadRequest, errValidation("currency", "currency must differ from the product currency").Error())
+		return
+	}
+
+	// Set currency price
+	currencyPrice, err := h.PriceRepo.SetCurrencyPrice(r.Context(), tx, prodID, code, req.Price)
+	if err != nil {
+		responseServerError(w, r, "Failed to set currency price", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PriceRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusOK, currencyPriceToResponse(currencyPrice))
+}
+
+// DeleteProductCurrencyPrice removes the price of a product in a currency.
+func (h *Handler) DeleteProductCurrencyPrice(w http.ResponseWriter, r *http.Request, productId string, currency api.CurrencyCode) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	code, err := money.ParseCurrency(currency)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("currency", "invalid currency code").Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PriceRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkProductInTenant(w, r, tx, prodID, "Currency price not found") {
+		return
+	}
+
+	// Delete currency price
+	err = h.PriceRepo.DeleteCurrencyPrice(r.Context(), tx, prodID, code)
+	if err != nil {
+		if errors.Is(err, prices.ErrCurrencyNotFound) {
+			responseError(w, http.StatusNotFound, "Currency price not found")
+			return
+		}
+		responseServerError(w, r, "Failed to delete currency price", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PriceRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseSupportedCurrency parses a currency code and checks that the exchange-rate table supports it.
+func (h *Handler) parseSupportedCurrency(code string) (money.Currency, error) {
+	currency, err := money.ParseCurrency(code)
+	if err != nil {
+		return "", errValidation("currency", "invalid currency code")
+	}
+	if !h.Rates.Supports(currency) {
+		return "", errValidation("currency", "currency is not supported")
+	}
+	return currency, nil
+}
+
+// parseRequestedCurrency parses the optional currency query parameter.
+func (h *Handler) parseRequestedCurrency(code *api.CurrencyParam) (*money.Currency, error) {
+	if code == nil {
+		return nil, nil
+	}
+	currency, err := h.parseSupportedCurrency(*code)
+	if err != nil {
+		return nil, err
+	}
+	return &currency, nil
+}
+
+// convertProduct converts the prices of a product and its variants to the currency.
+// A price set for the currency takes precedence over the converted product price;
+// variant price overrides are always converted.
+func (h *Handler) convertProduct(p *models.ProductWithRating, variantList []models.ProductVariant, currency money.Currency, currencyPrice *money.Amount) error {
+	from := p.Currency
+	if from == currency {
+		return nil
+	}
+
+	for i := range variantList {
+		if variantList[i].Price == nil {
+			continue
+		}
+		converted, err := h.Rates.Convert(*variantList[i].Price, from, currency)
+		if err != nil {
+			return err
+		}
+		variantList[i].Price = &converted
+	}
+
+	if currencyPrice != nil {
+		p.Price = *currencyPrice
+	} else {
+		converted, err := h.Rates.Convert(p.Price, from, currency)
+		if err != nil {
+			return err
+		}
+		p.Price = converted
+	}
+	p.Currency = currency
+
+	return nil
+}
+
+// currencyPriceToResponse converts CurrencyPrice model to API response.
+func currencyPriceToResponse(p *models.CurrencyPrice) api.CurrencyPrice {
+	return api.CurrencyPrice{
+		Currency: string(p.Currency),
+		Price:    p.Price,
+	}
+}
@@ -2,15 +2,42 @@ package handler
 
 import (
 	"context"
+	"net/http"
+	"time"
+
 	"product_review_hub/internal/api"
 	"product_review_hub/internal/cache"
 	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
 	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/storage"
 
 	"github.com/jmoiron/sqlx"
 )
 
-var _ api.ServerInterface = (*Handler)(nil)
+var (
+	_ api.ServerInterface = (*Handler)(nil)
+	_ Cache               = (*cache.Service)(nil)
+	_ Cache               = (*cache.Memory)(nil)
+)
+
+// Cache defines interface for caching products, reviews, ratings and API keys. It is
+// implemented by the Redis-backed cache.Service and the in-memory cache.Memory.
+type Cache interface {
+	Product(ctx context.Context, productID int64, representation string, load cache.LoadFunc) ([]byte, time.Time, error)
+	Products(ctx context.Context, query string, load cache.LoadFunc) ([]byte, time.Time, error)
+	Reviews(ctx context.Context, productID int64, limit, offset int, load func(ctx context.Context) ([]models.Review, error)) ([]models.Review, error)
+	GetRating(ctx context.Context, productID int64) (*float64, bool, error)
+	GetRatings(ctx context.Context, productIDs []int64) (map[int64]*float64, error)
+	SetRating(ctx context.Context, productID int64, rating *float64) error
+	SetRatings(ctx context.Context, ratings map[int64]*float64) error
+
+	InvalidateProduct(ctx context.Context, productID int64)
+	InvalidateProducts(ctx context.Context)
+	InvalidateCategories(ctx context.Context)
+	InvalidateProductCache(ctx context.Context, productID int64)
+	InvalidateAPIKey(ctx context.Context, hash string) error
+}
 
 // ProductRepository defines interface for product operations.
 type ProductRepository interface {
@@ -20,6 +47,7 @@ type ProductRepository interface {
 	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateProductParams) (*models.Product, error)
 	GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.ProductWithRating, error)
 	List(ctx context.Context, tx *sqlx.Tx, params models.ListProductsParams) ([]models.ProductWithRating, error)
+	Facets(ctx context.Context, tx *sqlx.Tx, filter models.ProductFilter) (*models.ProductFacets, error)
 	Update(ctx context.Context, tx *sqlx.Tx, id int64, params models.UpdateProductParams) (*models.Product, error)
 	Delete(ctx context.Context, tx *sqlx.Tx, id int64) error
 	Exists(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error)
@@ -33,27 +61,171 @@ type ReviewRepository interface {
 	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateReviewParams) (*models.Review, error)
 	GetByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) (*models.Review, error)
 	ListByProductID(ctx context.Context, tx *sqlx.Tx, params models.ListReviewsParams) ([]models.Review, error)
+	ListByUserID(ctx context.Context, tx *sqlx.Tx, params models.ListUserReviewsParams) ([]models.Review, error)
 	UpdateByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64, params models.UpdateReviewParams) (*models.Review, error)
 	DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) error
 	HasReviewsByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) (bool, error)
 }
 
+// CategoryRepository defines interface for category operations.
+type CategoryRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateCategoryParams) (*models.Category, error)
+	GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.CategoryWithStats, error)
+	List(ctx context.Context, tx *sqlx.Tx, params models.ListCategoriesParams) ([]models.CategoryWithStats, error)
+	ListByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.CategoryWithStats, error)
+	Update(ctx context.Context, tx *sqlx.Tx, id int64, params models.UpdateCategoryParams) (*models.Category, error)
+	Delete(ctx context.Context, tx *sqlx.Tx, id int64) error
+	Exists(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error)
+	FindMissing(ctx context.Context, tx *sqlx.Tx, ids []int64) ([]int64, error)
+	IsInSubtree(ctx context.Context, tx *sqlx.Tx, rootID, id int64) (bool, error)
+	HasChildren(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error)
+	HasProducts(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error)
+	SetProductCategories(ctx context.Context, tx *sqlx.Tx, productID int64, categoryIDs []int64) error
+}
+
+// VariantRepository defines interface for product variant operations.
+type VariantRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateVariantParams) (*models.ProductVariant, error)
+	GetByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) (*models.ProductVariant, error)
+	ListByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.ProductVariant, error)
+	ListByProductIDs(ctx context.Context, tx *sqlx.Tx, productIDs []int64) (map[int64][]models.ProductVariant, error)
+	UpdateByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64, params models.UpdateVariantParams) (*models.ProductVariant, error)
+	DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) error
+	HasReviews(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error)
+}
+
+// PriceRepository defines interface for product price history operations.
+type PriceRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Record(ctx context.Context, tx *sqlx.Tx, productID int64, price money.Amount) (*models.ProductPrice, error)
+	Schedule(ctx context.Context, tx *sqlx.Tx, params models.SchedulePriceParams) (*models.ProductPrice, error)
+	ListByProductID(ctx context.Context, tx *sqlx.Tx, params models.ListPricesParams) ([]models.ProductPrice, error)
+	DeleteScheduledByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) error
+	SetCurrencyPrice(ctx context.Context, tx *sqlx.Tx, productID int64, currency money.Currency, price money.Amount) (*models.CurrencyPrice, error)
+	ListCurrencyPrices(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.CurrencyPrice, error)
+	ListCurrencyPricesByProductIDs(ctx context.Context, tx *sqlx.Tx, productIDs []int64, currency money.Currency) (map[int64]money.Amount, error)
+	DeleteCurrencyPrice(ctx context.Context, tx *sqlx.Tx, productID int64, currency money.Currency) error
+}
+
+// StockRepository defines interface for product stock operations.
+type StockRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	GetByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) (*models.ProductStock, error)
+	Adjust(ctx context.Context, tx *sqlx.Tx, productID int64, delta int) (*models.ProductStock, error)
+	Reserve(ctx context.Context, tx *sqlx.Tx, productID int64, quantity int) (*models.ProductStock, error)
+	Release(ctx context.Context, tx *sqlx.Tx, productID int64, quantity int) (*models.ProductStock, error)
+	SetLowStockThreshold(ctx context.Context, tx *sqlx.Tx, productID int64, threshold int) (*models.ProductStock, error)
+}
+
+// ImageRepository defines interface for product image metadata operations.
+type ImageRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateImageParams) (*models.ProductImage, error)
+	ListByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.ProductImage, error)
+	ListByProductIDs(ctx context.Context, tx *sqlx.Tx, productIDs []int64) (map[int64][]models.ProductImage, error)
+	Reorder(ctx context.Context, tx *sqlx.Tx, productID int64, ids []int64) error
+	DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) (*models.ProductImage, error)
+}
+
+// ReviewPhotoRepository defines interface for review photo metadata operations.
+type ReviewPhotoRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateReviewPhotoParams, maxPhotos int) (*models.ReviewPhoto, error)
+	ListByReviewID(ctx context.Context, tx *sqlx.Tx, reviewID int64) ([]models.ReviewPhoto, error)
+	ListByReviewIDs(ctx context.Context, tx *sqlx.Tx, reviewIDs []int64) (map[int64][]models.ReviewPhoto, error)
+	DeleteByIDAndReviewID(ctx context.Context, tx *sqlx.Tx, id, reviewID int64) (*models.ReviewPhoto, error)
+}
+
+// UserRepository defines interface for user account operations.
+type UserRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateUserParams) (*models.User, error)
+	GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.User, error)
+	Exists(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error)
+}
+
+// APIKeyRepository defines the interface for API key repository operations.
+type APIKeyRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	Create(ctx context.Context, tx *sqlx.Tx, params models.CreateAPIKeyParams) (*models.APIKey, error)
+	List(ctx context.Context, tx *sqlx.Tx) ([]models.APIKey, error)
+	GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.APIKey, error)
+	Revoke(ctx context.Context, tx *sqlx.Tx, id int64) (*models.APIKey, error)
+	ExpireBy(ctx context.Context, tx *sqlx.Tx, id int64, at time.Time) (*models.APIKey, error)
+}
+
 // Handler implements all API handlers.
 type Handler struct {
-	DB          *sqlx.DB
-	ProductRepo ProductRepository
-	ReviewRepo  ReviewRepository
-	Publisher   *rabbitmq.Publisher
-	Cache       *cache.Service
+	DB           *sqlx.DB
+	ProductRepo  ProductRepository
+	ReviewRepo   ReviewRepository
+	CategoryRepo CategoryRepository
+	VariantRepo  VariantRepository
+	PriceRepo    PriceRepository
+	StockRepo    StockRepository
+	ImageRepo    ImageRepository
+	PhotoRepo    ReviewPhotoRepository
+	UserRepo     UserRepository
+	APIKeyRepo   APIKeyRepository
+	Publisher    *rabbitmq.Publisher
+	Cache        Cache
+	// Blobs stores uploaded files.
+	Blobs storage.BlobStore
+	// Rates is the exchange-rate table used to convert prices between currencies.
+	Rates money.Rates
 }
 
 // New creates a new Handler instance.
-func New(db *sqlx.DB, productRepo ProductRepository, reviewRepo ReviewRepository, publisher *rabbitmq.Publisher, cacheService *cache.Service) *Handler {
+func New(db *sqlx.DB, productRepo ProductRepository, reviewRepo ReviewRepository, categoryRepo CategoryRepository, variantRepo VariantRepository, priceRepo PriceRepository, stockRepo StockRepository, imageRepo ImageRepository, photoRepo ReviewPhotoRepository, userRepo UserRepository, apiKeyRepo APIKeyRepository, publisher *rabbitmq.Publisher, cacheService Cache, blobs storage.BlobStore, rates money.Rates) *Handler {
 	return &Handler{
-		DB:          db,
-		ProductRepo: productRepo,
-		ReviewRepo:  reviewRepo,
-		Publisher:   publisher,
-		Cache:       cacheService,
+		DB:           db,
+		ProductRepo:  productRepo,
+		ReviewRepo:   reviewRepo,
+		CategoryRepo: categoryRepo,
+		VariantRepo:  variantRepo,
+		PriceRepo:    priceRepo,
+		StockRepo:    stockRepo,
+		ImageRepo:    imageRepo,
+		PhotoRepo:    photoRepo,
+		UserRepo:     userRepo,
+		APIKeyRepo:   apiKeyRepo,
+		Publisher:    publisher,
+		Cache:        cacheService,
+		Blobs:        blobs,
+		Rates:        rates,
+	}
+}
+
+// checkProductInTenant responds with 404 and notFound unless the product exists in the tenant
+// of the request, so that nested resources of other tenants' products stay hidden.
+// It reports whether the handler may go on.
+func (h *Handler) checkProductInTenant(w http.ResponseWriter, r *http.Request, tx *sqlx.Tx, prodID int64, notFound string) bool {
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return false
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, notFound)
+		return false
 	}
+	return true
 }
@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/images"
+)
+
+const (
+	maxImageSize       = 5 << 20
+	imageThumbnailSize = 320
+	maxAltTextLength   = 255
+)
+
+// CreateProductImage uploads an image of a product.
+// The files are stored before the metadata is recorded and removed again when recording fails.
+func (h *Handler) CreateProductImage(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Parse multipart form
+	if err := parseMultipartForm(w, r, maxImageSize+multipartOverhead); err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			responseError(w, http.StatusRequestEntityTooLarge, "Image exceeds the maximum size of 5 MB")
+			return
+		}
+		responseError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	// Read and validate image
+	_, fh, err := r.FormFile("file")
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("file", "file is required").Error())
+		return
+	}
+	data, err := readFormFile(fh, maxImageSize)
+	if err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			responseError(w, http.StatusRequestEntityTooLarge, "Image exceeds the maximum size of 5 MB")
+			return
+		}
+		responseError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	altText := formValue(r, "alt_text")
+	if altText != nil && utf8.RuneCountInString(*altText) > maxAltTextLength {
+		responseError(w, http.StatusBadRequest, errValidation("alt_text", fmt.Sprintf("alt_text must be at most %d characters", maxAltTextLength)).Error())
+		return
+	}
+
+	upload, err := processImage(data, imageThumbnailSize)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, imageValidationError("file", err).Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.ImageRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Store files
+	blobKey, thumbnailKey, err := h.storeImage(r.Context(), "products/"+strconv.FormatInt(prodID, 10), upload)
+	if err != nil {
+		responseServerError(w, r, "Failed to store image", err)
+		return
+	}
+
+	// Record image
+	image, err := h.ImageRepo.Create(r.Context(), tx, models.CreateImageParams{
+		ProductID:    prodID,
+		BlobKey:      blobKey,
+		ThumbnailKey: thumbnailKey,
+		ContentType:  upload.ContentType,
+		SizeBytes:    int64(len(upload.Data)),
+		Width:        upload.Width,
+		Height:       upload.Height,
+		AltText:      altText,
+	})
+	if err != nil {
+		h.deleteBlobs(r.Context(), blobKey, thumbnailKey)
+		responseServerError(w, r, "Failed to create image", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.ImageRepo.CommitTx(tx); err != nil {
+		h.deleteBlobs(r.Context(), blobKey, thumbnailKey)
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusCreated, h.imageToResponse(image))
+}
+
+// GetProductImages returns the images of a product in display order.
+func (h *Handler) GetProductImages(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.ImageRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Fetch images
+	imageList, err := h.ImageRepo.ListByProductID(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch images", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.ImageRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, h.imagesToResponse(imageList))
+}
+
+// ReorderProductImages sets the display order of the images of a product.
+func (h *Handler) ReorderProductImages(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.ProductImageOrder
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	ids := make([]int64, len(req.ImageIds))
+	for i, imageID := range req.ImageIds {
+		id, err := parseID(imageID)
+		if err != nil {
+			responseError(w, http.StatusBadRequest, errValidation("image_ids", "invalid image ID").Error())
+			return
+		}
+		ids[i] = id
+	}
+
+	// Begin transaction
+	tx, err := h.ImageRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Reorder images
+	if err := h.ImageRepo.Reorder(r.Context(), tx, prodID, ids); err != nil {
+		if errors.Is(err, images.ErrOrderMismatch) {
+			responseError(w, http.StatusBadRequest, errValidation("image_ids", "image_ids must list every image of the product exactly once").Error())
+			return
+		}
+		responseServerError(w, r, "Failed to reorder images", err)
+		return
+	}
+
+	// Fetch images in their new order
+	imageList, err := h.ImageRepo.ListByProductID(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch images", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.ImageRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusOK, h.imagesToResponse(imageList))
+}
+
+// DeleteProductImage deletes an image of a product and its files.
+func (h *Handler) DeleteProductImage(w http.ResponseWriter, r *http.Request, productId string, imageId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	id, err := parseID(imageId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid image ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.ImageRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkProductInTenant(w, r, tx, prodID, "Image not found") {
+		return
+	}
+
+	// Delete image metadata
+	image, err := h.ImageRepo.DeleteByIDAndProductID(r.Context(), tx, id, prodID)
+	if err != nil {
+		if errors.Is(err, images.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Image not found")
+			return
+		}
+		responseServerError(w, r, "Failed to delete image", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.ImageRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	// Delete files
+	h.deleteBlobs(r.Context(), image.BlobKey, image.ThumbnailKey)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// imageToResponse converts ProductImage model to API response.
+func (h *Handler) imageToResponse(image *models.ProductImage) api.ProductImage {
+	return api.ProductImage{
+		Id:           strconv.FormatInt(image.ID, 10),
+		ProductId:    strconv.FormatInt(image.ProductID, 10),
+		Url:          h.Blobs.URL(image.BlobKey),
+		ThumbnailUrl: h.Blobs.URL(image.ThumbnailKey),
+		ContentType:  image.ContentType,
+		Size:         image.SizeBytes,
+		Width:        image.Width,
+		Height:       image.Height,
+		Position:     image.Position,
+		AltText:      image.AltText,
+		CreatedAt:    image.CreatedAt,
+	}
+}
+
+// imagesToResponse converts a list of ProductImage models to API response.
+func (h *Handler) imagesToResponse(imageList []models.ProductImage) []api.ProductImage {
+	response := make([]api.ProductImage, len(imageList))
+	for i := range imageList {
+		response[i] = h.imageToResponse(&imageList[i])
+	}
+	return response
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/repository/prices"
+)
+
+const (
+	defaultPriceHistoryLimit = 10
+	maxPriceHistoryLimit     = 100
+)
+
+// GetProductPriceHistory returns a paginated price history of a product.
+func (h *Handler) GetProductPriceHistory(w http.ResponseWriter, r *http.Request, productId string, params api.GetProductPriceHistoryParams) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Apply pagination defaults
+	limit := defaultPriceHistoryLimit
+	offset := 0
+
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > maxPriceHistoryLimit {
+			limit = maxPriceHistoryLimit
+		}
+	}
+
+	if params.Offset != nil {
+		offset = *params.Offset
+		if offset < 0 {
+			offset = 0
+		}
+	}
+
+	// Begin transaction
+	tx, err := h.PriceRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Fetch price history
+	priceList, err := h.PriceRepo.ListByProductID(r.Context(), tx, models.ListPricesParams{
+		ProductID: prodID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch price history", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PriceRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	response := make([]api.PriceHistoryEntry, len(priceList))
+	for i := range priceList {
+		response[i] = priceToResponse(&priceList[i])
+	}
+
+	responseJSON(w, http.StatusOK, response)
+}
+
+// CreateScheduledPrice schedules a price change of a product.
+// The change is applied to the stored price and announced by the price scheduler once it takes effect.
+func (h *Handler) CreateScheduledPrice(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.ScheduledPriceCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateScheduledPrice(req, time.Now()); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PriceRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Schedule price change
+	productPrice, err := h.PriceRepo.Schedule(r.Context(), tx, models.SchedulePriceParams{
+		ProductID:     prodID,
+		Price:         req.Price,
+		EffectiveFrom: req.EffectiveFrom,
+		EffectiveTo:   req.EffectiveTo,
+	})
+	if err != nil {
+		responseServerError(w, r, "Failed to schedule price", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PriceRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists, since the price may take effect at once
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusCreated, priceToResponse(productPrice))
+}
+
+// validatePrice checks that a price is positive and fits the DECIMAL(10, 2) price columns.
+func validatePrice(price money.Amount) error {
+	if price <= 0 {
+		return errValidation("price", "price must be greater than 0")
+	}
+	if price > money.MaxAmount {
+		return errValidation("price", "price must be at most "+money.MaxAmount.String())
+	}
+	return nil
+}
+
+func validateScheduledPrice(req api.ScheduledPriceCreate, now time.Time) error {
+	if err := validatePrice(req.Price); err != nil {
+		return err
+	}
+	if req.EffectiveFrom.IsZero() {
+		return errValidation("effective_from", "effective_from is required")
+	}
+	if req.EffectiveTo != nil {
+		if !req.EffectiveTo.After(req.EffectiveFrom) {
+			return errValidation("effective_to", "effective_to must be after effective_from")
+		}
+		if !req.EffectiveTo.After(now) {
+			return errValidation("effective_to", "effective_to must be in the future")
+		}
+	}
+	return nil
+}
+
+// DeleteScheduledPrice cancels a price change that has not taken effect yet.
+func (h *Handler) DeleteScheduledPrice(w http.ResponseWriter, r *http.Request, productId string, priceId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	id, err := parseID(priceId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid price ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PriceRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkProductInTenant(w, r, tx, prodID, "Scheduled price not found") {
+		return
+	}
+
+	// Delete scheduled price
+	err = h.PriceRepo.DeleteScheduledByIDAndProductID(r.Context(), tx, id, prodID)
+	if err != nil {
+		if errors.Is(err, prices.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Scheduled price not found")
+			return
+		}
+		if errors.Is(err, prices.ErrAlreadyEffective) {
+			responseError(w, http.StatusConflict, "Cannot cancel a price change that has already taken effect")
+			return
+		}
+		responseServerError(w, r, "Failed to delete scheduled price", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PriceRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// publishPriceChanged publishes a manual price change of a product.
+func (h *Handler) publishPriceChanged(r *http.Request, productID int64, oldPrice, newPrice money.Amount, currency money.Currency) {
+	if h.Publisher == nil {
+		return
+	}
+	event := rabbitmq.NewPriceChangedEvent(
+		r.Context(),
+		strconv.FormatInt(productID, 10),
+		oldPrice,
+		newPrice,
+		currency,
+		rabbitmq.PriceChangeManual,
+	)
+	if err := h.Publisher.PublishPriceChanged(r.Context(), event); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to publish price changed event", logging.Error(err))
+	}
+}
+
+// priceToResponse converts ProductPrice model to API response.
+func priceToResponse(p *models.ProductPrice) api.PriceHistoryEntry {
+	return api.PriceHistoryEntry{
+		Id:            strconv.FormatInt(p.ID, 10),
+		ProductId:     strconv.FormatInt(p.ProductID, 10),
+		Price:         p.Price,
+		Currency:      string(p.Currency),
+		EffectiveFrom: p.EffectiveFrom,
+		EffectiveTo:   p.EffectiveTo,
+		IsCurrent:     p.IsCurrent,
+	}
+}
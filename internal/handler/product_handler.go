@@ -1,20 +1,31 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/logging"
 	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/repository/categories"
 	"product_review_hub/internal/repository/products"
 )
 
 const (
 	defaultProductLimit = 10
 	maxProductLimit     = 100
+
+	// attributeFilterPrefix is the query parameter prefix for attribute filters, e.g. attr.color=red.
+	attributeFilterPrefix = "attr."
 )
 
 // CreateProduct creates a new product.
@@ -31,17 +42,30 @@ func (h *Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	currency := money.DefaultCurrency
+	if req.Currency != nil {
+		var err error
+		currency, err = h.parseSupportedCurrency(*req.Currency)
+		if err != nil {
+			responseError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	// Create product params
 	params := models.CreateProductParams{
 		Name:        req.Name,
 		Description: &req.Description,
-		Price:       float64(req.Price),
+		Price:       req.Price,
+		Currency:    currency,
+		Tags:        normalizeTags(req.Tags),
+		Attributes:  attributesFromRequest(req.Attributes),
 	}
 
 	// Begin transaction
 	tx, err := h.ProductRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
@@ -49,36 +73,42 @@ func (h *Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	// Create product in database
 	product, err := h.ProductRepo.Create(r.Context(), tx, params)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to create product")
+		responseServerError(w, r, "Failed to create product", err)
+		return
+	}
+
+	// Record initial price
+	if _, err := h.PriceRepo.Record(r.Context(), tx, product.ID, product.Price); err != nil {
+		responseServerError(w, r, "Failed to record product price", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
 		return
 	}
 
-	// Convert to API response
-	response := api.Product{
-		Id:          strconv.FormatInt(product.ID, 10),
-		Name:        product.Name,
-		Description: getStringValue(product.Description),
-		Price:       float32(product.Price),
+	// Invalidate cached product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProducts(r.Context())
 	}
 
 	// Return created product
-	responseJSON(w, http.StatusCreated, response)
+	responseJSON(w, http.StatusCreated, productToResponse(&models.ProductWithRating{Product: *product}, nil))
 }
 
 func validateProductCreate(req api.ProductCreate) error {
 	if req.Name == "" {
 		return errValidation("name", "name is required")
 	}
-	if req.Price <= 0 {
-		return errValidation("price", "price must be greater than 0")
+	if err := validatePrice(req.Price); err != nil {
+		return err
 	}
-	return nil
+	if err := validateTags(req.Tags); err != nil {
+		return err
+	}
+	return validateAttributes(req.Attributes)
 }
 
 // GetProducts returns a paginated list of products.
@@ -104,116 +134,324 @@ func (h *Handler) GetProducts(w http.ResponseWriter, r *http.Request, params api
 		}
 	}
 
+	filter, err := parseProductFilter(r.URL.Query(), params.Category, params.Tag)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.InStock = params.InStock
+
+	currency, err := h.parseRequestedCurrency(params.Currency)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	listParams := models.ListProductsParams{
+		Limit:         limit,
+		Offset:        offset,
+		ProductFilter: filter,
+	}
+	load := func(ctx context.Context) ([]byte, error) {
+		response, err := h.loadProducts(ctx, listParams, currency)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(response)
+	}
+
+	// Read through the cache; pages are keyed by the full query string
+	var payload []byte
+	freshUntil := time.Now()
+	if h.Cache != nil {
+		payload, freshUntil, err = h.Cache.Products(r.Context(), r.URL.Query().Encode(), load)
+	} else {
+		payload, err = load(r.Context())
+	}
+	if err != nil {
+		if errors.Is(err, categories.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch products", err)
+		return
+	}
+
+	responseCachedJSON(w, payload, freshUntil)
+}
+
+// GetProductFacets returns tag and attribute value counts for the products matching the filters.
+func (h *Handler) GetProductFacets(w http.ResponseWriter, r *http.Request, params api.GetProductFacetsParams) {
+	filter, err := parseProductFilter(r.URL.Query(), params.Category, params.Tag)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Begin transaction
 	tx, err := h.ProductRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
 
-	// Fetch products from database
-	productList, err := h.ProductRepo.List(r.Context(), tx, models.ListProductsParams{
-		Limit:  limit,
-		Offset: offset,
-	})
+	// Check if category exists
+	if filter.CategoryID != nil {
+		exists, err := h.CategoryRepo.Exists(r.Context(), tx, *filter.CategoryID)
+		if err != nil {
+			responseServerError(w, r, "Failed to check category existence", err)
+			return
+		}
+		if !exists {
+			responseError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+	}
+
+	// Count facets in database
+	facets, err := h.ProductRepo.Facets(r.Context(), tx, filter)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to fetch products")
+		responseServerError(w, r, "Failed to fetch product facets", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, facetsToResponse(facets))
+}
+
+// GetProductById returns a product by its ID.
+func (h *Handler) GetProductById(w http.ResponseWriter, r *http.Request, productId string, params api.GetProductByIdParams) {
+	// Parse product ID
+	id, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	currency, err := h.parseRequestedCurrency(params.Currency)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Cache ratings for products
+	representation := "base"
+	if currency != nil {
+		representation = string(*currency)
+	}
+	load := func(ctx context.Context) ([]byte, error) {
+		response, err := h.loadProduct(ctx, id, currency)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(response)
+	}
+
+	// Read through the cache
+	var payload []byte
+	freshUntil := time.Now()
 	if h.Cache != nil {
+		payload, freshUntil, err = h.Cache.Product(r.Context(), id, representation, load)
+	} else {
+		payload, err = load(r.Context())
+	}
+	if err != nil {
+		if errors.Is(err, products.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch product", err)
+		return
+	}
+
+	responseCachedJSON(w, payload, freshUntil)
+}
+
+// loadProducts loads a product list page from the database. It returns categories.ErrNotFound
+// when the filtered category does not exist.
+func (h *Handler) loadProducts(ctx context.Context, params models.ListProductsParams, currency *money.Currency) ([]api.Product, error) {
+	// Begin transaction
+	tx, err := h.ProductRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Check if category exists
+	if params.CategoryID != nil {
+		exists, err := h.CategoryRepo.Exists(ctx, tx, *params.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check category existence: %w", err)
+		}
+		if !exists {
+			return nil, categories.ErrNotFound
+		}
+	}
+
+	// Fetch products from database
+	productList, err := h.ProductRepo.List(ctx, tx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	// Fetch variants of the listed products
+	productIDs := make([]int64, len(productList))
+	for i := range productList {
+		productIDs[i] = productList[i].ID
+	}
+	variantsByProduct, err := h.VariantRepo.ListByProductIDs(ctx, tx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product variants: %w", err)
+	}
+
+	// Fetch images of the listed products
+	imagesByProduct, err := h.ImageRepo.ListByProductIDs(ctx, tx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product images: %w", err)
+	}
+
+	// Fetch prices set for the requested currency
+	var currencyPrices map[int64]money.Amount
+	if currency != nil {
+		currencyPrices, err = h.PriceRepo.ListCurrencyPricesByProductIDs(ctx, tx, productIDs, *currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currency prices: %w", err)
+		}
+	}
+
+	// Commit transaction
+	if err := h.ProductRepo.CommitTx(tx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Convert prices to the requested currency
+	if currency != nil {
 		for i := range productList {
-			// Try to get rating from cache
-			cachedRating, ratingCached, cacheErr := h.Cache.GetRating(r.Context(), productList[i].ID)
-			if cacheErr != nil {
-				log.Printf("Failed to get rating from cache for product %d: %v", productList[i].ID, cacheErr)
-				continue
+			var currencyPrice *money.Amount
+			if price, ok := currencyPrices[productList[i].ID]; ok {
+				currencyPrice = &price
+			}
+			if err := h.convertProduct(&productList[i], variantsByProduct[productList[i].ID], *currency, currencyPrice); err != nil {
+				return nil, fmt.Errorf("failed to convert prices: %w", err)
 			}
+		}
+	}
 
-			if ratingCached {
-				// Use cached rating
-				productList[i].AverageRating = cachedRating
-			} else {
-				// Cache the DB rating
-				if err := h.Cache.SetRating(r.Context(), productList[i].ID, productList[i].AverageRating); err != nil {
-					log.Printf("Failed to cache rating for product %d: %v", productList[i].ID, err)
+	// Cache ratings for products: one MGET for the page and one pipeline for the misses
+	if h.Cache != nil && len(productList) > 0 {
+		cachedRatings, cacheErr := h.Cache.GetRatings(ctx, productIDs)
+		if cacheErr != nil {
+			slog.WarnContext(ctx, "Failed to get ratings from cache", logging.Error(cacheErr))
+		} else {
+			missing := make(map[int64]*float64)
+			for i := range productList {
+				if rating, ok := cachedRatings[productList[i].ID]; ok {
+					// Use cached rating
+					productList[i].AverageRating = rating
+				} else {
+					// Cache the DB rating
+					missing[productList[i].ID] = productList[i].AverageRating
 				}
 			}
+			if err := h.Cache.SetRatings(ctx, missing); err != nil {
+				slog.WarnContext(ctx, "Failed to cache ratings", logging.Error(err), slog.Int("count", len(missing)))
+			}
 		}
 	}
 
 	// Convert to API response
 	response := make([]api.Product, len(productList))
 	for i := range productList {
-		response[i] = productToResponse(&productList[i])
+		response[i] = productToResponse(&productList[i], variantsByProduct[productList[i].ID])
+		response[i].Images = h.imagesToResponse(imagesByProduct[productList[i].ID])
 	}
 
-	responseJSON(w, http.StatusOK, response)
+	return response, nil
 }
 
-// GetProductById returns a product by its ID.
-func (h *Handler) GetProductById(w http.ResponseWriter, r *http.Request, productId string) {
-	// Parse product ID
-	id, err := parseID(productId)
-	if err != nil {
-		responseError(w, http.StatusBadRequest, "Invalid product ID")
-		return
-	}
-
+// loadProduct loads a product from the database. It returns products.ErrNotFound when the
+// product does not exist.
+func (h *Handler) loadProduct(ctx context.Context, id int64, currency *money.Currency) (*api.Product, error) {
 	// Try to get rating from cache
 	var cachedRating *float64
 	var ratingCached bool
 	if h.Cache != nil {
 		var cacheErr error
-		cachedRating, ratingCached, cacheErr = h.Cache.GetRating(r.Context(), id)
+		cachedRating, ratingCached, cacheErr = h.Cache.GetRating(ctx, id)
 		if cacheErr != nil {
-			log.Printf("Failed to get rating from cache: %v", cacheErr)
+			slog.WarnContext(ctx, "Failed to get rating from cache", logging.Error(cacheErr), slog.Int64("product_id", id))
 		}
 	}
 
 	// Begin transaction
-	tx, err := h.ProductRepo.BeginTx(r.Context())
+	tx, err := h.ProductRepo.BeginTx(ctx)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
-		return
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Fetch product from database
-	product, err := h.ProductRepo.GetByID(r.Context(), tx, id)
+	product, err := h.ProductRepo.GetByID(ctx, tx, id)
 	if err != nil {
 		if errors.Is(err, products.ErrNotFound) {
-			responseError(w, http.StatusNotFound, "Product not found")
-			return
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	// Fetch product variants
+	variantList, err := h.VariantRepo.ListByProductID(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product variants: %w", err)
+	}
+
+	// Fetch product images
+	imageList, err := h.ImageRepo.ListByProductID(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product images: %w", err)
+	}
+
+	// Fetch the price set for the requested currency
+	var currencyPrice *money.Amount
+	if currency != nil {
+		currencyPrices, err := h.PriceRepo.ListCurrencyPricesByProductIDs(ctx, tx, []int64{id}, *currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currency prices: %w", err)
+		}
+		if price, ok := currencyPrices[id]; ok {
+			currencyPrice = &price
 		}
-		responseError(w, http.StatusInternalServerError, "Failed to fetch product")
-		return
 	}
 
 	// Commit transaction
 	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
-		return
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Convert prices to the requested currency
+	if currency != nil {
+		if err := h.convertProduct(product, variantList, *currency, currencyPrice); err != nil {
+			return nil, fmt.Errorf("failed to convert prices: %w", err)
+		}
 	}
 
 	// Use cached rating if available, otherwise cache the DB rating
 	if ratingCached {
 		product.AverageRating = cachedRating
 	} else if h.Cache != nil {
-		if err := h.Cache.SetRating(r.Context(), id, product.AverageRating); err != nil {
-			log.Printf("Failed to cache rating: %v", err)
+		if err := h.Cache.SetRating(ctx, id, product.AverageRating); err != nil {
+			slog.WarnContext(ctx, "Failed to cache rating", logging.Error(err), slog.Int64("product_id", id))
 		}
 	}
 
-	responseJSON(w, http.StatusOK, productToResponse(product))
+	response := productToResponse(product, variantList)
+	response.Images = h.imagesToResponse(imageList)
+	return &response, nil
 }
 
 // UpdateProduct updates an existing product.
@@ -238,21 +476,44 @@ func (h *Handler) UpdateProduct(w http.ResponseWriter, r *http.Request, productI
 		return
 	}
 
+	var currency money.Currency
+	if req.Currency != nil {
+		currency, err = h.parseSupportedCurrency(*req.Currency)
+		if err != nil {
+			responseError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	// Prepare update params
 	params := models.UpdateProductParams{
 		Name:        req.Name,
 		Description: &req.Description,
-		Price:       float64(req.Price),
+		Price:       req.Price,
+		Currency:    currency,
+		Tags:        normalizeTags(req.Tags),
+		Attributes:  attributesFromRequest(req.Attributes),
 	}
 
 	// Begin transaction
 	tx, err := h.ProductRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
 
+	// Fetch the price currently in effect
+	current, err := h.ProductRepo.GetByID(r.Context(), tx, id)
+	if err != nil {
+		if errors.Is(err, products.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Product not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch product", err)
+		return
+	}
+
 	// Update product in database
 	product, err := h.ProductRepo.Update(r.Context(), tx, id, params)
 	if err != nil {
@@ -260,36 +521,172 @@ func (h *Handler) UpdateProduct(w http.ResponseWriter, r *http.Request, productI
 			responseError(w, http.StatusNotFound, "Product not found")
 			return
 		}
-		responseError(w, http.StatusInternalServerError, "Failed to update product")
+		responseServerError(w, r, "Failed to update product", err)
 		return
 	}
 
+	// Record the new price, it takes effect immediately and overrides scheduled prices in effect
+	priceChanged := current.Price != product.Price || current.Currency != product.Currency
+	if priceChanged {
+		if _, err := h.PriceRepo.Record(r.Context(), tx, product.ID, product.Price); err != nil {
+			responseServerError(w, r, "Failed to record product price", err)
+			return
+		}
+	}
+
 	// Fetch product with rating for response
 	productWithRating, err := h.ProductRepo.GetByID(r.Context(), tx, product.ID)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to fetch updated product")
+		responseServerError(w, r, "Failed to fetch updated product", err)
+		return
+	}
+
+	// Fetch product variants
+	variantList, err := h.VariantRepo.ListByProductID(r.Context(), tx, product.ID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch product variants", err)
+		return
+	}
+
+	// Fetch product images
+	imageList, err := h.ImageRepo.ListByProductID(r.Context(), tx, product.ID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch product images", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
 		return
 	}
 
-	responseJSON(w, http.StatusOK, productToResponse(productWithRating))
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), id)
+	}
+
+	// Publish price changed event
+	if priceChanged {
+		h.publishPriceChanged(r, product.ID, current.Price, product.Price, product.Currency)
+	}
+
+	response := productToResponse(productWithRating, variantList)
+	response.Images = h.imagesToResponse(imageList)
+
+	responseJSON(w, http.StatusOK, response)
 }
 
 func validateProductUpdate(req api.ProductUpdate) error {
 	if req.Name == "" {
 		return errValidation("name", "name is required")
 	}
-	if req.Price <= 0 {
-		return errValidation("price", "price must be greater than 0")
+	if err := validatePrice(req.Price); err != nil {
+		return err
+	}
+	if err := validateTags(req.Tags); err != nil {
+		return err
+	}
+	return validateAttributes(req.Attributes)
+}
+
+// validateTags checks that no tag is blank.
+func validateTags(tags *api.ProductTags) error {
+	if tags == nil {
+		return nil
+	}
+	for _, tag := range *tags {
+		if strings.TrimSpace(tag) == "" {
+			return errValidation("tags", "tags must not be empty")
+		}
+	}
+	return nil
+}
+
+// validateAttributes checks that attribute keys are not blank and values are strings, numbers or booleans.
+func validateAttributes(attributes *api.ProductAttributes) error {
+	if attributes == nil {
+		return nil
+	}
+	for key, value := range *attributes {
+		if strings.TrimSpace(key) == "" {
+			return errValidation("attributes", "attribute keys must not be empty")
+		}
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			return errValidation("attributes", fmt.Sprintf("attribute %q must be a string, number or boolean", key))
+		}
 	}
 	return nil
 }
 
+// normalizeTags trims tags and removes duplicates, keeping the original order.
+// A nil input is returned as nil so that updates keep the stored tags.
+func normalizeTags(tags *api.ProductTags) []string {
+	if tags == nil {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(*tags))
+	normalized := make([]string, 0, len(*tags))
+	for _, tag := range *tags {
+		tag = strings.TrimSpace(tag)
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// attributesFromRequest converts API attributes to the model type.
+// A nil input is returned as nil so that updates keep the stored attributes.
+func attributesFromRequest(attributes *api.ProductAttributes) models.Attributes {
+	if attributes == nil {
+		return nil
+	}
+	return models.Attributes(*attributes)
+}
+
+// parseProductFilter builds a product filter from the category and tag parameters
+// and the attr.<key>=<value> query parameters.
+func parseProductFilter(query url.Values, category *string, tags *[]string) (models.ProductFilter, error) {
+	var filter models.ProductFilter
+
+	categoryID, err := parseOptionalID(category)
+	if err != nil {
+		return filter, errValidation("category", "invalid category ID")
+	}
+	filter.CategoryID = categoryID
+
+	if tags != nil {
+		for _, tag := range *tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				return filter, errValidation("tag", "tags must not be empty")
+			}
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	for param, values := range query {
+		if !strings.HasPrefix(param, attributeFilterPrefix) {
+			continue
+		}
+		key := strings.TrimPrefix(param, attributeFilterPrefix)
+		if key == "" {
+			return filter, errValidation("attr", "attribute key must not be empty")
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string][]string)
+		}
+		filter.Attributes[key] = append(filter.Attributes[key], values...)
+	}
+
+	return filter, nil
+}
+
 // DeleteProduct deletes a product by its ID.
 func (h *Handler) DeleteProduct(w http.ResponseWriter, r *http.Request, productId string) {
 	// Parse product ID
@@ -302,7 +699,7 @@ func (h *Handler) DeleteProduct(w http.ResponseWriter, r *http.Request, productI
 	// Begin transaction
 	tx, err := h.ProductRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
@@ -310,7 +707,7 @@ func (h *Handler) DeleteProduct(w http.ResponseWriter, r *http.Request, productI
 	// Check if product exists
 	exists, err := h.ProductRepo.Exists(r.Context(), tx, id)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to check product existence")
+		responseServerError(w, r, "Failed to check product existence", err)
 		return
 	}
 	if !exists {
@@ -321,7 +718,7 @@ func (h *Handler) DeleteProduct(w http.ResponseWriter, r *http.Request, productI
 	// Check if product has reviews
 	hasReviews, err := h.ReviewRepo.HasReviewsByProductID(r.Context(), tx, id)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to check product reviews")
+		responseServerError(w, r, "Failed to check product reviews", err)
 		return
 	}
 	if hasReviews {
@@ -329,6 +726,13 @@ func (h *Handler) DeleteProduct(w http.ResponseWriter, r *http.Request, productI
 		return
 	}
 
+	// Fetch product images so their files can be removed once the product is gone
+	imageList, err := h.ImageRepo.ListByProductID(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch product images", err)
+		return
+	}
+
 	// Delete product
 	err = h.ProductRepo.Delete(r.Context(), tx, id)
 	if err != nil {
@@ -336,32 +740,83 @@ func (h *Handler) DeleteProduct(w http.ResponseWriter, r *http.Request, productI
 			responseError(w, http.StatusNotFound, "Product not found")
 			return
 		}
-		responseError(w, http.StatusInternalServerError, "Failed to delete product")
+		responseServerError(w, r, "Failed to delete product", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
 		return
 	}
 
+	// Invalidate all cached data of the product
+	if h.Cache != nil {
+		h.Cache.InvalidateProductCache(r.Context(), id)
+	}
+
+	// Delete image files of the product
+	for _, image := range imageList {
+		h.deleteBlobs(r.Context(), image.BlobKey, image.ThumbnailKey)
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// productToResponse converts ProductWithRating model to API response.
-func productToResponse(p *models.ProductWithRating) api.Product {
+// productToResponse converts ProductWithRating model and its variants to API response.
+// Images are left empty; callers fill them in with imagesToResponse.
+func productToResponse(p *models.ProductWithRating, variantList []models.ProductVariant) api.Product {
 	var avgRating *float32
 	if p.AverageRating != nil {
 		rating := float32(*p.AverageRating)
 		avgRating = &rating
 	}
 
+	tags := api.ProductTags(p.Tags)
+	if tags == nil {
+		tags = api.ProductTags{}
+	}
+
+	attributes := api.ProductAttributes(p.Attributes)
+	if attributes == nil {
+		attributes = api.ProductAttributes{}
+	}
+
 	return api.Product{
 		Id:            strconv.FormatInt(p.ID, 10),
 		Name:          p.Name,
 		Description:   getStringValue(p.Description),
-		Price:         float32(p.Price),
+		Price:         p.Price,
+		Currency:      string(p.Currency),
 		AverageRating: avgRating,
+		Tags:          tags,
+		Attributes:    attributes,
+		InStock:       p.InStock,
+		Variants:      variantsToResponse(variantList),
+		Images:        []api.ProductImage{},
+	}
+}
+
+// facetsToResponse converts ProductFacets model to API response, grouping attribute values by key.
+func facetsToResponse(f *models.ProductFacets) api.ProductFacets {
+	response := api.ProductFacets{
+		Tags:       make([]api.FacetCount, len(f.Tags)),
+		Attributes: []api.AttributeFacet{},
+	}
+
+	for i, tag := range f.Tags {
+		response.Tags[i] = api.FacetCount{Value: tag.Value, Count: int(tag.Count)}
 	}
+
+	// Attribute counts arrive ordered by key, so values of the same key are adjacent
+	for _, attr := range f.Attributes {
+		n := len(response.Attributes)
+		if n == 0 || response.Attributes[n-1].Key != attr.Key {
+			response.Attributes = append(response.Attributes, api.AttributeFacet{Key: attr.Key, Values: []api.FacetCount{}})
+			n++
+		}
+		response.Attributes[n-1].Values = append(response.Attributes[n-1].Values, api.FacetCount{Value: attr.Value, Count: int(attr.Count)})
+	}
+
+	return response
 }
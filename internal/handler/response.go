@@ -3,8 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/tenant"
+	"time"
 )
 
 // responseJSON writes a JSON response with the given status code.
@@ -16,6 +20,30 @@ func responseJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	}
 }
 
+// responseSecretJSON writes a JSON response carrying a secret, e.g. a plaintext API key. It is
+// marked no-store so that neither HTTP caches nor the idempotency store keep it.
+func responseSecretJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Cache-Control", "no-store")
+	responseJSON(w, statusCode, data)
+}
+
+// responseCachedJSON writes a JSON payload read through the cache with a 200 status. The
+// Cache-Control header lets the client reuse the response until freshUntil, the end of the cached
+// entry's TTL. It is private, since the tenant may come from the host or the token as well as
+// from the header shared caches could vary on.
+func responseCachedJSON(w http.ResponseWriter, payload []byte, freshUntil time.Time) {
+	maxAge := int(time.Until(freshUntil) / time.Second)
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	w.Header().Add("Vary", tenant.Header)
+	w.WriteHeader(http.StatusOK)
+	//nolint:errcheck // Response write error cannot be handled meaningfully here
+	w.Write(payload)
+}
+
 // responseError writes an error response with the given status code and message.
 func responseError(w http.ResponseWriter, statusCode int, message string) {
 	errorResponse := api.ErrorResponse{
@@ -24,6 +52,13 @@ func responseError(w http.ResponseWriter, statusCode int, message string) {
 	responseJSON(w, statusCode, errorResponse)
 }
 
+// responseServerError logs err with the request ID of the request and writes a 500 response
+// with the given message. The error itself is not exposed to the client.
+func responseServerError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	slog.ErrorContext(r.Context(), message, logging.Error(err))
+	responseError(w, http.StatusInternalServerError, message)
+}
+
 // errValidation creates a validation error with field and message.
 func errValidation(field, message string) error {
 	return fmt.Errorf("validation error: %s - %s", field, message)
@@ -1,16 +1,24 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/logging"
 	"product_review_hub/internal/models"
 	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/repository/products"
 	"product_review_hub/internal/repository/reviews"
+	"product_review_hub/internal/repository/variants"
+
+	"github.com/jmoiron/sqlx"
 )
 
 const (
@@ -43,18 +51,31 @@ func (h *Handler) CreateProductReview(w http.ResponseWriter, r *http.Request, pr
 		return
 	}
 
+	variantID, err := parseOptionalID(req.VariantId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("variant_id", "variant_id must be a valid variant ID").Error())
+		return
+	}
+
 	// Begin transaction
 	tx, err := h.ProductRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
 
+	// Identify the author
+	user, err := h.currentUser(r.Context(), tx)
+	if err != nil {
+		writeCurrentUserError(w, r, err)
+		return
+	}
+
 	// Check if product exists
 	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to check product existence")
+		responseServerError(w, r, "Failed to check product existence", err)
 		return
 	}
 	if !exists {
@@ -62,25 +83,39 @@ func (h *Handler) CreateProductReview(w http.ResponseWriter, r *http.Request, pr
 		return
 	}
 
-	// Prepare create params
+	// Check if variant belongs to the product
+	if variantID != nil {
+		if err := h.checkReviewVariant(r.Context(), tx, *variantID, prodID); err != nil {
+			writeReviewVariantError(w, r, err)
+			return
+		}
+	}
+
+	// Prepare create params; the author's name is taken from the account
 	params := models.CreateReviewParams{
 		ProductID: prodID,
+		VariantID: variantID,
+		UserID:    &user.ID,
 		Rating:    req.Rating,
-		FirstName: getStringValue(req.FirstName),
-		LastName:  getStringValue(req.LastName),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
 		Comment:   req.Comment,
 	}
 
 	// Create review
 	review, err := h.ReviewRepo.Create(r.Context(), tx, params)
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to create review")
+		if errors.Is(err, reviews.ErrDuplicate) {
+			responseError(w, http.StatusConflict, "You have already reviewed this product")
+			return
+		}
+		responseServerError(w, r, "Failed to create review", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
 		return
 	}
 
@@ -92,18 +127,19 @@ func (h *Handler) CreateProductReview(w http.ResponseWriter, r *http.Request, pr
 	// Publish review created event
 	if h.Publisher != nil {
 		event := rabbitmq.NewReviewEvent(
+			r.Context(),
 			rabbitmq.EventReviewCreated,
 			strconv.FormatInt(review.ID, 10),
 			strconv.FormatInt(review.ProductID, 10),
 			review.Rating,
 		)
 		if err := h.Publisher.Publish(r.Context(), event); err != nil {
-			log.Printf("Failed to publish review created event: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to publish review created event", logging.Error(err))
 		}
 	}
 
 	// Return created review
-	responseJSON(w, http.StatusCreated, reviewToResponse(review))
+	responseJSON(w, http.StatusCreated, h.reviewToResponse(review))
 }
 
 // GetProductReviews returns a paginated list of reviews for a product.
@@ -136,70 +172,36 @@ func (h *Handler) GetProductReviews(w http.ResponseWriter, r *http.Request, prod
 		}
 	}
 
-	// Try to get reviews from cache
-	if h.Cache != nil {
-		cachedReviews, err := h.Cache.GetReviews(r.Context(), prodID, limit, offset)
-		if err != nil {
-			log.Printf("Failed to get reviews from cache: %v", err)
-		} else if cachedReviews != nil {
-			// Cache hit - return cached reviews
-			response := make([]api.Review, len(cachedReviews))
-			for i, rev := range cachedReviews {
-				response[i] = reviewToResponse(&rev)
-			}
-			responseJSON(w, http.StatusOK, response)
-			return
-		}
-	}
-
-	// Cache miss - fetch from database
-	// Begin transaction
-	tx, err := h.ProductRepo.BeginTx(r.Context())
-	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
-		return
-	}
-	defer tx.Rollback()
-
-	// Check if product exists
-	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
-	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to check product existence")
-		return
-	}
-	if !exists {
-		responseError(w, http.StatusNotFound, "Product not found")
-		return
-	}
-
-	// Fetch reviews
-	reviewList, err := h.ReviewRepo.ListByProductID(r.Context(), tx, models.ListReviewsParams{
+	listParams := models.ListReviewsParams{
 		ProductID: prodID,
+		HasMedia:  params.HasMedia,
 		Limit:     limit,
 		Offset:    offset,
-	})
-	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to fetch reviews")
-		return
 	}
-
-	// Commit transaction
-	if err := h.ProductRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
-		return
+	load := func(ctx context.Context) ([]models.Review, error) {
+		return h.loadProductReviews(ctx, listParams)
 	}
 
-	// Store in cache
-	if h.Cache != nil {
-		if err := h.Cache.SetReviews(r.Context(), prodID, limit, offset, reviewList); err != nil {
-			log.Printf("Failed to cache reviews: %v", err)
+	// Read through the cache; only the unfiltered list is cached
+	var reviewList []models.Review
+	if h.Cache != nil && params.HasMedia == nil {
+		reviewList, err = h.Cache.Reviews(r.Context(), prodID, limit, offset, load)
+	} else {
+		reviewList, err = load(r.Context())
+	}
+	if err != nil {
+		if errors.Is(err, products.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Product not found")
+			return
 		}
+		responseServerError(w, r, "Failed to fetch reviews", err)
+		return
 	}
 
 	// Convert to API response
 	response := make([]api.Review, len(reviewList))
 	for i, rev := range reviewList {
-		response[i] = reviewToResponse(&rev)
+		response[i] = h.reviewToResponse(&rev)
 	}
 
 	responseJSON(w, http.StatusOK, response)
@@ -233,22 +235,44 @@ func (h *Handler) UpdateProductReview(w http.ResponseWriter, r *http.Request, pr
 		return
 	}
 
-	// Prepare update params
-	params := models.UpdateReviewParams{
-		Rating:    req.Rating,
-		FirstName: getStringValue(req.FirstName),
-		LastName:  getStringValue(req.LastName),
-		Comment:   req.Comment,
+	variantID, err := parseOptionalID(req.VariantId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("variant_id", "variant_id must be a valid variant ID").Error())
+		return
 	}
 
 	// Begin transaction
 	tx, err := h.ReviewRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
 
+	// Check that the caller may modify the review
+	existing, err := h.reviewForChange(r.Context(), tx, revID, prodID, reviewEditors)
+	if err != nil {
+		writeReviewAccessError(w, r, err)
+		return
+	}
+
+	// Prepare update params; the author's name does not change
+	params := models.UpdateReviewParams{
+		VariantID: variantID,
+		Rating:    req.Rating,
+		FirstName: existing.FirstName,
+		LastName:  existing.LastName,
+		Comment:   req.Comment,
+	}
+
+	// Check if variant belongs to the product
+	if variantID != nil {
+		if err := h.checkReviewVariant(r.Context(), tx, *variantID, prodID); err != nil {
+			writeReviewVariantError(w, r, err)
+			return
+		}
+	}
+
 	// Update review
 	review, err := h.ReviewRepo.UpdateByIDAndProductID(r.Context(), tx, revID, prodID, params)
 	if err != nil {
@@ -256,13 +280,20 @@ func (h *Handler) UpdateProductReview(w http.ResponseWriter, r *http.Request, pr
 			responseError(w, http.StatusNotFound, "Review not found")
 			return
 		}
-		responseError(w, http.StatusInternalServerError, "Failed to update review")
+		responseServerError(w, r, "Failed to update review", err)
+		return
+	}
+
+	// Fetch photos of the review
+	review.Photos, err = h.PhotoRepo.ListByReviewID(r.Context(), tx, review.ID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch review photos", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ReviewRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
 		return
 	}
 
@@ -274,17 +305,18 @@ func (h *Handler) UpdateProductReview(w http.ResponseWriter, r *http.Request, pr
 	// Publish review updated event
 	if h.Publisher != nil {
 		event := rabbitmq.NewReviewEvent(
+			r.Context(),
 			rabbitmq.EventReviewUpdated,
 			strconv.FormatInt(review.ID, 10),
 			strconv.FormatInt(review.ProductID, 10),
 			review.Rating,
 		)
 		if err := h.Publisher.Publish(r.Context(), event); err != nil {
-			log.Printf("Failed to publish review updated event: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to publish review updated event", logging.Error(err))
 		}
 	}
 
-	responseJSON(w, http.StatusOK, reviewToResponse(review))
+	responseJSON(w, http.StatusOK, h.reviewToResponse(review))
 }
 
 // DeleteProductReview deletes a review.
@@ -305,11 +337,24 @@ func (h *Handler) DeleteProductReview(w http.ResponseWriter, r *http.Request, pr
 	// Begin transaction
 	tx, err := h.ReviewRepo.BeginTx(r.Context())
 	if err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to begin transaction")
+		responseServerError(w, r, "Failed to begin transaction", err)
 		return
 	}
 	defer tx.Rollback()
 
+	// Check that the caller may delete the review
+	if _, err := h.reviewForChange(r.Context(), tx, revID, prodID, reviewModerators); err != nil {
+		writeReviewAccessError(w, r, err)
+		return
+	}
+
+	// Fetch photos so their files can be removed with the review
+	photos, err := h.PhotoRepo.ListByReviewID(r.Context(), tx, revID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch review photos", err)
+		return
+	}
+
 	// Delete review
 	err = h.ReviewRepo.DeleteByIDAndProductID(r.Context(), tx, revID, prodID)
 	if err != nil {
@@ -317,16 +362,21 @@ func (h *Handler) DeleteProductReview(w http.ResponseWriter, r *http.Request, pr
 			responseError(w, http.StatusNotFound, "Review not found")
 			return
 		}
-		responseError(w, http.StatusInternalServerError, "Failed to delete review")
+		responseServerError(w, r, "Failed to delete review", err)
 		return
 	}
 
 	// Commit transaction
 	if err := h.ReviewRepo.CommitTx(tx); err != nil {
-		responseError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		responseServerError(w, r, "Failed to commit transaction", err)
 		return
 	}
 
+	// Delete photo files
+	for _, photo := range photos {
+		h.deleteBlobs(r.Context(), photo.BlobKey, photo.ThumbnailKey)
+	}
+
 	// Invalidate cache for product reviews and rating
 	if h.Cache != nil {
 		h.Cache.InvalidateProductCache(r.Context(), prodID)
@@ -335,13 +385,14 @@ func (h *Handler) DeleteProductReview(w http.ResponseWriter, r *http.Request, pr
 	// Publish review deleted event
 	if h.Publisher != nil {
 		event := rabbitmq.NewReviewEvent(
+			r.Context(),
 			rabbitmq.EventReviewDeleted,
 			reviewId,
 			productId,
 			0,
 		)
 		if err := h.Publisher.Publish(r.Context(), event); err != nil {
-			log.Printf("Failed to publish review deleted event: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to publish review deleted event", logging.Error(err))
 		}
 	}
 
@@ -356,19 +407,147 @@ func validateRating(rating int) error {
 	return nil
 }
 
+// errForbidden is returned when the caller may not modify a review.
+var errForbidden = errors.New("forbidden")
+
+// Roles that may change reviews written by other users. Moderators can remove reviews and
+// their photos, but only admins can edit them.
+var (
+	reviewEditors    = []string{auth.RoleAdmin}
+	reviewModerators = []string{auth.RoleAdmin, auth.RoleModerator}
+)
+
+// reviewForChange returns a review of the product after checking that the caller is its
+// author or has one of the privileged roles.
+func (h *Handler) reviewForChange(ctx context.Context, tx *sqlx.Tx, reviewID, productID int64, privileged []string) (*models.Review, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	review, err := h.ReviewRepo.GetByIDAndProductID(ctx, tx, reviewID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canModifyReview(principal, review, privileged) {
+		return nil, errForbidden
+	}
+	return review, nil
+}
+
+// writeReviewAccessError writes the response for a failed reviewForChange check.
+func writeReviewAccessError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		responseError(w, http.StatusUnauthorized, "Authentication required")
+	case errors.Is(err, errForbidden):
+		responseError(w, http.StatusForbidden, "You are not allowed to modify this review")
+	case errors.Is(err, reviews.ErrNotFound):
+		responseError(w, http.StatusNotFound, "Review not found")
+	default:
+		responseServerError(w, r, "Failed to fetch review", err)
+	}
+}
+
+// loadProductReviews loads a reviews page of a product with the photos of its reviews from the
+// database. It returns products.ErrNotFound when the product does not exist.
+func (h *Handler) loadProductReviews(ctx context.Context, params models.ListReviewsParams) ([]models.Review, error) {
+	// Begin transaction
+	tx, err := h.ProductRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(ctx, tx, params.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check product existence: %w", err)
+	}
+	if !exists {
+		return nil, products.ErrNotFound
+	}
+
+	// Fetch reviews
+	reviewList, err := h.ReviewRepo.ListByProductID(ctx, tx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+
+	// Fetch photos of the reviews
+	if err := h.loadReviewPhotos(ctx, tx, reviewList); err != nil {
+		return nil, fmt.Errorf("failed to fetch review photos: %w", err)
+	}
+
+	// Commit transaction
+	if err := h.ProductRepo.CommitTx(tx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return reviewList, nil
+}
+
+// loadReviewPhotos sets the photos of each review in the list.
+func (h *Handler) loadReviewPhotos(ctx context.Context, tx *sqlx.Tx, reviewList []models.Review) error {
+	reviewIDs := make([]int64, len(reviewList))
+	for i := range reviewList {
+		reviewIDs[i] = reviewList[i].ID
+	}
+
+	photosByReview, err := h.PhotoRepo.ListByReviewIDs(ctx, tx, reviewIDs)
+	if err != nil {
+		return err
+	}
+	for i := range reviewList {
+		reviewList[i].Photos = photosByReview[reviewList[i].ID]
+	}
+	return nil
+}
+
+// checkReviewVariant checks that the reviewed variant exists and belongs to the product.
+func (h *Handler) checkReviewVariant(ctx context.Context, tx *sqlx.Tx, variantID, productID int64) error {
+	_, err := h.VariantRepo.GetByIDAndProductID(ctx, tx, variantID, productID)
+	return err
+}
+
+// writeReviewVariantError writes the response for a failed review variant check.
+func writeReviewVariantError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, variants.ErrNotFound) {
+		responseError(w, http.StatusBadRequest, errValidation("variant_id", "variant does not exist for this product").Error())
+		return
+	}
+	responseServerError(w, r, "Failed to check variant existence", err)
+}
+
 // parseID parses a string ID to int64.
 func parseID(id string) (int64, error) {
 	return strconv.ParseInt(id, 10, 64)
 }
 
 // reviewToResponse converts a Review model to API response.
-func reviewToResponse(review *models.Review) api.Review {
+func (h *Handler) reviewToResponse(review *models.Review) api.Review {
+	var variantID *string
+	if review.VariantID != nil {
+		id := strconv.FormatInt(*review.VariantID, 10)
+		variantID = &id
+	}
+
+	var userID *string
+	if review.UserID != nil {
+		id := strconv.FormatInt(*review.UserID, 10)
+		userID = &id
+	}
+
 	return api.Review{
 		Id:        strconv.FormatInt(review.ID, 10),
 		ProductId: strconv.FormatInt(review.ProductID, 10),
+		VariantId: variantID,
+		UserId:    userID,
 		Rating:    review.Rating,
 		FirstName: &review.FirstName,
 		LastName:  &review.LastName,
 		Comment:   review.Comment,
+		Photos:    h.photosToResponse(review.Photos),
 	}
 }
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/reviewphotos"
+)
+
+const (
+	maxReviewPhotoSize = 5 << 20
+	maxReviewPhotos    = 5
+)
+
+// CreateReviewPhoto attaches a photo to a review.
+// The photo is re-encoded without its metadata before it is stored.
+func (h *Handler) CreateReviewPhoto(w http.ResponseWriter, r *http.Request, productId string, reviewId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	revID, err := parseID(reviewId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid review ID")
+		return
+	}
+
+	// Parse multipart form
+	if err := parseMultipartForm(w, r, maxReviewPhotoSize+multipartOverhead); err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			responseError(w, http.StatusRequestEntityTooLarge, "Photo exceeds the maximum size of 5 MB")
+			return
+		}
+		responseError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	// Read, validate and strip photo
+	_, fh, err := r.FormFile("file")
+	if err != nil {
+		responseError(w, http.StatusBadRequest, errValidation("file", "file is required").Error())
+		return
+	}
+	data, err := readFormFile(fh, maxReviewPhotoSize)
+	if err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			responseError(w, http.StatusRequestEntityTooLarge, "Photo exceeds the maximum size of 5 MB")
+			return
+		}
+		responseError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	upload, err := processStrippedImage(data, imageThumbnailSize)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, imageValidationError("file", err).Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PhotoRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check that the caller may modify the review
+	if _, err := h.reviewForChange(r.Context(), tx, revID, prodID, reviewEditors); err != nil {
+		writeReviewAccessError(w, r, err)
+		return
+	}
+
+	// Store files
+	blobKey, thumbnailKey, err := h.storeImage(r.Context(), "reviews/"+strconv.FormatInt(revID, 10), upload)
+	if err != nil {
+		responseServerError(w, r, "Failed to store photo", err)
+		return
+	}
+
+	// Record photo
+	photo, err := h.PhotoRepo.Create(r.Context(), tx, models.CreateReviewPhotoParams{
+		ReviewID:     revID,
+		BlobKey:      blobKey,
+		ThumbnailKey: thumbnailKey,
+		ContentType:  upload.ContentType,
+		SizeBytes:    int64(len(upload.Data)),
+		Width:        upload.Width,
+		Height:       upload.Height,
+	}, maxReviewPhotos)
+	if err != nil {
+		h.deleteBlobs(r.Context(), blobKey, thumbnailKey)
+		switch {
+		case errors.Is(err, reviewphotos.ErrTooManyPhotos):
+			responseError(w, http.StatusConflict, fmt.Sprintf("Review already has the maximum of %d photos", maxReviewPhotos))
+		case errors.Is(err, reviewphotos.ErrReviewNotFound):
+			responseError(w, http.StatusNotFound, "Review not found")
+		default:
+			responseServerError(w, r, "Failed to create photo", err)
+		}
+		return
+	}
+
+	// Commit transaction
+	if err := h.PhotoRepo.CommitTx(tx); err != nil {
+		h.deleteBlobs(r.Context(), blobKey, thumbnailKey)
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cache for product reviews
+	if h.Cache != nil {
+		h.Cache.InvalidateProductCache(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusCreated, h.photoToResponse(photo))
+}
+
+// DeleteReviewPhoto deletes a photo of a review and its files.
+func (h *Handler) DeleteReviewPhoto(w http.ResponseWriter, r *http.Request, productId string, reviewId string, photoId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	revID, err := parseID(reviewId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid review ID")
+		return
+	}
+
+	id, err := parseID(photoId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid photo ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.PhotoRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check that the caller may modify the review
+	if _, err := h.reviewForChange(r.Context(), tx, revID, prodID, reviewModerators); err != nil {
+		writeReviewAccessError(w, r, err)
+		return
+	}
+
+	// Delete photo metadata
+	photo, err := h.PhotoRepo.DeleteByIDAndReviewID(r.Context(), tx, id, revID)
+	if err != nil {
+		if errors.Is(err, reviewphotos.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Photo not found")
+			return
+		}
+		responseServerError(w, r, "Failed to delete photo", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.PhotoRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Delete files
+	h.deleteBlobs(r.Context(), photo.BlobKey, photo.ThumbnailKey)
+
+	// Invalidate cache for product reviews
+	if h.Cache != nil {
+		h.Cache.InvalidateProductCache(r.Context(), prodID)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// photoToResponse converts ReviewPhoto model to API response.
+func (h *Handler) photoToResponse(photo *models.ReviewPhoto) api.ReviewPhoto {
+	return api.ReviewPhoto{
+		Id:           strconv.FormatInt(photo.ID, 10),
+		ReviewId:     strconv.FormatInt(photo.ReviewID, 10),
+		Url:          h.Blobs.URL(photo.BlobKey),
+		ThumbnailUrl: h.Blobs.URL(photo.ThumbnailKey),
+		ContentType:  photo.ContentType,
+		Size:         photo.SizeBytes,
+		Width:        photo.Width,
+		Height:       photo.Height,
+		CreatedAt:    photo.CreatedAt,
+	}
+}
+
+// photosToResponse converts a list of ReviewPhoto models to API response.
+func (h *Handler) photosToResponse(photos []models.ReviewPhoto) []api.ReviewPhoto {
+	response := make([]api.ReviewPhoto, len(photos))
+	for i := range photos {
+		response[i] = h.photoToResponse(&photos[i])
+	}
+	return response
+}
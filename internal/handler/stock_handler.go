@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/repository/stock"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GetProductStock returns the stock level of a product.
+func (h *Handler) GetProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.StockRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Fetch stock
+	productStock, err := h.StockRepo.GetByProductID(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch stock", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.StockRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, stockToResponse(productStock))
+}
+
+// UpdateProductStock sets the low-stock threshold of a product.
+func (h *Handler) UpdateProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.StockSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if req.LowStockThreshold < 0 {
+		responseError(w, http.StatusBadRequest, errValidation("low_stock_threshold", "low_stock_threshold must not be negative").Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.StockRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Set threshold
+	productStock, err := h.StockRepo.SetLowStockThreshold(r.Context(), tx, prodID, req.LowStockThreshold)
+	if err != nil {
+		responseServerError(w, r, "Failed to update stock settings", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.StockRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusOK, stockToResponse(productStock))
+}
+
+// AdjustProductStock changes the quantity on hand of a product.
+func (h *Handler) AdjustProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.StockAdjustment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if req.Delta == 0 {
+		responseError(w, http.StatusBadRequest, errValidation("delta", "delta must not be 0").Error())
+		return
+	}
+
+	h.changeStock(w, r, prodID, models.StockAdjusted, req.Delta, func(tx *sqlx.Tx) (*models.ProductStock, error) {
+		return h.StockRepo.Adjust(r.Context(), tx, prodID, req.Delta)
+	})
+}
+
+// ReserveProductStock holds units of the available stock of a product.
+func (h *Handler) ReserveProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.StockQuantity
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateStockQuantity(req); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.changeStock(w, r, prodID, models.StockReserved, req.Quantity, func(tx *sqlx.Tx) (*models.ProductStock, error) {
+		return h.StockRepo.Reserve(r.Context(), tx, prodID, req.Quantity)
+	})
+}
+
+// ReleaseProductStock gives reserved units of a product back to available stock.
+func (h *Handler) ReleaseProductStock(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.StockQuantity
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateStockQuantity(req); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.changeStock(w, r, prodID, models.StockReleased, req.Quantity, func(tx *sqlx.Tx) (*models.ProductStock, error) {
+		return h.StockRepo.Release(r.Context(), tx, prodID, req.Quantity)
+	})
+}
+
+func validateStockQuantity(req api.StockQuantity) error {
+	if req.Quantity < 1 {
+		return errValidation("quantity", "quantity must be greater than 0")
+	}
+	return nil
+}
+
+// changeStock applies a stock change to an existing product in a transaction,
+// writes the resulting stock level and publishes a stock changed event after commit.
+func (h *Handler) changeStock(w http.ResponseWriter, r *http.Request, prodID int64, operation models.StockOperation, delta int, apply func(tx *sqlx.Tx) (*models.ProductStock, error)) {
+	// Begin transaction
+	tx, err := h.StockRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Change stock
+	productStock, err := apply(tx)
+	if err != nil {
+		if errors.Is(err, stock.ErrInsufficientStock) {
+			responseError(w, http.StatusConflict, "Insufficient stock")
+			return
+		}
+		if errors.Is(err, stock.ErrInsufficientReserved) {
+			responseError(w, http.StatusConflict, "Cannot release more than the reserved quantity")
+			return
+		}
+		responseServerError(w, r, "Failed to update stock", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.StockRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	// Publish event
+	if h.Publisher != nil {
+		event := rabbitmq.NewStockChangedEvent(r.Context(), strconv.FormatInt(prodID, 10), operation, delta, productStock)
+		if err := h.Publisher.PublishStockChanged(r.Context(), event); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to publish stock changed event", logging.Error(err))
+		}
+	}
+
+	responseJSON(w, http.StatusOK, stockToResponse(productStock))
+}
+
+// stockToResponse converts ProductStock model to API response.
+func stockToResponse(s *models.ProductStock) api.ProductStock {
+	return api.ProductStock{
+		ProductId:         strconv.FormatInt(s.ProductID, 10),
+		Quantity:          s.Quantity,
+		Reserved:          s.Reserved,
+		Available:         s.Available(),
+		LowStockThreshold: s.LowStockThreshold,
+		LowStock:          s.IsLow(),
+		UpdatedAt:         s.UpdatedAt,
+	}
+}
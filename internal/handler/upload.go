@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"product_review_hub/internal/imaging"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/storage"
+)
+
+// multipartOverhead is the room left for form fields and part headers on top of the file size limits.
+const multipartOverhead = 1 << 20
+
+// errUploadTooLarge is returned when an upload exceeds its size limit.
+var errUploadTooLarge = errors.New("upload too large")
+
+// parseMultipartForm parses a multipart request body of at most maxBody bytes.
+func parseMultipartForm(w http.ResponseWriter, r *http.Request, maxBody int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+	if err := r.ParseMultipartForm(maxBody); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return errUploadTooLarge
+		}
+		return err
+	}
+	return nil
+}
+
+// readFormFile reads an uploaded file of at most maxSize bytes.
+func readFormFile(fh *multipart.FileHeader, maxSize int64) ([]byte, error) {
+	if fh.Size > maxSize {
+		return nil, errUploadTooLarge
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errUploadTooLarge
+	}
+
+	return data, nil
+}
+
+// formValue returns the trimmed value of a multipart form field, or nil if it is empty.
+func formValue(r *http.Request, key string) *string {
+	value := strings.TrimSpace(r.FormValue(key))
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// uploadedImage is a validated image upload together with its generated thumbnail.
+type uploadedImage struct {
+	Data          []byte
+	ContentType   string
+	Width         int
+	Height        int
+	Thumbnail     []byte
+	ThumbnailType string
+}
+
+// processImage validates image data by content and generates a thumbnail that fits within thumbnailSize.
+func processImage(data []byte, thumbnailSize int) (*uploadedImage, error) {
+	contentType, err := imaging.DetectContentType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUploadedImage(data, contentType, img, thumbnailSize)
+}
+
+// processStrippedImage works like processImage but re-encodes the image to drop its metadata
+// (EXIF location, camera details, embedded text chunks). The EXIF orientation is applied to
+// the pixels first, and GIFs become PNGs.
+func processStrippedImage(data []byte, thumbnailSize int) (*uploadedImage, error) {
+	contentType, err := imaging.DetectContentType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	img = imaging.Orient(img, imaging.Orientation(data))
+
+	var stripped bytes.Buffer
+	contentType, err = imaging.Encode(&stripped, img, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUploadedImage(stripped.Bytes(), contentType, img, thumbnailSize)
+}
+
+// newUploadedImage generates the thumbnail of a decoded image.
+func newUploadedImage(data []byte, contentType string, img image.Image, thumbnailSize int) (*uploadedImage, error) {
+	var thumbnail bytes.Buffer
+	thumbnailType, err := imaging.Encode(&thumbnail, imaging.Thumbnail(img, thumbnailSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uploadedImage{
+		Data:          data,
+		ContentType:   contentType,
+		Width:         img.Bounds().Dx(),
+		Height:        img.Bounds().Dy(),
+		Thumbnail:     thumbnail.Bytes(),
+		ThumbnailType: thumbnailType,
+	}, nil
+}
+
+// imageValidationError converts an image processing error to a validation error of the field.
+func imageValidationError(field string, err error) error {
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		return errValidation(field, "only JPEG, PNG and GIF images are supported")
+	case errors.Is(err, imaging.ErrTooManyPixels):
+		return errValidation(field, "image dimensions are too large")
+	default:
+		return errValidation(field, "invalid image")
+	}
+}
+
+// storeImage stores an uploaded image and its thumbnail under prefix and returns their keys.
+// Nothing is left behind when storing either file fails.
+func (h *Handler) storeImage(ctx context.Context, prefix string, upload *uploadedImage) (string, string, error) {
+	ext := imaging.Extension(upload.ContentType)
+	blobKey, err := storage.NewKey(prefix, ext)
+	if err != nil {
+		return "", "", err
+	}
+	thumbnailKey := strings.TrimSuffix(blobKey, ext) + "_thumb" + imaging.Extension(upload.ThumbnailType)
+
+	if err := h.Blobs.Put(ctx, blobKey, bytes.NewReader(upload.Data)); err != nil {
+		return "", "", err
+	}
+	if err := h.Blobs.Put(ctx, thumbnailKey, bytes.NewReader(upload.Thumbnail)); err != nil {
+		h.deleteBlobs(ctx, blobKey)
+		return "", "", err
+	}
+
+	return blobKey, thumbnailKey, nil
+}
+
+// deleteBlobs removes files from the blob store, logging failures. It runs after the metadata
+// referencing the files is gone, so a failure only leaves an unreferenced file behind.
+func (h *Handler) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := h.Blobs.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			slog.WarnContext(ctx, "Failed to delete blob", logging.Error(err), slog.String("key", key))
+		}
+	}
+}
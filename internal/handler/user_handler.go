@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/users"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// errUnauthenticated is returned when a request does not identify an existing user.
+var errUnauthenticated = errors.New("authentication required")
+
+// CreateUser creates a new user account.
+func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	// Decode request body
+	var req api.UserCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	params, err := validateUserCreate(req)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.UserRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Create user
+	user, err := h.UserRepo.Create(r.Context(), tx, params)
+	if err != nil {
+		if errors.Is(err, users.ErrDuplicateEmail) {
+			responseError(w, http.StatusConflict, "User with this email already exists")
+			return
+		}
+		responseServerError(w, r, "Failed to create user", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.UserRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusCreated, userToResponse(user))
+}
+
+// validateUserCreate validates a user creation request and normalizes its email.
+func validateUserCreate(req api.UserCreate) (models.CreateUserParams, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == "" {
+		return models.CreateUserParams{}, errValidation("email", "email is required")
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return models.CreateUserParams{}, errValidation("email", "email must be a valid email address")
+	}
+
+	firstName := strings.TrimSpace(req.FirstName)
+	if firstName == "" {
+		return models.CreateUserParams{}, errValidation("first_name", "first_name is required")
+	}
+	lastName := strings.TrimSpace(req.LastName)
+	if lastName == "" {
+		return models.CreateUserParams{}, errValidation("last_name", "last_name is required")
+	}
+
+	return models.CreateUserParams{
+		Email:     email,
+		FirstName: firstName,
+		LastName:  lastName,
+	}, nil
+}
+
+// GetUserById returns the public profile of a user, without the email address.
+func (h *Handler) GetUserById(w http.ResponseWriter, r *http.Request, userId string) {
+	// Parse user ID
+	id, err := parseID(userId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.UserRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Fetch user
+	user, err := h.UserRepo.GetByID(r.Context(), tx, id)
+	if err != nil {
+		if errors.Is(err, users.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch user", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.UserRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, userToPublicResponse(user))
+}
+
+// GetUserReviews returns a paginated list of the reviews written by a user.
+func (h *Handler) GetUserReviews(w http.ResponseWriter, r *http.Request, userId string, params api.GetUserReviewsParams) {
+	// Parse user ID
+	id, err := parseID(userId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Apply pagination defaults
+	limit := defaultReviewLimit
+	offset := 0
+
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > maxReviewLimit {
+			limit = maxReviewLimit
+		}
+	}
+
+	if params.Offset != nil {
+		offset = *params.Offset
+		if offset < 0 {
+			offset = 0
+		}
+	}
+
+	// Begin transaction
+	tx, err := h.UserRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if user exists
+	exists, err := h.UserRepo.Exists(r.Context(), tx, id)
+	if err != nil {
+		responseServerError(w, r, "Failed to check user existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	// Fetch reviews with their photos
+	reviewList, err := h.ReviewRepo.ListByUserID(r.Context(), tx, models.ListUserReviewsParams{
+		UserID: id,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch reviews", err)
+		return
+	}
+	if err := h.loadReviewPhotos(r.Context(), tx, reviewList); err != nil {
+		responseServerError(w, r, "Failed to fetch review photos", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.UserRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	response := make([]api.Review, len(reviewList))
+	for i := range reviewList {
+		response[i] = h.reviewToResponse(&reviewList[i])
+	}
+
+	responseJSON(w, http.StatusOK, response)
+}
+
+// currentUser returns the user identified by the subject of the request's token, or
+// errUnauthenticated when the request does not identify an existing user.
+func (h *Handler) currentUser(ctx context.Context, tx *sqlx.Tx) (*models.User, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	id, err := parseID(principal.Subject)
+	if err != nil {
+		return nil, errUnauthenticated
+	}
+
+	user, err := h.UserRepo.GetByID(ctx, tx, id)
+	if err != nil {
+		if errors.Is(err, users.ErrNotFound) {
+			return nil, errUnauthenticated
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// writeCurrentUserError writes the response for a failed currentUser lookup.
+func writeCurrentUserError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errUnauthenticated) {
+		responseError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	responseServerError(w, r, "Failed to fetch user", err)
+}
+
+// canModifyReview reports whether the principal may change the review: its author always
+// may, and so may any principal with one of the privileged roles. Reviews written before
+// user accounts existed have no author and can only be changed by privileged roles.
+func canModifyReview(principal *auth.Principal, review *models.Review, privileged []string) bool {
+	if principal.HasRole(privileged...) {
+		return true
+	}
+	return review.UserID != nil && strconv.FormatInt(*review.UserID, 10) == principal.Subject
+}
+
+// userToResponse converts User model to API response.
+func userToResponse(user *models.User) api.User {
+	return api.User{
+		Id:        strconv.FormatInt(user.ID, 10),
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+// userToPublicResponse converts User model to the public API response.
+func userToPublicResponse(user *models.User) api.PublicUser {
+	return api.PublicUser{
+		Id:        strconv.FormatInt(user.ID, 10),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: user.CreatedAt,
+	}
+}
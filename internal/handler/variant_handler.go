@@ -0,0 +1,374 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/repository/variants"
+)
+
+// CreateProductVariant creates a new variant of a product.
+func (h *Handler) CreateProductVariant(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Decode request body
+	var req api.ProductVariantCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateVariant(req.Sku, req.Price); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	// Begin transaction
+	tx, err := h.VariantRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Create variant
+	variant, err := h.VariantRepo.Create(r.Context(), tx, models.CreateVariantParams{
+		ProductID: prodID,
+		SKU:       strings.TrimSpace(req.Sku),
+		Options:   variantOptionsFromRequest(req.Options),
+		Price:     req.Price,
+		Active:    active,
+	})
+	if err != nil {
+		if errors.Is(err, variants.ErrDuplicateSKU) {
+			responseError(w, http.StatusConflict, "Variant with this SKU already exists")
+			return
+		}
+		responseServerError(w, r, "Failed to create variant", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.VariantRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusCreated, variantToResponse(variant))
+}
+
+// GetProductVariants returns all variants of a product.
+func (h *Handler) GetProductVariants(w http.ResponseWriter, r *http.Request, productId string) {
+	// Parse product ID
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.VariantRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check if product exists
+	exists, err := h.ProductRepo.Exists(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check product existence", err)
+		return
+	}
+	if !exists {
+		responseError(w, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	// Fetch variants
+	variantList, err := h.VariantRepo.ListByProductID(r.Context(), tx, prodID)
+	if err != nil {
+		responseServerError(w, r, "Failed to fetch variants", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.VariantRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, variantsToResponse(variantList))
+}
+
+// GetProductVariantById returns a variant of a product by its ID.
+func (h *Handler) GetProductVariantById(w http.ResponseWriter, r *http.Request, productId string, variantId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	varID, err := parseID(variantId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid variant ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.VariantRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkProductInTenant(w, r, tx, prodID, "Variant not found") {
+		return
+	}
+
+	// Fetch variant
+	variant, err := h.VariantRepo.GetByIDAndProductID(r.Context(), tx, varID, prodID)
+	if err != nil {
+		if errors.Is(err, variants.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Variant not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch variant", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.VariantRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	responseJSON(w, http.StatusOK, variantToResponse(variant))
+}
+
+// UpdateProductVariant updates an existing variant of a product.
+func (h *Handler) UpdateProductVariant(w http.ResponseWriter, r *http.Request, productId string, variantId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	varID, err := parseID(variantId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid variant ID")
+		return
+	}
+
+	// Decode request body
+	var req api.ProductVariantUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateVariant(req.Sku, req.Price); err != nil {
+		responseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.VariantRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkProductInTenant(w, r, tx, prodID, "Variant not found") {
+		return
+	}
+
+	// Update variant
+	variant, err := h.VariantRepo.UpdateByIDAndProductID(r.Context(), tx, varID, prodID, models.UpdateVariantParams{
+		SKU:     strings.TrimSpace(req.Sku),
+		Options: variantOptionsFromRequest(req.Options),
+		Price:   req.Price,
+		Active:  req.Active,
+	})
+	if err != nil {
+		if errors.Is(err, variants.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Variant not found")
+			return
+		}
+		if errors.Is(err, variants.ErrDuplicateSKU) {
+			responseError(w, http.StatusConflict, "Variant with this SKU already exists")
+			return
+		}
+		responseServerError(w, r, "Failed to update variant", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.VariantRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	responseJSON(w, http.StatusOK, variantToResponse(variant))
+}
+
+// DeleteProductVariant deletes a variant of a product.
+func (h *Handler) DeleteProductVariant(w http.ResponseWriter, r *http.Request, productId string, variantId string) {
+	// Parse IDs
+	prodID, err := parseID(productId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	varID, err := parseID(variantId)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, "Invalid variant ID")
+		return
+	}
+
+	// Begin transaction
+	tx, err := h.VariantRepo.BeginTx(r.Context())
+	if err != nil {
+		responseServerError(w, r, "Failed to begin transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkProductInTenant(w, r, tx, prodID, "Variant not found") {
+		return
+	}
+
+	// Check if variant exists
+	if _, err := h.VariantRepo.GetByIDAndProductID(r.Context(), tx, varID, prodID); err != nil {
+		if errors.Is(err, variants.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Variant not found")
+			return
+		}
+		responseServerError(w, r, "Failed to fetch variant", err)
+		return
+	}
+
+	// Check if variant has reviews
+	hasReviews, err := h.VariantRepo.HasReviews(r.Context(), tx, varID)
+	if err != nil {
+		responseServerError(w, r, "Failed to check variant reviews", err)
+		return
+	}
+	if hasReviews {
+		responseError(w, http.StatusConflict, "Cannot delete variant with existing reviews")
+		return
+	}
+
+	// Delete variant
+	err = h.VariantRepo.DeleteByIDAndProductID(r.Context(), tx, varID, prodID)
+	if err != nil {
+		if errors.Is(err, variants.ErrNotFound) {
+			responseError(w, http.StatusNotFound, "Variant not found")
+			return
+		}
+		responseServerError(w, r, "Failed to delete variant", err)
+		return
+	}
+
+	// Commit transaction
+	if err := h.VariantRepo.CommitTx(tx); err != nil {
+		responseServerError(w, r, "Failed to commit transaction", err)
+		return
+	}
+
+	// Invalidate cached product and product lists
+	if h.Cache != nil {
+		h.Cache.InvalidateProduct(r.Context(), prodID)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateVariant validates the SKU and the optional price override of a variant.
+func validateVariant(sku string, price *money.Amount) error {
+	if strings.TrimSpace(sku) == "" {
+		return errValidation("sku", "sku is required")
+	}
+	if price != nil {
+		return validatePrice(*price)
+	}
+	return nil
+}
+
+// variantOptionsFromRequest converts API variant options to the model type.
+func variantOptionsFromRequest(options *api.VariantOptions) models.VariantOptions {
+	if options == nil {
+		return models.VariantOptions{}
+	}
+	return models.VariantOptions(*options)
+}
+
+// variantsToResponse converts a list of ProductVariant models to API response.
+func variantsToResponse(variantList []models.ProductVariant) []api.ProductVariant {
+	response := make([]api.ProductVariant, len(variantList))
+	for i := range variantList {
+		response[i] = variantToResponse(&variantList[i])
+	}
+	return response
+}
+
+// variantToResponse converts a ProductVariant model to API response.
+func variantToResponse(v *models.ProductVariant) api.ProductVariant {
+	options := api.VariantOptions(v.Options)
+	if options == nil {
+		options = api.VariantOptions{}
+	}
+
+	return api.ProductVariant{
+		Id:        strconv.FormatInt(v.ID, 10),
+		ProductId: strconv.FormatInt(v.ProductID, 10),
+		Sku:       v.SKU,
+		Options:   options,
+		Price:     v.Price,
+		Active:    v.Active,
+	}
+}
@@ -0,0 +1,202 @@
+// Package health implements liveness and readiness probes.
+//
+// Liveness only reports that the process serves requests. Readiness runs a check per
+// dependency and fails when a critical dependency is down; failing non-critical dependencies
+// only degrade the report. Check results are cached, so that frequent probes from several
+// orchestrators do not hammer the dependencies.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Report statuses.
+const (
+	// StatusOK means every dependency is up.
+	StatusOK = "ok"
+	// StatusDegraded means a non-critical dependency is down.
+	StatusDegraded = "degraded"
+	// StatusUnavailable means a critical dependency is down.
+	StatusUnavailable = "unavailable"
+)
+
+// Check results.
+const (
+	Up   = "up"
+	Down = "down"
+)
+
+const (
+	// DefaultCacheTTL is how long check results are reused.
+	DefaultCacheTTL = 5 * time.Second
+	// DefaultTimeout bounds each check.
+	DefaultTimeout = 2 * time.Second
+)
+
+// CheckFunc checks a dependency and returns an error when it is unusable.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named dependency check.
+type Check struct {
+	Name string
+	// Critical checks make the service unready when they fail.
+	Critical bool
+	Check    CheckFunc
+}
+
+// Result is the outcome of a check.
+type Result struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of all checks.
+type Report struct {
+	Status    string            `json:"status"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every critical check passed.
+func (r *Report) Ready() bool {
+	return r.Status != StatusUnavailable
+}
+
+// Checker runs dependency checks and caches their results.
+type Checker struct {
+	checks  []Check
+	ttl     time.Duration
+	timeout time.Duration
+
+	mu     sync.Mutex
+	report *Report
+}
+
+// NewChecker creates a checker that reuses results for ttl and bounds each check by timeout.
+// Zero durations select DefaultCacheTTL and DefaultTimeout.
+func NewChecker(ttl, timeout time.Duration, checks ...Check) *Checker {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{
+		checks:  checks,
+		ttl:     ttl,
+		timeout: timeout,
+	}
+}
+
+// Run returns the cached report, running the checks concurrently when it has expired.
+// Concurrent callers wait for a single run.
+func (c *Checker) Run(ctx context.Context) *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report != nil && time.Now().Sub(c.report.CheckedAt) < c.ttl {
+		return c.report
+	}
+
+	// A probe that gives up must not leave failed results in the cache
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := &Report{
+		Status:    StatusOK,
+		CheckedAt: time.Now(),
+		Checks:    make(map[string]Result, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+		if result.Status == Up {
+			continue
+		}
+		if check.Critical {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+
+	c.report = report
+	return report
+}
+
+// run runs a single check within the timeout.
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	result := Result{
+		Status:    Up,
+		Critical:  check.Critical,
+		LatencyMS: float64(time.Now().Sub(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = Down
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// ParseCritical parses a comma-separated list of critical check names, e.g. "postgres,rabbitmq".
+func ParseCritical(s string) map[string]bool {
+	critical := make(map[string]bool)
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			critical[name] = true
+		}
+	}
+	return critical
+}
+
+// LiveHandler returns the liveness handler, which responds 200 as long as the process serves
+// requests.
+func LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	})
+}
+
+// ReadyHandler returns the readiness handler, which responds with the report of checker and
+// 503 Service Unavailable when a critical dependency is down.
+func ReadyHandler(checker *Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Run(r.Context())
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	//nolint:errcheck // Response write error cannot be handled meaningfully here
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,123 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(context.Context) error { return nil }
+
+func down(context.Context) error { return errors.New("connection refused") }
+
+func TestChecker_Run(t *testing.T) {
+	t.Run("report ok when all checks pass", func(t *testing.T) {
+		checker := health.NewChecker(time.Minute, time.Second,
+			health.Check{Name: "postgres", Critical: true, Check: up},
+			health.Check{Name: "redis", Check: up},
+		)
+
+		report := checker.Run(context.Background())
+		assert.Equal(t, health.StatusOK, report.Status)
+		assert.True(t, report.Ready())
+		assert.Equal(t, health.Up, report.Checks["postgres"].Status)
+		assert.True(t, report.Checks["postgres"].Critical)
+		assert.Equal(t, health.Up, report.Checks["redis"].Status)
+	})
+
+	t.Run("degrade when a non-critical check fails", func(t *testing.T) {
+		checker := health.NewChecker(time.Minute, time.Second,
+			health.Check{Name: "postgres", Critical: true, Check: up},
+			health.Check{Name: "redis", Check: down},
+		)
+
+		report := checker.Run(context.Background())
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		assert.True(t, report.Ready())
+		assert.Equal(t, health.Down, report.Checks["redis"].Status)
+		assert.Equal(t, "connection refused", report.Checks["redis"].Error)
+	})
+
+	t.Run("become unavailable when a critical check fails", func(t *testing.T) {
+		checker := health.NewChecker(time.Minute, time.Second,
+			health.Check{Name: "postgres", Critical: true, Check: down},
+			health.Check{Name: "redis", Check: down},
+		)
+
+		report := checker.Run(context.Background())
+		assert.Equal(t, health.StatusUnavailable, report.Status)
+		assert.False(t, report.Ready())
+	})
+
+	t.Run("time out slow checks", func(t *testing.T) {
+		checker := health.NewChecker(time.Minute, 10*time.Millisecond,
+			health.Check{Name: "rabbitmq", Critical: true, Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		)
+
+		report := checker.Run(context.Background())
+		assert.Equal(t, health.Down, report.Checks["rabbitmq"].Status)
+		assert.Contains(t, report.Checks["rabbitmq"].Error, "deadline exceeded")
+	})
+
+	t.Run("cache results", func(t *testing.T) {
+		var calls atomic.Int32
+		checker := health.NewChecker(time.Minute, time.Second,
+			health.Check{Name: "postgres", Critical: true, Check: func(context.Context) error {
+				calls.Add(1)
+				return nil
+			}},
+		)
+
+		checker.Run(context.Background())
+		checker.Run(context.Background())
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestParseCritical(t *testing.T) {
+	assert.Equal(t, map[string]bool{"postgres": true, "rabbitmq": true}, health.ParseCritical(" postgres, rabbitmq,,"))
+	assert.Empty(t, health.ParseCritical(""))
+}
+
+func TestReadyHandler(t *testing.T) {
+	t.Run("respond 503 when unavailable", func(t *testing.T) {
+		checker := health.NewChecker(time.Minute, time.Second, health.Check{Name: "postgres", Critical: true, Check: down})
+
+		rec := httptest.NewRecorder()
+		health.ReadyHandler(checker).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, health.StatusUnavailable, report.Status)
+		assert.Equal(t, health.Down, report.Checks["postgres"].Status)
+	})
+
+	t.Run("respond 200 when degraded", func(t *testing.T) {
+		checker := health.NewChecker(time.Minute, time.Second, health.Check{Name: "redis", Check: down})
+
+		rec := httptest.NewRecorder()
+		health.ReadyHandler(checker).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestLiveHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	health.LiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
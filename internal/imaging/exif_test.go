@@ -0,0 +1,101 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withExifOrientation inserts an EXIF segment with the orientation after the SOI marker of JPEG data.
+func withExifOrientation(t *testing.T, data []byte, orientation uint16, order binary.ByteOrder) []byte {
+	t.Helper()
+
+	var tiff bytes.Buffer
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	require.NoError(t, binary.Write(&tiff, order, uint16(42)))
+	require.NoError(t, binary.Write(&tiff, order, uint32(8)))
+	require.NoError(t, binary.Write(&tiff, order, uint16(1)))
+	// Tag, type SHORT, count, value padded to 4 bytes
+	require.NoError(t, binary.Write(&tiff, order, []uint16{exifOrientationTag, 3}))
+	require.NoError(t, binary.Write(&tiff, order, uint32(1)))
+	require.NoError(t, binary.Write(&tiff, order, []uint16{orientation, 0}))
+	require.NoError(t, binary.Write(&tiff, order, uint32(0)))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+func TestOrientation(t *testing.T) {
+	data := encodeJPEG(t, solid(4, 2, color.White))
+
+	assert.Equal(t, 1, Orientation(data))
+	assert.Equal(t, 6, Orientation(withExifOrientation(t, data, 6, binary.BigEndian)))
+	assert.Equal(t, 3, Orientation(withExifOrientation(t, data, 3, binary.LittleEndian)))
+	assert.Equal(t, 1, Orientation(withExifOrientation(t, data, 9, binary.BigEndian)))
+	assert.Equal(t, 1, Orientation(encodePNG(t, solid(4, 2, color.White))))
+	assert.Equal(t, 1, Orientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}))
+}
+
+func TestOrient(t *testing.T) {
+	// Red pixel in the top-left corner of a 2x1 image
+	img := solid(2, 1, color.White)
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	red := color.RGBAModel.Convert(color.RGBA{R: 255, A: 255})
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		redX, redY  int
+	}{
+		{1, 2, 1, 0, 0},
+		{2, 2, 1, 1, 0},
+		{3, 2, 1, 1, 0},
+		{6, 1, 2, 0, 0},
+		{8, 1, 2, 0, 1},
+	}
+
+	for _, tt := range tests {
+		oriented := Orient(img, tt.orientation)
+		assert.Equal(t, tt.width, oriented.Bounds().Dx(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.height, oriented.Bounds().Dy(), "orientation %d", tt.orientation)
+		assert.Equal(t, red, color.RGBAModel.Convert(oriented.At(tt.redX, tt.redY)), "orientation %d", tt.orientation)
+	}
+}
+
+func TestEncodeDropsMetadata(t *testing.T) {
+	data := withExifOrientation(t, encodeJPEG(t, solid(4, 2, color.White)), 6, binary.BigEndian)
+
+	img, err := Decode(data)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = Encode(&buf, Orient(img, Orientation(data)), TypeJPEG)
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "Exif")
+	assert.Equal(t, 1, Orientation(buf.Bytes()))
+}
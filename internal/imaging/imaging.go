@@ -0,0 +1,157 @@
+// Package imaging validates, decodes, resizes and re-encodes uploaded images using the standard library only.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+// Supported content types.
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeGIF  = "image/gif"
+)
+
+// MaxPixels limits the decoded size of an image to protect against decompression bombs.
+const MaxPixels = 40_000_000
+
+// jpegQuality is the quality used when encoding JPEG images.
+const jpegQuality = 85
+
+// Common errors.
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrInvalidImage    = errors.New("invalid image")
+	ErrTooManyPixels   = errors.New("image dimensions are too large")
+)
+
+// DetectContentType returns the content type of the image data, sniffed from its content rather than trusted
+// from the client. Only JPEG, PNG and GIF images are supported.
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case TypeJPEG, TypePNG, TypeGIF:
+		return contentType, nil
+	default:
+		return "", ErrUnsupportedType
+	}
+}
+
+// Extension returns the file extension for a supported content type.
+func Extension(contentType string) string {
+	switch contentType {
+	case TypeJPEG:
+		return ".jpg"
+	case TypePNG:
+		return ".png"
+	case TypeGIF:
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+// Decode decodes the image data after checking its dimensions.
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return img, nil
+}
+
+// Encode writes img in the format of contentType. GIF images are encoded as PNG,
+// so the returned content type may differ from the requested one.
+func Encode(w io.Writer, img image.Image, contentType string) (string, error) {
+	switch contentType {
+	case TypeJPEG:
+		if err := jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return "", fmt.Errorf("failed to encode jpeg: %w", err)
+		}
+		return TypeJPEG, nil
+	case TypePNG, TypeGIF:
+		if err := png.Encode(w, img); err != nil {
+			return "", fmt.Errorf("failed to encode png: %w", err)
+		}
+		return TypePNG, nil
+	default:
+		return "", ErrUnsupportedType
+	}
+}
+
+// Thumbnail scales img down to fit within a maxSize x maxSize box, keeping its aspect ratio.
+// Images that already fit are copied unscaled. Each destination pixel is the average of the
+// source pixels it covers (box filter).
+func Thumbnail(img image.Image, maxSize int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := srcW, srcH
+	if srcW > maxSize || srcH > maxSize {
+		if srcW >= srcH {
+			dstW = maxSize
+			dstH = max(1, srcH*maxSize/srcW)
+		} else {
+			dstH = maxSize
+			dstW = max(1, srcW*maxSize/srcH)
+		}
+	}
+
+	// Work on premultiplied RGBA so averaging treats transparent pixels correctly
+	src := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	if dstW == srcW && dstH == srcH {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := y * srcH / dstH
+		y1 := max(y0+1, (y+1)*srcH/dstH)
+		for x := 0; x < dstW; x++ {
+			x0 := x * srcW / dstW
+			x1 := max(x0+1, (x+1)*srcW/dstW)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,123 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func solid(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDetectContentType(t *testing.T) {
+	img := solid(2, 2, color.White)
+
+	var jpg, gf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpg, img, nil))
+	require.NoError(t, gif.Encode(&gf, img, nil))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{name: "png", data: encodePNG(t, img), want: TypePNG},
+		{name: "jpeg", data: jpg.Bytes(), want: TypeJPEG},
+		{name: "gif", data: gf.Bytes(), want: TypeGIF},
+		{name: "text", data: []byte("hello world"), wantErr: ErrUnsupportedType},
+		{name: "svg", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), wantErr: ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectContentType(tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("valid image", func(t *testing.T) {
+		img, err := Decode(encodePNG(t, solid(3, 2, color.White)))
+		require.NoError(t, err)
+		assert.Equal(t, 3, img.Bounds().Dx())
+		assert.Equal(t, 2, img.Bounds().Dy())
+	})
+
+	t.Run("truncated image", func(t *testing.T) {
+		data := encodePNG(t, solid(3, 2, color.White))
+		_, err := Decode(data[:len(data)/2])
+		assert.ErrorIs(t, err, ErrInvalidImage)
+	})
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("keeps aspect ratio", func(t *testing.T) {
+		thumb := Thumbnail(solid(400, 100, color.White), 100)
+		assert.Equal(t, image.Rect(0, 0, 100, 25), thumb.Bounds())
+
+		thumb = Thumbnail(solid(50, 200, color.White), 100)
+		assert.Equal(t, image.Rect(0, 0, 25, 100), thumb.Bounds())
+	})
+
+	t.Run("does not upscale", func(t *testing.T) {
+		thumb := Thumbnail(solid(20, 10, color.White), 100)
+		assert.Equal(t, image.Rect(0, 0, 20, 10), thumb.Bounds())
+	})
+
+	t.Run("averages covered pixels", func(t *testing.T) {
+		// Alternating black and white columns average to mid grey
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				if x%2 == 0 {
+					img.Set(x, y, color.Black)
+				} else {
+					img.Set(x, y, color.White)
+				}
+			}
+		}
+
+		thumb := Thumbnail(img, 2)
+		assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, thumb.RGBAAt(0, 0))
+		assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, thumb.RGBAAt(1, 1))
+	})
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	contentType, err := Encode(&buf, solid(2, 2, color.White), TypeGIF)
+	require.NoError(t, err)
+	assert.Equal(t, TypePNG, contentType)
+
+	detected, err := DetectContentType(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, TypePNG, detected)
+}
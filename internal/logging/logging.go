@@ -0,0 +1,109 @@
+// Package logging configures structured logging with log/slog.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"product_review_hub/internal/requestid"
+	"product_review_hub/internal/tenant"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Output formats.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config holds logging configuration.
+type Config struct {
+	// Level is the minimum level of logged records: debug, info, warn or error.
+	Level string
+	// Format is the output format: json or text.
+	Format string
+}
+
+// New creates a logger writing to w. Records logged with a context carry the request ID and
+// the tenant stored in it.
+func New(cfg Config, w io.Writer) (*slog.Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: expected json or text", cfg.Format)
+	}
+
+	return slog.New(contextHandler{handler}), nil
+}
+
+// Setup creates a logger writing to w and installs it as the default logger, which the log
+// package writes through as well.
+func Setup(cfg Config, w io.Writer) error {
+	logger, err := New(cfg, w)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// ParseLevel parses a level name; an empty name is info.
+func ParseLevel(s string) (slog.Level, error) {
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// Error returns an attribute for err under the "error" key.
+func Error(err error) slog.Attr {
+	return slog.Any("error", err)
+}
+
+// contextHandler adds the request ID, tenant and trace of the record's context to records.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds the context attributes and passes the record on.
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if id, ok := tenant.FromContext(ctx); ok {
+		record.AddAttrs(slog.String("tenant_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		record.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the attributes that still adds context attributes.
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler with the group that still adds context attributes.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,94 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/requestid"
+	"product_review_hub/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("write json with context attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := logging.New(logging.Config{Level: "info", Format: "json"}, &buf)
+		require.NoError(t, err)
+
+		ctx := requestid.WithID(context.Background(), "req-1")
+		ctx = tenant.WithID(ctx, "shop-a")
+		logger.ErrorContext(ctx, "Failed to get product", logging.Error(errors.New("boom")))
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "Failed to get product", record["msg"])
+		assert.Equal(t, "boom", record["error"])
+		assert.Equal(t, "req-1", record["request_id"])
+		assert.Equal(t, "shop-a", record["tenant_id"])
+	})
+
+	t.Run("keep context attributes on derived loggers", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := logging.New(logging.Config{Format: "text"}, &buf)
+		require.NoError(t, err)
+
+		ctx := requestid.WithID(context.Background(), "req-2")
+		logger.With("component", "cache").WarnContext(ctx, "Cache unavailable")
+		assert.Contains(t, buf.String(), "component=cache")
+		assert.Contains(t, buf.String(), "request_id=req-2")
+	})
+
+	t.Run("add the trace of the context", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := logging.New(logging.Config{Format: "json"}, &buf)
+		require.NoError(t, err)
+
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		})
+		logger.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "Traced")
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+		assert.Equal(t, "00f067aa0ba902b7", record["span_id"])
+	})
+
+	t.Run("drop records below level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := logging.New(logging.Config{Level: "warn"}, &buf)
+		require.NoError(t, err)
+
+		logger.Info("ignored")
+		assert.Empty(t, buf.String())
+		logger.Warn("kept")
+		assert.NotEmpty(t, buf.String())
+	})
+
+	t.Run("reject invalid configuration", func(t *testing.T) {
+		_, err := logging.New(logging.Config{Level: "verbose"}, &bytes.Buffer{})
+		assert.Error(t, err)
+		_, err = logging.New(logging.Config{Format: "xml"}, &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := logging.ParseLevel("")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelInfo, level)
+
+	level, err = logging.ParseLevel("DEBUG")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, level)
+}
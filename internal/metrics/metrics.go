@@ -0,0 +1,141 @@
+// Package metrics defines the Prometheus metrics of the API and the review watcher.
+//
+// Metrics are registered with the default Prometheus registry, which also carries the Go
+// runtime and process metrics, and are exposed in the text format by Handler.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of all metrics.
+const Namespace = "product_review_hub"
+
+// Cache lookup results.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+	// CacheSkipped is a lookup that was not sent to Redis, because it was unavailable or its
+	// circuit breaker was open.
+	CacheSkipped = "skipped"
+	// CacheStale is a lookup served from an expired entry while it is refreshed.
+	CacheStale = "stale"
+)
+
+// Publish and processing results.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var (
+	// HTTPRequestDuration observes the latency of HTTP requests by method, route pattern and status.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route pattern and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// CacheRequests counts cache lookups by cache and result.
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Cache lookups by cache and result (hit, miss, stale, error or skipped).",
+	}, []string{"cache", "result"})
+
+	// CacheLocalRequests counts lookups in the in-process cache tier by cache and result.
+	CacheLocalRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "cache",
+		Name:      "local_requests_total",
+		Help:      "In-process cache tier lookups by cache and result (hit or miss); misses go on to Redis.",
+	}, []string{"cache", "result"})
+
+	// IdempotencyReplays counts responses replayed for a repeated idempotency key.
+	IdempotencyReplays = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "idempotency",
+		Name:      "replays_total",
+		Help:      "Responses replayed for a repeated idempotency key by method.",
+	}, []string{"method"})
+
+	// IdempotencyBypasses counts requests with an idempotency key processed without idempotency
+	// because the store was unavailable.
+	IdempotencyBypasses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "idempotency",
+		Name:      "bypasses_total",
+		Help:      "Requests with an idempotency key processed without idempotency by method.",
+	}, []string{"method"})
+
+	// EventsPublished counts published events by event type and result.
+	EventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "events",
+		Name:      "published_total",
+		Help:      "Events published to RabbitMQ by event type and result (success or failure).",
+	}, []string{"event_type", "result"})
+
+	// BreakerState is the state of each circuit breaker: 0 closed, 1 half-open, 2 open.
+	BreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "breaker",
+		Name:      "state",
+		Help:      "State of the circuit breaker: 0 closed, 1 half-open, 2 open.",
+	}, []string{"breaker"})
+
+	// BreakerRejections counts calls rejected by an open circuit breaker.
+	BreakerRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "breaker",
+		Name:      "rejections_total",
+		Help:      "Calls rejected by an open circuit breaker.",
+	}, []string{"breaker"})
+
+	// EventsConsumed counts events consumed by the review watcher by event type and result.
+	EventsConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "watcher",
+		Name:      "events_consumed_total",
+		Help:      "Events consumed by the review watcher by event type and result (success or failure).",
+	}, []string{"event_type", "result"})
+
+	// EventLag observes the time between publishing an event and the review watcher receiving it.
+	EventLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "watcher",
+		Name:      "event_lag_seconds",
+		Help:      "Time between publishing an event and the review watcher receiving it.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+	}, []string{"event_type"})
+
+	// EventProcessingDuration observes how long the review watcher takes to process an event.
+	EventProcessingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "watcher",
+		Name:      "event_processing_duration_seconds",
+		Help:      "Time the review watcher takes to process an event.",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+	}, []string{"event_type"})
+)
+
+// RegisterDB registers the connection pool statistics of db, as reported by sql.DB.Stats,
+// under the given database name.
+func RegisterDB(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler returns the handler that serves the registered metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/apikeys"
+)
+
+// APIKeyHeader is the HTTP header name for API keys.
+const APIKeyHeader = "X-API-Key"
+
+// lastUsedInterval is how often the last use of a key is written to the database.
+const lastUsedInterval = time.Minute
+
+var (
+	// errInvalidAPIKey is returned for unknown and revoked keys.
+	errInvalidAPIKey = errors.New("invalid api key")
+	// errExpiredAPIKey is returned for keys past their expiry.
+	errExpiredAPIKey = errors.New("api key has expired")
+)
+
+// APIKeyRepository looks up API keys and records their use.
+type APIKeyRepository interface {
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
+
+// APIKeyCache caches API keys by their hash. GetAPIKey returns nil on a miss.
+type APIKeyCache interface {
+	GetAPIKey(ctx context.Context, hash string) (*models.APIKey, error)
+	SetAPIKey(ctx context.Context, key *models.APIKey) error
+}
+
+// APIKeyAuthenticator resolves API keys to principals.
+type APIKeyAuthenticator struct {
+	repo  APIKeyRepository
+	cache APIKeyCache
+
+	mu       sync.Mutex
+	lastUsed map[int64]time.Time
+}
+
+// NewAPIKeyAuthenticator creates an APIKeyAuthenticator. The cache may be nil.
+func NewAPIKeyAuthenticator(repo APIKeyRepository, cache APIKeyCache) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		repo:     repo,
+		cache:    cache,
+		lastUsed: make(map[int64]time.Time),
+	}
+}
+
+// Authenticate returns the principal of a plaintext API key. Lookups go through the cache
+// first; expiry and revocation are checked on every request, so a cached key still stops
+// working when it expires.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, plaintext string) (*auth.Principal, error) {
+	if !auth.LooksLikeAPIKey(plaintext) {
+		return nil, errInvalidAPIKey
+	}
+
+	key, err := a.lookup(ctx, auth.HashAPIKey(plaintext))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil {
+		return nil, errInvalidAPIKey
+	}
+	if key.Expired(now) {
+		return nil, errExpiredAPIKey
+	}
+
+	a.touch(ctx, key.ID, now)
+
+	return &auth.Principal{
+		Subject:  strconv.FormatInt(key.UserID, 10),
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+		Tenant:   key.TenantID,
+	}, nil
+}
+
+// lookup finds a key by its hash in the cache or the database.
+func (a *APIKeyAuthenticator) lookup(ctx context.Context, hash string) (*models.APIKey, error) {
+	if a.cache != nil {
+		key, err := a.cache.GetAPIKey(ctx, hash)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to get api key from cache", logging.Error(err))
+		} else if key != nil {
+			return key, nil
+		}
+	}
+
+	key, err := a.repo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, apikeys.ErrNotFound) {
+			return nil, errInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to look up api key: %w", err)
+	}
+
+	if a.cache != nil {
+		if err := a.cache.SetAPIKey(ctx, key); err != nil {
+			slog.WarnContext(ctx, "Failed to set api key in cache", logging.Error(err))
+		}
+	}
+
+	return key, nil
+}
+
+// touch records the use of a key, writing to the database at most once per lastUsedInterval
+// per key from this process.
+func (a *APIKeyAuthenticator) touch(ctx context.Context, id int64, now time.Time) {
+	a.mu.Lock()
+	if last, ok := a.lastUsed[id]; ok && now.Sub(last) < lastUsedInterval {
+		a.mu.Unlock()
+		return
+	}
+	a.lastUsed[id] = now
+	a.mu.Unlock()
+
+	if err := a.repo.TouchLastUsed(ctx, id, now); err != nil {
+		slog.WarnContext(ctx, "Failed to record use of api key", logging.Error(err), slog.Int64("api_key_id", id))
+	}
+}
@@ -2,15 +2,27 @@ package middleware
 
 import (
 	"bytes"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/metrics"
 	"product_review_hub/internal/repository/idempotency"
+	"product_review_hub/internal/requestid"
 )
 
 // IdempotencyKeyHeader is the HTTP header name for idempotency key.
 const IdempotencyKeyHeader = "X-Idempotency-Key"
 
+// IdempotencyBypassedHeader is set on responses to requests with an idempotency key that were
+// processed without idempotency because the store was unavailable, so that clients know a
+// retry may apply the request twice.
+const IdempotencyBypassedHeader = "X-Idempotency-Bypassed"
+
 // mutatingMethods contains HTTP methods that should be checked for idempotency.
 var mutatingMethods = map[string]bool{
 	http.MethodPost:   true,
@@ -53,8 +65,8 @@ func (r *responseRecorder) Header() http.Header {
 	return r.ResponseWriter.Header()
 }
 
-// toCachedResponse converts the recorded response to CachedResponse.
-func (r *responseRecorder) toCachedResponse() *idempotency.CachedResponse {
+// toCachedResponse converts the recorded response of caller to CachedResponse.
+func (r *responseRecorder) toCachedResponse(caller string) *idempotency.CachedResponse {
 	headers := make(map[string]string)
 	for k, v := range r.ResponseWriter.Header() {
 		if len(v) > 0 {
@@ -66,7 +78,32 @@ func (r *responseRecorder) toCachedResponse() *idempotency.CachedResponse {
 		StatusCode: r.statusCode,
 		Headers:    headers,
 		Body:       r.body.Bytes(),
+		Caller:     caller,
+	}
+}
+
+// idempotencyCaller identifies the caller of a request: its principal and API key.
+// Anonymous callers share an identity.
+func idempotencyCaller(r *http.Request) string {
+	principal, ok := auth.FromContext(r.Context())
+	if !ok {
+		return "anonymous"
 	}
+	return fmt.Sprintf("%s|%d", principal.Subject, principal.APIKeyID)
+}
+
+// storable reports whether a response may be stored for replay: a 2xx response not marked
+// no-store, which is how handlers flag responses carrying secrets.
+func (r *responseRecorder) storable() bool {
+	if r.statusCode < 200 || r.statusCode >= 300 {
+		return false
+	}
+	for _, directive := range strings.Split(r.ResponseWriter.Header().Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return false
+		}
+	}
+	return true
 }
 
 // isMutatingMethod checks if the HTTP method should be checked for idempotency.
@@ -74,9 +111,13 @@ func isMutatingMethod(method string) bool {
 	return mutatingMethods[method]
 }
 
-// writeCachedResponse writes a cached response to the ResponseWriter.
+// writeCachedResponse writes a cached response to the ResponseWriter. The replay keeps the
+// request ID of the current request rather than the one of the original request.
 func writeCachedResponse(w http.ResponseWriter, cached *idempotency.CachedResponse) {
 	for k, v := range cached.Headers {
+		if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(requestid.Header) {
+			continue
+		}
 		w.Header().Set(k, v)
 	}
 	w.WriteHeader(cached.StatusCode)
@@ -84,9 +125,13 @@ func writeCachedResponse(w http.ResponseWriter, cached *idempotency.CachedRespon
 	w.Write(cached.Body)
 }
 
-// Idempotency returns a middleware that checks for idempotency keys and caches responses.
-// It only processes POST, PUT, and DELETE requests that include the X-Idempotency-Key header.
-func Idempotency(store idempotency.Store, ttl time.Duration) func(http.Handler) http.Handler {
+// Idempotency returns a per-operation middleware that checks for idempotency keys and caches
+// responses. It must run after Authorize: responses are only replayed to the caller of the
+// original request, and a key reused by another caller is rejected with 422.
+// It only processes POST, PUT, and DELETE requests that include the X-Idempotency-Key header,
+// and never stores responses marked Cache-Control: no-store. While the store is unavailable
+// requests are processed without idempotency and marked with the X-Idempotency-Bypassed header.
+func Idempotency(store idempotency.Store, ttl time.Duration) api.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip non-mutating methods
@@ -103,10 +148,25 @@ func Idempotency(store idempotency.Store, ttl time.Duration) func(http.Handler)
 				return
 			}
 
+			if !store.Available() {
+				slog.WarnContext(r.Context(), "Idempotency store unavailable, processing request without idempotency")
+				metrics.IdempotencyBypasses.WithLabelValues(r.Method).Inc()
+				w.Header().Set(IdempotencyBypassedHeader, "true")
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			// Check for cached response
+			caller := idempotencyCaller(r)
 			cached, err := store.Get(r.Context(), key)
 			if err == nil && cached != nil {
+				if cached.Caller != caller {
+					writeError(w, http.StatusUnprocessableEntity, "Idempotency key was used by another caller")
+					return
+				}
+
 				// Return cached response
+				metrics.IdempotencyReplays.WithLabelValues(r.Method).Inc()
 				writeCachedResponse(w, cached)
 				return
 			}
@@ -115,10 +175,10 @@ func Idempotency(store idempotency.Store, ttl time.Duration) func(http.Handler)
 			rec := newResponseRecorder(w)
 			next.ServeHTTP(rec, r)
 
-			// Cache successful responses (2xx status codes)
-			if rec.statusCode >= 200 && rec.statusCode < 300 {
+			// Cache successful responses (2xx status codes) that do not carry secrets
+			if rec.storable() {
 				//nolint:errcheck // Best-effort caching, failure is non-critical
-				store.Set(r.Context(), key, rec.toCachedResponse(), ttl)
+				store.Set(r.Context(), key, rec.toCachedResponse(caller), ttl)
 			}
 		})
 	}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"product_review_hub/internal/metrics"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Metrics returns a middleware that observes the latency of every request by method, route
+// pattern and status. Like the access log it uses the route pattern rather than the path, so
+// that resource IDs do not create a time series each.
+func Metrics() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			metrics.HTTPRequestDuration.
+				WithLabelValues(r.Method, routePattern(r), strconv.Itoa(status)).
+				Observe(time.Since(start).Seconds())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/ratelimit"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RateLimit returns a per-operation middleware that limits requests by the rule of their
+// route. Callers are identified by their API key, their user or, for anonymous requests, their
+// IP address, so it must run after Authorize. Responses carry RateLimit-* headers; rejected
+// requests get a 429 problem response with Retry-After. Requests are let through when the
+// limiter fails.
+func RateLimit(limiter ratelimit.Limiter, rules ratelimit.Rules) api.MiddlewareFunc {
+	return rateLimit(limiter, rules, clientKey, true)
+}
+
+// RateLimitByIP returns a per-operation middleware like RateLimit that identifies callers by
+// their IP address only. It runs before Authorize so that floods of requests with missing or
+// invalid credentials are rejected before they reach the database. RateLimit-* headers are only
+// set on rejected requests, leaving them to describe the caller's own limit otherwise.
+func RateLimitByIP(limiter ratelimit.Limiter, rules ratelimit.Rules) api.MiddlewareFunc {
+	return rateLimit(limiter, rules, func(r *http.Request) string { return "addr:" + clientIP(r) }, false)
+}
+
+func rateLimit(limiter ratelimit.Limiter, rules ratelimit.Rules, key func(*http.Request) string, headers bool) api.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := chi.RouteContext(r.Context()).RoutePattern()
+			limit, ok := rules.For(r.Method, route)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := limiter.Allow(r.Context(), r.Method+" "+route+":"+key(r), limit)
+			if err != nil {
+				slog.WarnContext(r.Context(), "Failed to check rate limit", logging.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if headers || !result.Allowed {
+				h := w.Header()
+				h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, seconds(limit.Period)))
+				h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+				h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+				h.Set("RateLimit-Reset", strconv.Itoa(seconds(result.ResetAfter)))
+			}
+
+			if !result.Allowed {
+				retryAfter := seconds(result.RetryAfter)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				writeProblem(w, http.StatusTooManyRequests,
+					fmt.Sprintf("Rate limit of %d requests per %s exceeded, retry in %d seconds", limit.Requests, limit.Period, retryAfter))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey identifies the caller of a request for rate limiting.
+func clientKey(r *http.Request) string {
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		if principal.APIKeyID != 0 {
+			return "key:" + strconv.FormatInt(principal.APIKeyID, 10)
+		}
+		return "user:" + principal.Subject
+	}
+	return "ip:" + clientIP(r)
+}
+
+// clientIP returns the IP address of the client of a request.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// seconds rounds a duration up to whole seconds.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// writeProblem writes an RFC 9457 problem details response.
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	//nolint:errcheck // Response write error cannot be handled meaningfully here
+	json.NewEncoder(w).Encode(api.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
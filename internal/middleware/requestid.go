@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"product_review_hub/internal/requestid"
+)
+
+// RequestID returns a middleware that stores the request ID of the X-Request-ID header in
+// the request context, generating one when the header is missing or invalid. The ID is
+// echoed in the response header so that clients can correlate their requests with logs.
+func RequestID() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = requestid.New()
+			}
+			w.Header().Set(requestid.Header, id)
+			next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), id)))
+		})
+	}
+}
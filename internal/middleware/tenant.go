@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/tenant"
+)
+
+// Tenant returns a middleware that stores the tenant named by the X-Tenant-ID header or the
+// request host in the request context. Requests naming an unknown tenant are rejected with 400.
+// Requests naming none are left to Authorize, which binds them to the tenant of the token.
+func Tenant(resolver *tenant.Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := resolver.Resolve(r)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "Unknown tenant")
+				return
+			}
+			if id != "" {
+				r = r.WithContext(tenant.WithID(r.Context(), id))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bindTenant binds ctx to the tenant of the principal's token. It reports false if the
+// request names another tenant than the one the token was issued for.
+func bindTenant(ctx context.Context, principal *auth.Principal) (context.Context, bool) {
+	if principal.Tenant == "" {
+		return ctx, true
+	}
+	if id, ok := tenant.FromContext(ctx); ok {
+		return ctx, id == principal.Tenant
+	}
+	return tenant.WithID(ctx, principal.Tenant), true
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKey represents an API key issued to a machine client. Only the hash of the key is stored.
+type APIKey struct {
+	ID         int64          `db:"id"`
+	UserID     int64          `db:"user_id"`
+	TenantID   string         `db:"tenant_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	KeyHash    string         `db:"key_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	ExpiresAt  *time.Time     `db:"expires_at"`
+	RevokedAt  *time.Time     `db:"revoked_at"`
+	LastUsedAt *time.Time     `db:"last_used_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+// Expired reports whether the key has expired at the given time.
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// CreateAPIKeyParams contains parameters for creating a new API key.
+type CreateAPIKeyParams struct {
+	UserID    int64
+	TenantID  string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
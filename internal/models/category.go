@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// Category represents a node of the product category tree in the database.
+type Category struct {
+	ID          int64     `db:"id"`
+	ParentID    *int64    `db:"parent_id"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// CategoryWithStats represents a category with rating aggregates over its whole subtree.
+type CategoryWithStats struct {
+	Category
+	ProductCount  int64    `db:"product_count"`
+	ReviewCount   int64    `db:"review_count"`
+	AverageRating *float64 `db:"average_rating"`
+}
+
+// CreateCategoryParams contains parameters for creating a new category.
+type CreateCategoryParams struct {
+	ParentID    *int64
+	Name        string
+	Description *string
+}
+
+// UpdateCategoryParams contains parameters for updating a category.
+type UpdateCategoryParams struct {
+	ParentID    *int64
+	Name        string
+	Description *string
+}
+
+// ListCategoriesParams contains parameters for listing categories.
+type ListCategoriesParams struct {
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// ProductImage represents the metadata of a product image in the database.
+// The image and its thumbnail are stored in the blob store under BlobKey and ThumbnailKey.
+type ProductImage struct {
+	ID           int64     `db:"id"`
+	ProductID    int64     `db:"product_id"`
+	Position     int       `db:"position"`
+	BlobKey      string    `db:"blob_key"`
+	ThumbnailKey string    `db:"thumbnail_key"`
+	ContentType  string    `db:"content_type"`
+	SizeBytes    int64     `db:"size_bytes"`
+	Width        int       `db:"width"`
+	Height       int       `db:"height"`
+	AltText      *string   `db:"alt_text"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// CreateImageParams contains parameters for recording a new product image.
+// The image is appended after the existing images of the product.
+type CreateImageParams struct {
+	ProductID    int64
+	BlobKey      string
+	ThumbnailKey string
+	ContentType  string
+	SizeBytes    int64
+	Width        int
+	Height       int
+	AltText      *string
+}
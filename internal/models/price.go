@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"product_review_hub/internal/money"
+)
+
+// ProductPrice represents a price change of a product in the database.
+// A price with EffectiveFrom in the future is a scheduled change.
+type ProductPrice struct {
+	ID            int64          `db:"id"`
+	ProductID     int64          `db:"product_id"`
+	Price         money.Amount   `db:"price"`
+	Currency      money.Currency `db:"currency"`
+	EffectiveFrom time.Time      `db:"effective_from"`
+	EffectiveTo   *time.Time     `db:"effective_to"`
+	CreatedAt     time.Time      `db:"created_at"`
+	// IsCurrent reports whether this is the price in effect at query time.
+	IsCurrent bool `db:"is_current"`
+}
+
+// SchedulePriceParams contains parameters for scheduling a price change.
+type SchedulePriceParams struct {
+	ProductID     int64
+	Price         money.Amount
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+}
+
+// ListPricesParams contains parameters for listing the price history of a product.
+type ListPricesParams struct {
+	ProductID int64
+	Limit     int
+	Offset    int
+}
+
+// PriceChange describes a change of the price in effect for a product.
+type PriceChange struct {
+	ProductID int64          `db:"product_id"`
+	TenantID  string         `db:"tenant_id"`
+	Currency  money.Currency `db:"currency"`
+	OldPrice  money.Amount   `db:"old_price"`
+	NewPrice  money.Amount   `db:"new_price"`
+}
+
+// CurrencyPrice represents the price of a product in a currency other than its own.
+type CurrencyPrice struct {
+	ProductID int64          `db:"product_id"`
+	Currency  money.Currency `db:"currency"`
+	Price     money.Amount   `db:"price"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
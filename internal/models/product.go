@@ -2,41 +2,121 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
+
+	"product_review_hub/internal/money"
+
+	"github.com/lib/pq"
 )
 
 // Product represents a product entity in the database.
 type Product struct {
-	ID          int64     `db:"id"`
-	Name        string    `db:"name"`
-	Description *string   `db:"description"`
-	Price       float64   `db:"price"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID          int64          `db:"id"`
+	Name        string         `db:"name"`
+	Description *string        `db:"description"`
+	Price       money.Amount   `db:"price"`
+	Currency    money.Currency `db:"currency"`
+	Tags        pq.StringArray `db:"tags"`
+	Attributes  Attributes     `db:"attributes"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+// Attributes represents typed product attributes stored as JSONB.
+// Values are strings, numbers or booleans.
+type Attributes map[string]interface{}
+
+// Value implements driver.Valuer. A nil map is stored as NULL.
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner.
+func (a *Attributes) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for attributes: %T", src)
+	}
+	return json.Unmarshal(data, a)
 }
 
-// ProductWithRating represents a product with its average rating.
+// ProductWithRating represents a product with its average rating and stock availability.
 type ProductWithRating struct {
 	Product
 	AverageRating *float64 `db:"average_rating"`
+	// InStock reports whether the product has stock that is not reserved.
+	InStock bool `db:"in_stock"`
 }
 
 // CreateProductParams contains parameters for creating a new product.
 type CreateProductParams struct {
 	Name        string
 	Description *string
-	Price       float64
+	Price       money.Amount
+	Currency    money.Currency
+	Tags        []string
+	Attributes  Attributes
 }
 
 // UpdateProductParams contains parameters for updating a product.
+// Nil Tags or Attributes and an empty Currency leave the stored values unchanged.
 type UpdateProductParams struct {
 	Name        string
 	Description *string
-	Price       float64
+	Price       money.Amount
+	Currency    money.Currency
+	Tags        []string
+	Attributes  Attributes
+}
+
+// ProductFilter contains filters shared by product listing and facet counting.
+type ProductFilter struct {
+	// CategoryID restricts products to the category and its descendants.
+	CategoryID *int64
+	// Tags restricts products to those carrying all of the tags.
+	Tags []string
+	// Attributes maps an attribute key to its accepted values.
+	// A product must match one of the values for every key.
+	Attributes map[string][]string
+	// InStock restricts products to those with (true) or without (false) available stock.
+	InStock *bool
 }
 
 // ListProductsParams contains parameters for listing products.
 type ListProductsParams struct {
 	Limit  int
 	Offset int
+	ProductFilter
+}
+
+// FacetCount represents the number of products sharing a tag or attribute value.
+type FacetCount struct {
+	Value string `db:"value"`
+	Count int64  `db:"count"`
+}
+
+// AttributeFacetCount represents the number of products sharing an attribute value.
+type AttributeFacetCount struct {
+	Key string `db:"key"`
+	FacetCount
+}
+
+// ProductFacets contains facet counts for a set of products.
+type ProductFacets struct {
+	Tags       []FacetCount
+	Attributes []AttributeFacetCount
 }
@@ -8,17 +8,24 @@ import (
 type Review struct {
 	ID        int64     `db:"id"`
 	ProductID int64     `db:"product_id"`
+	VariantID *int64    `db:"variant_id"`
+	UserID    *int64    `db:"user_id"`
 	FirstName string    `db:"first_name"`
 	LastName  string    `db:"last_name"`
 	Rating    int       `db:"rating"`
 	Comment   *string   `db:"comment"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
+
+	// Photos is loaded separately and not mapped to a column.
+	Photos []ReviewPhoto `db:"-"`
 }
 
 // CreateReviewParams contains parameters for creating a new review.
 type CreateReviewParams struct {
 	ProductID int64
+	VariantID *int64
+	UserID    *int64
 	FirstName string
 	LastName  string
 	Rating    int
@@ -27,6 +34,7 @@ type CreateReviewParams struct {
 
 // UpdateReviewParams contains parameters for updating a review.
 type UpdateReviewParams struct {
+	VariantID *int64
 	FirstName string
 	LastName  string
 	Rating    int
@@ -36,6 +44,14 @@ type UpdateReviewParams struct {
 // ListReviewsParams contains parameters for listing reviews.
 type ListReviewsParams struct {
 	ProductID int64
+	HasMedia  *bool
 	Limit     int
 	Offset    int
 }
+
+// ListUserReviewsParams contains parameters for listing the reviews of a user.
+type ListUserReviewsParams struct {
+	UserID int64
+	Limit  int
+	Offset int
+}
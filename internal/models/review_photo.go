@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ReviewPhoto represents the metadata of a photo attached to a review.
+// The photo and its thumbnail are stored in the blob store under BlobKey and ThumbnailKey.
+type ReviewPhoto struct {
+	ID           int64     `db:"id"`
+	ReviewID     int64     `db:"review_id"`
+	BlobKey      string    `db:"blob_key"`
+	ThumbnailKey string    `db:"thumbnail_key"`
+	ContentType  string    `db:"content_type"`
+	SizeBytes    int64     `db:"size_bytes"`
+	Width        int       `db:"width"`
+	Height       int       `db:"height"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// CreateReviewPhotoParams contains parameters for recording a new review photo.
+type CreateReviewPhotoParams struct {
+	ReviewID     int64
+	BlobKey      string
+	ThumbnailKey string
+	ContentType  string
+	SizeBytes    int64
+	Width        int
+	Height       int
+}
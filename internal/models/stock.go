@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ProductStock represents the stock level of a product in the database.
+type ProductStock struct {
+	ProductID int64 `db:"product_id"`
+	Quantity  int   `db:"quantity"`
+	// Reserved is the part of Quantity held for pending orders.
+	Reserved int `db:"reserved"`
+	// LowStockThreshold is the available quantity at or below which stock is low; 0 disables the check.
+	LowStockThreshold int        `db:"low_stock_threshold"`
+	UpdatedAt         *time.Time `db:"updated_at"`
+}
+
+// Available returns the quantity that can still be reserved or sold.
+func (s *ProductStock) Available() int {
+	return s.Quantity - s.Reserved
+}
+
+// IsLow reports whether the available quantity has reached the low-stock threshold.
+func (s *ProductStock) IsLow() bool {
+	return s.LowStockThreshold > 0 && s.Available() <= s.LowStockThreshold
+}
+
+// StockOperation describes how a stock level was changed.
+type StockOperation string
+
+const (
+	// StockAdjusted is a change of the quantity on hand, e.g. a delivery or a write-off.
+	StockAdjusted StockOperation = "adjust"
+	// StockReserved is a quantity held for a pending order.
+	StockReserved StockOperation = "reserve"
+	// StockReleased is a reservation given back to available stock.
+	StockReleased StockOperation = "release"
+)
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// User represents a user account in the database.
+type User struct {
+	ID        int64     `db:"id"`
+	Email     string    `db:"email"`
+	FirstName string    `db:"first_name"`
+	LastName  string    `db:"last_name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// CreateUserParams contains parameters for creating a new user.
+type CreateUserParams struct {
+	Email     string
+	FirstName string
+	LastName  string
+}
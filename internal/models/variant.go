@@ -0,0 +1,67 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"product_review_hub/internal/money"
+)
+
+// ProductVariant represents a purchasable variant (SKU) of a product in the database.
+type ProductVariant struct {
+	ID        int64          `db:"id"`
+	ProductID int64          `db:"product_id"`
+	SKU       string         `db:"sku"`
+	Options   VariantOptions `db:"options"`
+	Price     *money.Amount  `db:"price"`
+	Active    bool           `db:"active"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+// VariantOptions represents option values of a variant, e.g. size and color, stored as JSONB.
+type VariantOptions map[string]string
+
+// Value implements driver.Valuer. A nil map is stored as an empty object.
+func (o VariantOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(o)
+}
+
+// Scan implements sql.Scanner.
+func (o *VariantOptions) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*o = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for variant options: %T", src)
+	}
+	return json.Unmarshal(data, o)
+}
+
+// CreateVariantParams contains parameters for creating a new variant.
+type CreateVariantParams struct {
+	ProductID int64
+	SKU       string
+	Options   VariantOptions
+	Price     *money.Amount
+	Active    bool
+}
+
+// UpdateVariantParams contains parameters for updating a variant.
+type UpdateVariantParams struct {
+	SKU     string
+	Options VariantOptions
+	Price   *money.Amount
+	Active  bool
+}
@@ -0,0 +1,117 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// DefaultCurrency is the currency of products created without one.
+const DefaultCurrency Currency = "USD"
+
+// ErrUnsupportedCurrency is returned when no exchange rate is configured for a currency.
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// Currency is an ISO 4217 currency code, e.g. USD.
+type Currency string
+
+// ParseCurrency normalizes a currency code to upper case and checks that it has three letters.
+func ParseCurrency(s string) (Currency, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if len(code) != 3 {
+		return "", fmt.Errorf("invalid currency code %q", s)
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", fmt.Errorf("invalid currency code %q", s)
+		}
+	}
+	return Currency(code), nil
+}
+
+// Rates is an exchange-rate table. Every rate is the price of one unit of a common
+// reference currency in the given currency, so only the ratio between two rates matters.
+type Rates map[Currency]*big.Rat
+
+// ParseRates parses an exchange-rate table in the form "USD=1,EUR=0.92,GBP=0.79".
+func ParseRates(s string) (Rates, error) {
+	rates := make(Rates)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate %q: expected CODE=RATE", pair)
+		}
+		currency, err := ParseCurrency(code)
+		if err != nil {
+			return nil, err
+		}
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %q", currency, value)
+		}
+		rates[currency] = rate
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("exchange rate table is empty")
+	}
+	return rates, nil
+}
+
+// Supports reports whether the table has a rate for the currency.
+func (r Rates) Supports(currency Currency) bool {
+	_, ok := r[currency]
+	return ok
+}
+
+// Currencies returns the supported currencies in alphabetical order.
+func (r Rates) Currencies() []Currency {
+	currencies := make([]Currency, 0, len(r))
+	for currency := range r {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+	return currencies
+}
+
+// Convert converts an amount between currencies, rounding half away from zero to minor units.
+func (r Rates) Convert(amount Amount, from, to Currency) (Amount, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, ok := r[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, from)
+	}
+	toRate, ok := r[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, to)
+	}
+
+	converted := new(big.Rat).SetInt64(amount.Minor())
+	converted.Mul(converted, toRate)
+	converted.Quo(converted, fromRate)
+
+	return Amount(roundHalfAwayFromZero(converted)), nil
+}
+
+// roundHalfAwayFromZero rounds a rational number to the nearest integer.
+func roundHalfAwayFromZero(r *big.Rat) int64 {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+
+	// (2*|num| + den) / (2*den) rounds |r| half up
+	num.Mul(num, big.NewInt(2))
+	num.Add(num, den)
+	quo := num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo.Int64()
+}
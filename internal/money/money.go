@@ -0,0 +1,135 @@
+// Package money provides an exact monetary amount type, currency codes and exchange rates.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of minor units in a major unit, matching DECIMAL(10, 2) columns.
+const Scale = 100
+
+// MaxAmount is the largest amount a DECIMAL(10, 2) column holds, 99999999.99.
+const MaxAmount Amount = 99_999_999_99
+
+// decimalPattern matches plain decimal strings such as "19.99" or "-5".
+var decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// Common errors.
+var (
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrTooManyDecimals = errors.New("amount must have at most 2 decimal places")
+)
+
+// Amount is an exact monetary amount in minor units, e.g. cents.
+// It is stored as a decimal and encoded in JSON as a number with up to two decimal places,
+// so 19.99 round-trips as 19.99 rather than through a binary float.
+type Amount int64
+
+// FromMinor creates an Amount from minor units.
+func FromMinor(minor int64) Amount {
+	return Amount(minor)
+}
+
+// Parse parses a plain decimal string such as "19.99" into an Amount. Fractions, exponents
+// and other bases are rejected, and so are amounts with more than two decimal places rather
+// than rounded.
+func Parse(s string) (Amount, error) {
+	trimmed := strings.TrimSpace(s)
+	if !decimalPattern.MatchString(trimmed) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	r, ok := new(big.Rat).SetString(trimmed)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	r.Mul(r, big.NewRat(Scale, 1))
+	if !r.IsInt() {
+		return 0, ErrTooManyDecimals
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	return Amount(r.Num().Int64()), nil
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants and tests.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Minor returns the amount in minor units.
+func (a Amount) Minor() int64 {
+	return int64(a)
+}
+
+// String formats the amount as a decimal with two decimal places, e.g. "19.99".
+func (a Amount) String() string {
+	sign := ""
+	minor := int64(a)
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/Scale, minor%Scale)
+}
+
+// MarshalJSON encodes the amount as a JSON number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or a numeric string without going through float64.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The amount is sent as a decimal string.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements sql.Scanner for DECIMAL columns.
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case int64:
+		*a = Amount(v * Scale)
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for amount: %T", src)
+	}
+}
+
+func (a *Amount) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
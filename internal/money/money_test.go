@@ -0,0 +1,111 @@
+package money_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"product_review_hub/internal/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("parse decimal amounts exactly", func(t *testing.T) {
+		cases := map[string]int64{
+			"19.99": 1999,
+			"20":    2000,
+			"0.1":   10,
+			"-5.05": -505,
+			"7.50":  750,
+		}
+		for input, minor := range cases {
+			amount, err := money.Parse(input)
+			require.NoError(t, err, input)
+			assert.Equal(t, minor, amount.Minor(), input)
+		}
+	})
+
+	t.Run("reject more than two decimal places", func(t *testing.T) {
+		_, err := money.Parse("19.999")
+		assert.ErrorIs(t, err, money.ErrTooManyDecimals)
+	})
+
+	t.Run("reject invalid amounts", func(t *testing.T) {
+		for _, input := range []string{"abc", "", "1/4", "0x10", "1e2", "+5", ".5", "5."} {
+			_, err := money.Parse(input)
+			assert.ErrorIs(t, err, money.ErrInvalidAmount, input)
+		}
+	})
+}
+
+func TestAmount_JSON(t *testing.T) {
+	t.Run("round-trip without float conversion", func(t *testing.T) {
+		var amount money.Amount
+		require.NoError(t, json.Unmarshal([]byte("19.99"), &amount))
+		assert.Equal(t, int64(1999), amount.Minor())
+
+		data, err := json.Marshal(amount)
+		require.NoError(t, err)
+		assert.Equal(t, "19.99", string(data))
+	})
+
+	t.Run("accept numeric strings", func(t *testing.T) {
+		var amount money.Amount
+		require.NoError(t, json.Unmarshal([]byte(`"5.5"`), &amount))
+		assert.Equal(t, "5.50", amount.String())
+	})
+}
+
+func TestAmount_Scan(t *testing.T) {
+	var amount money.Amount
+	require.NoError(t, amount.Scan([]byte("19.99")))
+	assert.Equal(t, money.MustParse("19.99"), amount)
+
+	value, err := amount.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "19.99", value)
+}
+
+func TestRates_Convert(t *testing.T) {
+	rates, err := money.ParseRates("USD=1, EUR=0.92, JPY=151.5")
+	require.NoError(t, err)
+
+	t.Run("convert with rounding to minor units", func(t *testing.T) {
+		converted, err := rates.Convert(money.MustParse("19.99"), "USD", "EUR")
+		require.NoError(t, err)
+		// 19.99 * 0.92 = 18.3908
+		assert.Equal(t, money.MustParse("18.39"), converted)
+
+		converted, err = rates.Convert(money.MustParse("18.39"), "EUR", "JPY")
+		require.NoError(t, err)
+		// 18.39 / 0.92 * 151.5 = 3028.353...
+		assert.Equal(t, money.MustParse("3028.35"), converted)
+	})
+
+	t.Run("same currency is returned unchanged", func(t *testing.T) {
+		converted, err := rates.Convert(money.MustParse("1.23"), "GBP", "GBP")
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParse("1.23"), converted)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		_, err := rates.Convert(money.MustParse("1"), "USD", "GBP")
+		assert.ErrorIs(t, err, money.ErrUnsupportedCurrency)
+	})
+}
+
+func TestParseRates(t *testing.T) {
+	t.Run("reject malformed entries", func(t *testing.T) {
+		for _, input := range []string{"", "USD", "USD=abc", "USD=0", "US=1"} {
+			_, err := money.ParseRates(input)
+			assert.Error(t, err, input)
+		}
+	})
+
+	t.Run("normalize currency codes", func(t *testing.T) {
+		rates, err := money.ParseRates("usd=1,eur=0.9")
+		require.NoError(t, err)
+		assert.Equal(t, []money.Currency{"EUR", "USD"}, rates.Currencies())
+	})
+}
@@ -1,14 +1,18 @@
 package rabbitmq
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+
+	"product_review_hub/internal/logging"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 const (
-	// ExchangeName is the name of the exchange for review events.
+	// ExchangeName is the name of the exchange for review and product events.
 	ExchangeName = "review.events"
 	// QueueName is the name of the queue for the review watcher.
 	QueueName = "review.events.watcher"
@@ -61,7 +65,7 @@ func NewConnection(cfg Config) (*Connection, error) {
 		return nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	log.Printf("Connected to RabbitMQ at %s:%s", cfg.Host, cfg.Port)
+	slog.Info("Connected to RabbitMQ", slog.String("host", cfg.Host), slog.String("port", cfg.Port))
 
 	return &Connection{
 		conn:    conn,
@@ -74,11 +78,23 @@ func (c *Connection) Channel() *amqp.Channel {
 	return c.channel
 }
 
+// Check reports an error when the connection or its channel has been closed, e.g. by the
+// broker. It is the readiness check of the connection.
+func (c *Connection) Check(_ context.Context) error {
+	if c.conn.IsClosed() {
+		return errors.New("connection is closed")
+	}
+	if c.channel.IsClosed() {
+		return errors.New("channel is closed")
+	}
+	return nil
+}
+
 // Close closes the connection and channel.
 func (c *Connection) Close() error {
 	if c.channel != nil {
 		if err := c.channel.Close(); err != nil {
-			log.Printf("Error closing channel: %v", err)
+			slog.Warn("Failed to close channel", logging.Error(err))
 		}
 	}
 	if c.conn != nil {
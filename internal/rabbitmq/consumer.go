@@ -2,7 +2,7 @@ package rabbitmq
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -60,7 +60,7 @@ func (c *Consumer) Consume() (<-chan amqp.Delivery, error) {
 		return nil, fmt.Errorf("failed to register consumer: %w", err)
 	}
 
-	log.Printf("Consumer started, waiting for messages on queue: %s", QueueName)
+	slog.Info("Consumer started, waiting for messages", slog.String("queue", QueueName))
 
 	return msgs, nil
 }
@@ -1,14 +1,35 @@
 package rabbitmq
 
-import "time"
+import (
+	"context"
+	"time"
 
-// EventType represents the type of review event.
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/requestid"
+	"product_review_hub/internal/tenant"
+)
+
+// EventType represents the type of event.
 type EventType string
 
 const (
 	EventReviewCreated EventType = "review.created"
 	EventReviewUpdated EventType = "review.updated"
 	EventReviewDeleted EventType = "review.deleted"
+
+	EventProductPriceChanged EventType = "product.price_changed"
+	EventProductStockChanged EventType = "product.stock_changed"
+)
+
+// PriceChangeSource describes what caused a price change.
+type PriceChangeSource string
+
+const (
+	// PriceChangeManual is a price set through the product API.
+	PriceChangeManual PriceChangeSource = "manual"
+	// PriceChangeScheduled is a scheduled price change that started or ended.
+	PriceChangeScheduled PriceChangeSource = "scheduled"
 )
 
 // ReviewEventData contains the data for a review event.
@@ -18,18 +39,31 @@ type ReviewEventData struct {
 	Rating    int    `json:"rating,omitempty"`
 }
 
+// EventMetadata describes where an event came from.
+type EventMetadata struct {
+	// RequestID is the ID of the request that caused the event, for correlating it with logs.
+	RequestID string `json:"request_id,omitempty"`
+}
+
 // ReviewEvent represents an event that is published when a review is created, updated, or deleted.
 type ReviewEvent struct {
 	EventType EventType       `json:"event_type"`
 	Timestamp time.Time       `json:"timestamp"`
+	TenantID  string          `json:"tenant_id"`
+	Metadata  EventMetadata   `json:"metadata"`
 	Data      ReviewEventData `json:"data"`
 }
 
-// NewReviewEvent creates a new ReviewEvent with the current timestamp.
-func NewReviewEvent(eventType EventType, reviewID, productID string, rating int) ReviewEvent {
+// NewReviewEvent creates a new ReviewEvent with the current timestamp. The tenant and the
+// request ID are taken from ctx.
+func NewReviewEvent(ctx context.Context, eventType EventType, reviewID, productID string, rating int) ReviewEvent {
 	return ReviewEvent{
 		EventType: eventType,
 		Timestamp: time.Now().UTC(),
+		TenantID:  tenant.ID(ctx),
+		Metadata: EventMetadata{
+			RequestID: requestid.FromContext(ctx),
+		},
 		Data: ReviewEventData{
 			ReviewID:  reviewID,
 			ProductID: productID,
@@ -37,3 +71,85 @@ func NewReviewEvent(eventType EventType, reviewID, productID string, rating int)
 		},
 	}
 }
+
+// PriceChangedEventData contains the data for a price changed event.
+// Prices are in the product currency; OldPrice is in the previous currency when it changed.
+type PriceChangedEventData struct {
+	ProductID string            `json:"product_id"`
+	OldPrice  money.Amount      `json:"old_price"`
+	NewPrice  money.Amount      `json:"new_price"`
+	Currency  money.Currency    `json:"currency"`
+	Source    PriceChangeSource `json:"source"`
+}
+
+// PriceChangedEvent represents an event that is published when the price in effect for a product changes.
+type PriceChangedEvent struct {
+	EventType EventType             `json:"event_type"`
+	Timestamp time.Time             `json:"timestamp"`
+	TenantID  string                `json:"tenant_id"`
+	Metadata  EventMetadata         `json:"metadata"`
+	Data      PriceChangedEventData `json:"data"`
+}
+
+// NewPriceChangedEvent creates a new PriceChangedEvent with the current timestamp. The tenant
+// and the request ID are taken from ctx.
+func NewPriceChangedEvent(ctx context.Context, productID string, oldPrice, newPrice money.Amount, currency money.Currency, source PriceChangeSource) PriceChangedEvent {
+	return PriceChangedEvent{
+		EventType: EventProductPriceChanged,
+		Timestamp: time.Now().UTC(),
+		TenantID:  tenant.ID(ctx),
+		Metadata: EventMetadata{
+			RequestID: requestid.FromContext(ctx),
+		},
+		Data: PriceChangedEventData{
+			ProductID: productID,
+			OldPrice:  oldPrice,
+			NewPrice:  newPrice,
+			Currency:  currency,
+			Source:    source,
+		},
+	}
+}
+
+// StockChangedEventData contains the data for a stock changed event.
+// Delta is the change of the quantity on hand for adjustments and the number of units reserved or released otherwise.
+type StockChangedEventData struct {
+	ProductID string                `json:"product_id"`
+	Operation models.StockOperation `json:"operation"`
+	Delta     int                   `json:"delta"`
+	Quantity  int                   `json:"quantity"`
+	Reserved  int                   `json:"reserved"`
+	Available int                   `json:"available"`
+	LowStock  bool                  `json:"low_stock"`
+}
+
+// StockChangedEvent represents an event that is published when the stock level of a product changes.
+type StockChangedEvent struct {
+	EventType EventType             `json:"event_type"`
+	Timestamp time.Time             `json:"timestamp"`
+	TenantID  string                `json:"tenant_id"`
+	Metadata  EventMetadata         `json:"metadata"`
+	Data      StockChangedEventData `json:"data"`
+}
+
+// NewStockChangedEvent creates a new StockChangedEvent with the current timestamp. The tenant
+// and the request ID are taken from ctx.
+func NewStockChangedEvent(ctx context.Context, productID string, operation models.StockOperation, delta int, stock *models.ProductStock) StockChangedEvent {
+	return StockChangedEvent{
+		EventType: EventProductStockChanged,
+		Timestamp: time.Now().UTC(),
+		TenantID:  tenant.ID(ctx),
+		Metadata: EventMetadata{
+			RequestID: requestid.FromContext(ctx),
+		},
+		Data: StockChangedEventData{
+			ProductID: productID,
+			Operation: operation,
+			Delta:     delta,
+			Quantity:  stock.Quantity,
+			Reserved:  stock.Reserved,
+			Available: stock.Available(),
+			LowStock:  stock.IsLow(),
+		},
+	}
+}
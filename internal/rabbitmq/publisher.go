@@ -3,44 +3,114 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync/atomic"
+
+	"product_review_hub/internal/breaker"
+	"product_review_hub/internal/metrics"
+	"product_review_hub/internal/requestid"
+	"product_review_hub/internal/tracing"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Publisher publishes review events to RabbitMQ.
+// ErrNotConnected is returned by a Publisher without a connection.
+var ErrNotConnected = errors.New("not connected to RabbitMQ")
+
+// Publisher publishes review and product events to RabbitMQ. Publishes go through a circuit
+// breaker when the publisher has one; while it is open they fail immediately with
+// breaker.ErrOpen instead of waiting on the broker.
 type Publisher struct {
-	conn *Connection
+	conn    atomic.Pointer[Connection]
+	breaker *breaker.Breaker
 }
 
-// NewPublisher creates a new Publisher.
+// NewPublisher creates a new Publisher. A nil connection creates a publisher that fails with
+// ErrNotConnected until a connection is attached.
 func NewPublisher(conn *Connection) *Publisher {
-	return &Publisher{conn: conn}
+	return NewPublisherWithBreaker(conn, nil)
+}
+
+// NewPublisherWithBreaker creates a new Publisher whose publishes go through the circuit
+// breaker b.
+func NewPublisherWithBreaker(conn *Connection, b *breaker.Breaker) *Publisher {
+	p := &Publisher{breaker: b}
+	p.conn.Store(conn)
+	return p
+}
+
+// Attach attaches the connection of a publisher created without one.
+func (p *Publisher) Attach(conn *Connection) {
+	p.conn.Store(conn)
 }
 
 // Publish publishes a review event to RabbitMQ.
 func (p *Publisher) Publish(ctx context.Context, event ReviewEvent) error {
+	return p.publish(ctx, event.EventType, event)
+}
+
+// PublishPriceChanged publishes a price changed event to RabbitMQ.
+func (p *Publisher) PublishPriceChanged(ctx context.Context, event PriceChangedEvent) error {
+	return p.publish(ctx, event.EventType, event)
+}
+
+// PublishStockChanged publishes a stock changed event to RabbitMQ.
+func (p *Publisher) PublishStockChanged(ctx context.Context, event StockChangedEvent) error {
+	return p.publish(ctx, event.EventType, event)
+}
+
+// publish marshals the event and publishes it with the event type as routing key, the
+// request ID of ctx as correlation ID and the trace context of ctx in the message headers.
+// The outcome is counted per event type.
+func (p *Publisher) publish(ctx context.Context, eventType EventType, event interface{}) error {
+	routingKey := string(eventType)
+
+	ctx, span := tracing.StartKind(ctx, routingKey+" publish", trace.SpanKindProducer,
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingOperationTypeSend,
+		semconv.MessagingDestinationName(ExchangeName),
+		semconv.MessagingRabbitMQDestinationRoutingKey(routingKey),
+	)
+	defer span.End()
+
+	conn := p.conn.Load()
+	if conn == nil {
+		metrics.EventsPublished.WithLabelValues(routingKey, metrics.ResultFailure).Inc()
+		tracing.RecordError(span, ErrNotConnected)
+		return ErrNotConnected
+	}
+
 	body, err := json.Marshal(event)
 	if err != nil {
+		metrics.EventsPublished.WithLabelValues(routingKey, metrics.ResultFailure).Inc()
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	routingKey := string(event.EventType)
-
-	err = p.conn.Channel().PublishWithContext(
-		ctx,
-		ExchangeName, // exchange
-		routingKey,   // routing key
-		false,        // mandatory
-		false,        // immediate
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
-	)
+	err = p.breaker.Do(ctx, func(ctx context.Context) error {
+		return conn.Channel().PublishWithContext(
+			ctx,
+			ExchangeName, // exchange
+			routingKey,   // routing key
+			false,        // mandatory
+			false,        // immediate
+			amqp.Publishing{
+				ContentType:   "application/json",
+				CorrelationId: requestid.FromContext(ctx),
+				Headers:       injectTraceContext(ctx),
+				Body:          body,
+			},
+		)
+	})
 	if err != nil {
+		metrics.EventsPublished.WithLabelValues(routingKey, metrics.ResultFailure).Inc()
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
+	metrics.EventsPublished.WithLabelValues(routingKey, metrics.ResultSuccess).Inc()
 	return nil
 }
@@ -0,0 +1,49 @@
+package rabbitmq
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+)
+
+// headerCarrier carries W3C trace context in AMQP message headers.
+type headerCarrier amqp.Table
+
+// Get returns the string value of a header.
+func (c headerCarrier) Get(key string) string {
+	if v, ok := c[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// Set sets a header.
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys returns the header names.
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectTraceContext returns message headers with the trace context of ctx.
+func injectTraceContext(ctx context.Context) amqp.Table {
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+	return headers
+}
+
+// ExtractTraceContext returns ctx with the trace context carried in the headers of msg, so that
+// spans of the consumer continue the trace of the publisher.
+func ExtractTraceContext(ctx context.Context, msg amqp.Delivery) context.Context {
+	if msg.Headers == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers))
+}
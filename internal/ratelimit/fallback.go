@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"product_review_hub/internal/logging"
+)
+
+// FallbackLimiter uses a primary Limiter and switches to a fallback for requests the primary
+// fails on, e.g. while Redis is unavailable.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	degraded atomic.Bool
+}
+
+// NewFallbackLimiter creates a FallbackLimiter.
+func NewFallbackLimiter(primary, fallback Limiter) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback}
+}
+
+// Allow implements Limiter. Switching between the limiters is logged once per switch.
+func (l *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	result, err := l.primary.Allow(ctx, key, limit)
+	if err == nil {
+		if l.degraded.CompareAndSwap(true, false) {
+			slog.InfoContext(ctx, "Rate limiter recovered, using primary limiter again")
+		}
+		return result, nil
+	}
+
+	if l.degraded.CompareAndSwap(false, true) {
+		slog.WarnContext(ctx, "Rate limiter failed, using in-process fallback", logging.Error(err))
+	}
+	return l.fallback.Allow(ctx, key, limit)
+}
@@ -0,0 +1,135 @@
+// Package ratelimit provides GCRA rate limiters backed by Redis or process memory.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests requests per Period, all of which may arrive in a burst.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// interval is the time after which one more request is allowed.
+func (l Limit) interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// String formats the limit in the form accepted by ParseRules, e.g. "5/1m0s".
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// Result is the outcome of a rate limit check.
+type Result struct {
+	Allowed bool
+	// Limit is the number of requests allowed per period.
+	Limit int
+	// Remaining is the number of requests that would be allowed right now.
+	Remaining int
+	// ResetAfter is the time until the full limit is available again.
+	ResetAfter time.Duration
+	// RetryAfter is the time until the next request is allowed; zero when Allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter checks and records requests against a limit.
+type Limiter interface {
+	// Allow records a request for key if the limit permits it.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// gcra applies the generic cell rate algorithm. tat is the theoretical arrival time stored
+// for the key, or zero for a new key. It returns the result and the new arrival time to store
+// when the request is allowed.
+func gcra(tat, now time.Time, limit Limit) (Result, time.Time) {
+	interval := limit.interval()
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-limit.Period)
+	if now.Before(allowAt) {
+		return Result{
+			Limit:      limit.Requests,
+			ResetAfter: tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}, tat
+	}
+
+	return Result{
+		Allowed:    true,
+		Limit:      limit.Requests,
+		Remaining:  int(now.Sub(allowAt) / interval),
+		ResetAfter: newTAT.Sub(now),
+	}, newTAT
+}
+
+// Rules are limits by route, keyed by "METHOD /route/pattern". The DefaultRoute entry, if
+// present, applies to routes without a limit of their own.
+type Rules map[string]Limit
+
+// DefaultRoute is the key of the limit that applies to routes without their own limit.
+const DefaultRoute = "*"
+
+// For returns the limit of the route with the given method and chi route pattern.
+func (r Rules) For(method, pattern string) (Limit, bool) {
+	if limit, ok := r[method+" "+pattern]; ok {
+		return limit, true
+	}
+	limit, ok := r[DefaultRoute]
+	return limit, ok
+}
+
+// ParseRules parses per-route limits in the form
+// "POST /api/v1/products/{productId}/reviews=5/1m,*=300/1m".
+func ParseRules(s string) (Rules, error) {
+	rules := make(Rules)
+	for _, rule := range strings.Split(s, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q: expected ROUTE=REQUESTS/PERIOD", rule)
+		}
+		route = strings.Join(strings.Fields(route), " ")
+		if route != DefaultRoute && !strings.Contains(route, " /") {
+			return nil, fmt.Errorf("invalid rate limit route %q: expected METHOD /pattern or %s", route, DefaultRoute)
+		}
+		limit, err := parseLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit for %s: %w", route, err)
+		}
+		rules[route] = limit
+	}
+	return rules, nil
+}
+
+// parseLimit parses a limit in the form "5/1m".
+func parseLimit(s string) (Limit, error) {
+	requests, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, errors.New("expected REQUESTS/PERIOD")
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 1 {
+		return Limit{}, fmt.Errorf("requests must be a positive integer, got %q", requests)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("period must be a positive duration, got %q", period)
+	}
+	if d/time.Duration(n) < time.Microsecond {
+		return Limit{}, errors.New("limit is too high for its period")
+	}
+	return Limit{Requests: n, Period: d}, nil
+}
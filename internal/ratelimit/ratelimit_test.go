@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/breaker"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Run("parse routes and default", func(t *testing.T) {
+		rules, err := ParseRules(" POST  /api/v1/products/{productId}/reviews=5/1m, *=300/1m ,")
+		require.NoError(t, err)
+
+		limit, ok := rules.For("POST", "/api/v1/products/{productId}/reviews")
+		require.True(t, ok)
+		assert.Equal(t, Limit{Requests: 5, Period: time.Minute}, limit)
+
+		limit, ok = rules.For("GET", "/api/v1/products")
+		require.True(t, ok)
+		assert.Equal(t, Limit{Requests: 300, Period: time.Minute}, limit)
+	})
+
+	t.Run("leave routes without default unlimited", func(t *testing.T) {
+		rules, err := ParseRules("POST /api/v1/users=1/1s")
+		require.NoError(t, err)
+
+		_, ok := rules.For("GET", "/api/v1/users")
+		assert.False(t, ok)
+	})
+
+	t.Run("reject invalid rules", func(t *testing.T) {
+		for _, s := range []string{
+			"POST /api/v1/users",
+			"/api/v1/users=5/1m",
+			"POST /api/v1/users=0/1m",
+			"POST /api/v1/users=5",
+			"POST /api/v1/users=5/soon",
+			"POST /api/v1/users=5000000/1s",
+		} {
+			_, err := ParseRules(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+
+	newLimiter := func() (*MemoryLimiter, *time.Time) {
+		now := time.Unix(1_700_000_000, 0)
+		l := NewMemoryLimiter()
+		l.now = func() time.Time { return now }
+		return l, &now
+	}
+
+	t.Run("allow a burst up to the limit", func(t *testing.T) {
+		l, _ := newLimiter()
+
+		for remaining := 2; remaining >= 0; remaining-- {
+			result, err := l.Allow(ctx, "user:1", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 3, result.Limit)
+			assert.Equal(t, remaining, result.Remaining)
+		}
+
+		result, err := l.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 3*time.Second, result.ResetAfter)
+	})
+
+	t.Run("refill one request per interval", func(t *testing.T) {
+		l, now := newLimiter()
+		for i := 0; i < 3; i++ {
+			_, err := l.Allow(ctx, "user:1", limit)
+			require.NoError(t, err)
+		}
+
+		*now = now.Add(time.Second)
+		result, err := l.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+
+		result, err = l.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("keep keys apart", func(t *testing.T) {
+		l, _ := newLimiter()
+		for i := 0; i < 3; i++ {
+			_, err := l.Allow(ctx, "user:1", limit)
+			require.NoError(t, err)
+		}
+
+		result, err := l.Allow(ctx, "user:2", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("drop keys back at the full limit", func(t *testing.T) {
+		l, now := newLimiter()
+		_, err := l.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+
+		*now = now.Add(2 * sweepInterval)
+		_, err = l.Allow(ctx, "user:2", limit)
+		require.NoError(t, err)
+		assert.NotContains(t, l.tats, "user:1")
+	})
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func TestFallbackLimiter(t *testing.T) {
+	t.Run("use fallback when primary fails", func(t *testing.T) {
+		fallback := NewMemoryLimiter()
+		l := NewFallbackLimiter(failingLimiter{}, fallback)
+		limit := Limit{Requests: 1, Period: time.Minute}
+
+		result, err := l.Allow(context.Background(), "ip:127.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = l.Allow(context.Background(), "ip:127.0.0.1", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+}
+
+func TestRedisLimiter(t *testing.T) {
+	t.Run("skip redis while breaker is open", func(t *testing.T) {
+		b := breaker.New("ratelimit_test", breaker.Config{Failures: 1, Cooldown: time.Minute})
+		_ = b.Do(context.Background(), func(context.Context) error { return errors.New("connection refused") })
+
+		// Nothing listens on the address; the open breaker rejects the check without dialing it
+		client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+		defer client.Close()
+		l := NewRedisLimiterWithBreaker(client, b)
+
+		_, err := l.Allow(context.Background(), "ip:127.0.0.1", Limit{Requests: 1, Period: time.Minute})
+		assert.ErrorIs(t, err, breaker.ErrOpen)
+	})
+}
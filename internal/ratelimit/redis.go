@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"product_review_hub/internal/breaker"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+// redisTimeout bounds a rate limit check so that a slow Redis cannot stall every request;
+// the FallbackLimiter takes over when it expires.
+const redisTimeout = 100 * time.Millisecond
+
+// gcraScript applies the generic cell rate algorithm atomically, using the Redis clock so that
+// all instances agree on the time. Times are in microseconds. It returns whether the request
+// is allowed, the remaining requests, the retry delay and the reset delay.
+var gcraScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then
+  tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - period
+if now < allow_at then
+  return {0, 0, allow_at - now, tat - now}
+end
+
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor((now - allow_at) / interval), 0, new_tat - now}
+`)
+
+// errUnavailable is returned by a RedisLimiter without a Redis client.
+var errUnavailable = errors.New("redis limiter is unavailable")
+
+// RedisLimiter is a Limiter shared by all instances through Redis. Checks go through a circuit
+// breaker when the limiter has one, so that a FallbackLimiter takes over without waiting on a
+// failing Redis while it is open.
+type RedisLimiter struct {
+	client  atomic.Pointer[redis.Client]
+	breaker *breaker.Breaker
+}
+
+// NewRedisLimiter creates a RedisLimiter. A nil client creates a limiter that fails until a
+// client is attached, so that a FallbackLimiter uses its fallback meanwhile.
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return NewRedisLimiterWithBreaker(client, nil)
+}
+
+// NewRedisLimiterWithBreaker creates a RedisLimiter whose checks go through the circuit
+// breaker b.
+func NewRedisLimiterWithBreaker(client *redis.Client, b *breaker.Breaker) *RedisLimiter {
+	l := &RedisLimiter{breaker: b}
+	l.client.Store(client)
+	return l
+}
+
+// Attach attaches the Redis client of a limiter created without one.
+func (l *RedisLimiter) Attach(client *redis.Client) {
+	l.client.Store(client)
+}
+
+// Allow implements Limiter.
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	client := l.client.Load()
+	if client == nil {
+		return Result{}, errUnavailable
+	}
+
+	var values []int64
+	err := l.breaker.Do(ctx, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+		defer cancel()
+
+		var err error
+		values, err = gcraScript.Run(ctx, client, []string{keyPrefix + key},
+			limit.interval().Microseconds(), limit.Period.Microseconds()).Int64Slice()
+		return err
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to run rate limit script: %w", err)
+	}
+	if len(values) != 4 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+		ResetAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
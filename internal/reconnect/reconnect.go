@@ -0,0 +1,67 @@
+// Package reconnect retries connecting to dependencies that were unavailable at startup.
+package reconnect
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"product_review_hub/internal/logging"
+)
+
+const (
+	// DefaultInitialDelay is the delay before the first retry.
+	DefaultInitialDelay = time.Second
+	// DefaultMaxDelay caps the delay between retries.
+	DefaultMaxDelay = 30 * time.Second
+)
+
+// Backoff is an exponential backoff between attempts.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// DefaultBackoff doubles the delay from DefaultInitialDelay up to DefaultMaxDelay.
+var DefaultBackoff = Backoff{Initial: DefaultInitialDelay, Max: DefaultMaxDelay}
+
+// delay returns the delay after the given number of failed attempts, starting at 1.
+func (b Backoff) delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 1; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// Retry calls connect until it succeeds or ctx is done, waiting with backoff between attempts,
+// and reports whether it connected. Failures are logged with the name of the dependency; the
+// first attempt is made right away.
+func Retry(ctx context.Context, name string, backoff Backoff, connect func(ctx context.Context) error) bool {
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			slog.InfoContext(ctx, "Connected to dependency", slog.String("dependency", name), slog.Int("attempt", attempt))
+			return true
+		}
+
+		delay := backoff.delay(attempt)
+		slog.WarnContext(ctx, "Failed to connect to dependency, retrying",
+			slog.String("dependency", name),
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", delay),
+			logging.Error(err),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+}
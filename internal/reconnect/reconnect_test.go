@@ -0,0 +1,46 @@
+package reconnect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_delay(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 10 * time.Second}
+	assert.Equal(t, time.Second, b.delay(1))
+	assert.Equal(t, 2*time.Second, b.delay(2))
+	assert.Equal(t, 8*time.Second, b.delay(4))
+	assert.Equal(t, 10*time.Second, b.delay(5))
+	assert.Equal(t, 10*time.Second, b.delay(100))
+}
+
+func TestRetry(t *testing.T) {
+	backoff := Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond}
+
+	t.Run("retry until connected", func(t *testing.T) {
+		attempts := 0
+		ok := Retry(context.Background(), "redis", backoff, func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+		assert.True(t, ok)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("stop when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		ok := Retry(ctx, "rabbitmq", backoff, func(context.Context) error {
+			return errors.New("connection refused")
+		})
+		assert.False(t, ok)
+	})
+}
@@ -9,13 +9,9 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// New creates a new Redis client with the given configuration.
+// New creates a new Redis client with the given configuration and verifies the connection.
 func New(cfg config.RedisConfig) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
-		Password: cfg.Password,
-		DB:       cfg.DB,
-	})
+	client := NewClient(cfg)
 
 	// Verify connection
 	ctx := context.Background()
@@ -25,3 +21,15 @@ func New(cfg config.RedisConfig) (*redis.Client, error) {
 
 	return client, nil
 }
+
+// NewClient creates a new Redis client with the given configuration without connecting.
+// The client connects on its first command. Commands honour the deadline of their context,
+// so callers can bound them with shorter timeouts than the client's own.
+func NewClient(cfg config.RedisConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:                  fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+		Password:              cfg.Password,
+		DB:                    cfg.DB,
+		ContextTimeoutEnabled: true,
+	})
+}
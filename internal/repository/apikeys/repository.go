@@ -0,0 +1,182 @@
+// Package apikeys provides repository for managing API keys in the database.
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Common errors.
+var (
+	ErrNotFound = errors.New("api key not found")
+)
+
+const apiKeyColumns = `id, user_id, tenant_id, name, prefix, key_hash, scopes, expires_at, revoked_at, last_used_at, created_at`
+
+// Repository provides methods for managing API keys in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new API keys repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// Create inserts a new API key.
+func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateAPIKeyParams) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO api_keys (user_id, tenant_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + apiKeyColumns
+
+	var key models.APIKey
+	err := tx.QueryRowxContext(ctx, query, params.UserID, params.TenantID, params.Name, params.Prefix, params.KeyHash, pq.Array(params.Scopes), params.ExpiresAt).
+		StructScan(&key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// List retrieves all API keys, including revoked and expired ones, newest first.
+func (r *Repository) List(ctx context.Context, tx *sqlx.Tx) ([]models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.List")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id DESC`
+
+	keys := []models.APIKey{}
+	if err := tx.SelectContext(ctx, &keys, query); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetByID retrieves an API key by its ID.
+func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.GetByID")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	var key models.APIKey
+	err := tx.QueryRowxContext(ctx, query, id).StructScan(&key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// Revoke marks an API key as revoked. Revoking an already revoked key keeps the original
+// revocation time.
+func (r *Repository) Revoke(ctx context.Context, tx *sqlx.Tx, id int64) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.Revoke")
+	defer span.End()
+
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+		WHERE id = $1
+		RETURNING ` + apiKeyColumns
+
+	var key models.APIKey
+	err := tx.QueryRowxContext(ctx, query, id).StructScan(&key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// ExpireBy moves the expiry of an API key forward to the given time. A key that already
+// expires earlier keeps its expiry.
+func (r *Repository) ExpireBy(ctx context.Context, tx *sqlx.Tx, id int64, at time.Time) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.ExpireBy")
+	defer span.End()
+
+	query := `
+		UPDATE api_keys
+		SET expires_at = LEAST(COALESCE(expires_at, $2), $2)
+		WHERE id = $1
+		RETURNING ` + apiKeyColumns
+
+	var key models.APIKey
+	err := tx.QueryRowxContext(ctx, query, id, at).StructScan(&key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to expire api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetByHash retrieves an API key by the hash of its plaintext. It runs outside of a
+// transaction because it is used to authenticate requests.
+func (r *Repository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.GetByHash")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	var key models.APIKey
+	err := r.db.QueryRowxContext(ctx, query, hash).StructScan(&key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// TouchLastUsed records when an API key was last used. It runs outside of a transaction
+// because it is used to authenticate requests.
+func (r *Repository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "apikeys.Repository.TouchLastUsed")
+	defer span.End()
+
+	query := `UPDATE api_keys SET last_used_at = GREATEST(COALESCE(last_used_at, $2), $2) WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, at); err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+
+	return nil
+}
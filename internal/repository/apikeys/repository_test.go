@@ -0,0 +1,217 @@
+package apikeys_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/apikeys"
+	"product_review_hub/internal/testutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createKey(t *testing.T, repo *apikeys.Repository, userID int64, hash string, expiresAt *time.Time) *models.APIKey {
+	t.Helper()
+	ctx := context.Background()
+
+	tx, err := repo.BeginTx(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	key, err := repo.Create(ctx, tx, models.CreateAPIKeyParams{
+		UserID:    userID,
+		TenantID:  "shop-a",
+		Name:      "Partner feed",
+		Prefix:    "prh_abcdefgh",
+		KeyHash:   hash,
+		Scopes:    []string{"products:read", "reviews:write"},
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.CommitTx(tx))
+
+	return key
+}
+
+func hashOf(c string) string {
+	return strings.Repeat(c, 64)
+}
+
+func TestRepository_Create(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := apikeys.NewRepository(tdb.DB)
+
+	t.Run("create key", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		key := createKey(t, repo, userID, hashOf("a"), &expiresAt)
+		assert.NotZero(t, key.ID)
+		assert.Equal(t, userID, key.UserID)
+		assert.Equal(t, "shop-a", key.TenantID)
+		assert.Equal(t, "Partner feed", key.Name)
+		assert.Equal(t, "prh_abcdefgh", key.Prefix)
+		assert.Equal(t, hashOf("a"), key.KeyHash)
+		assert.Equal(t, []string{"products:read", "reviews:write"}, []string(key.Scopes))
+		require.NotNil(t, key.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*key.ExpiresAt))
+		assert.Nil(t, key.RevokedAt)
+		assert.Nil(t, key.LastUsedAt)
+		assert.NotZero(t, key.CreatedAt)
+	})
+}
+
+func TestRepository_List(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := apikeys.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("list keys newest first", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		first := createKey(t, repo, userID, hashOf("a"), nil)
+		second := createKey(t, repo, userID, hashOf("b"), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		keys, err := repo.List(ctx, tx)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, second.ID, keys[0].ID)
+		assert.Equal(t, first.ID, keys[1].ID)
+	})
+
+	t.Run("return empty list", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		keys, err := repo.List(ctx, tx)
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+}
+
+func TestRepository_Revoke(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := apikeys.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("revoke key once", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		key := createKey(t, repo, userID, hashOf("a"), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		revoked, err := repo.Revoke(ctx, tx, key.ID)
+		require.NoError(t, err)
+		require.NotNil(t, revoked.RevokedAt)
+
+		again, err := repo.Revoke(ctx, tx, key.ID)
+		require.NoError(t, err)
+		assert.True(t, revoked.RevokedAt.Equal(*again.RevokedAt), "revocation time should not change")
+	})
+
+	t.Run("return not found", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Revoke(ctx, tx, 999)
+		assert.ErrorIs(t, err, apikeys.ErrNotFound)
+	})
+}
+
+func TestRepository_ExpireBy(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := apikeys.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("set expiry of key without one", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		key := createKey(t, repo, userID, hashOf("a"), nil)
+		at := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		expired, err := repo.ExpireBy(ctx, tx, key.ID, at)
+		require.NoError(t, err)
+		require.NotNil(t, expired.ExpiresAt)
+		assert.True(t, at.Equal(*expired.ExpiresAt))
+	})
+
+	t.Run("keep earlier expiry", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		earlier := time.Now().Add(time.Minute).Truncate(time.Second)
+		key := createKey(t, repo, userID, hashOf("a"), &earlier)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		expired, err := repo.ExpireBy(ctx, tx, key.ID, earlier.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, earlier.Equal(*expired.ExpiresAt))
+	})
+}
+
+func TestRepository_GetByHash(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := apikeys.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("find key by hash", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		key := createKey(t, repo, userID, hashOf("a"), nil)
+
+		found, err := repo.GetByHash(ctx, hashOf("a"))
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, found.ID)
+	})
+
+	t.Run("return not found for unknown hash", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		_, err := repo.GetByHash(ctx, hashOf("f"))
+		assert.ErrorIs(t, err, apikeys.ErrNotFound)
+	})
+}
+
+func TestRepository_TouchLastUsed(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := apikeys.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("never move last use backwards", func(t *testing.T) {
+		tdb.Cleanup(t)
+		userID := tdb.CreateTestUser(t, "partner@example.com")
+		key := createKey(t, repo, userID, hashOf("a"), nil)
+		now := time.Now().Truncate(time.Second)
+
+		require.NoError(t, repo.TouchLastUsed(ctx, key.ID, now))
+		require.NoError(t, repo.TouchLastUsed(ctx, key.ID, now.Add(-time.Hour)))
+
+		found, err := repo.GetByHash(ctx, hashOf("a"))
+		require.NoError(t, err)
+		require.NotNil(t, found.LastUsedAt)
+		assert.True(t, now.Equal(*found.LastUsedAt))
+	})
+}
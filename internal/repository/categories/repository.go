@@ -0,0 +1,326 @@
+// Package categories provides repository for managing product categories in the database.
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Common errors.
+var (
+	ErrNotFound = errors.New("category not found")
+)
+
+// Repository provides methods for managing categories in the database.
+// Every method is scoped to the tenant of its context.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new categories repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// withStatsQuery builds a query returning the categories of the tenant passed as $1 matched by
+// rootFilter together with product count, review count and average rating aggregated over each
+// category's subtree. A product assigned to several categories of the same subtree is only
+// counted once.
+func withStatsQuery(rootFilter string) string {
+	return `
+		WITH RECURSIVE subtree AS (
+			SELECT id AS root_id, id AS category_id
+			FROM categories
+			WHERE tenant_id = $1 AND ` + rootFilter + `
+			UNION
+			SELECT s.root_id, c.id
+			FROM categories c
+			JOIN subtree s ON c.parent_id = s.category_id
+			WHERE c.tenant_id = $1
+		),
+		subtree_products AS (
+			SELECT DISTINCT s.root_id, pc.product_id
+			FROM subtree s
+			JOIN product_categories pc ON pc.category_id = s.category_id
+			JOIN products p ON p.id = pc.product_id AND p.tenant_id = $1
+		)
+		SELECT
+			c.id, c.parent_id, c.name, c.description, c.created_at, c.updated_at,
+			COUNT(DISTINCT sp.product_id) AS product_count,
+			COUNT(r.id) AS review_count,
+			AVG(r.rating)::FLOAT AS average_rating
+		FROM categories c
+		JOIN (SELECT DISTINCT root_id FROM subtree) roots ON roots.root_id = c.id
+		LEFT JOIN subtree_products sp ON sp.root_id = c.id
+		LEFT JOIN reviews r ON r.product_id = sp.product_id
+		GROUP BY c.id
+	`
+}
+
+// Create inserts a new category of the tenant of ctx into the database.
+func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateCategoryParams) (*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO categories (parent_id, name, description, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, parent_id, name, description, created_at, updated_at
+	`
+
+	var category models.Category
+	err := tx.QueryRowxContext(ctx, query, params.ParentID, params.Name, params.Description, tenant.ID(ctx)).
+		StructScan(&category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return &category, nil
+}
+
+// GetByID retrieves a category by its ID with rating aggregates over its subtree.
+func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.CategoryWithStats, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.GetByID")
+	defer span.End()
+
+	query := withStatsQuery("id = $2")
+
+	var category models.CategoryWithStats
+	err := tx.QueryRowxContext(ctx, query, tenant.ID(ctx), id).StructScan(&category)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return &category, nil
+}
+
+// List retrieves a list of categories with pagination.
+func (r *Repository) List(ctx context.Context, tx *sqlx.Tx, params models.ListCategoriesParams) ([]models.CategoryWithStats, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.List")
+	defer span.End()
+
+	//this query aggregates the subtree of every category but for this simple project it is fine
+	query := withStatsQuery("TRUE") + `
+		ORDER BY c.name, c.id
+		LIMIT $2 OFFSET $3
+	`
+
+	var categories []models.CategoryWithStats
+	err := tx.SelectContext(ctx, &categories, query, tenant.ID(ctx), params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// ListByProductID retrieves all categories a product is directly assigned to.
+func (r *Repository) ListByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.CategoryWithStats, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.ListByProductID")
+	defer span.End()
+
+	query := withStatsQuery("id IN (SELECT category_id FROM product_categories WHERE product_id = $2)") + `
+		ORDER BY c.name, c.id
+	`
+
+	var categories []models.CategoryWithStats
+	err := tx.SelectContext(ctx, &categories, query, tenant.ID(ctx), productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// Update updates an existing category.
+func (r *Repository) Update(ctx context.Context, tx *sqlx.Tx, id int64, params models.UpdateCategoryParams) (*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.Update")
+	defer span.End()
+
+	query := `
+		UPDATE categories
+		SET parent_id = $1, name = $2, description = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND tenant_id = $5
+		RETURNING id, parent_id, name, description, created_at, updated_at
+	`
+
+	var category models.Category
+	err := tx.QueryRowxContext(ctx, query, params.ParentID, params.Name, params.Description, id, tenant.ID(ctx)).
+		StructScan(&category)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return &category, nil
+}
+
+// Delete removes a category from the database.
+func (r *Repository) Delete(ctx context.Context, tx *sqlx.Tx, id int64) error {
+	ctx, span := tracing.Start(ctx, "categories.Repository.Delete")
+	defer span.End()
+
+	query := `DELETE FROM categories WHERE id = $1 AND tenant_id = $2`
+
+	result, err := tx.ExecContext(ctx, query, id, tenant.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Exists checks if a category with the given ID exists.
+func (r *Repository) Exists(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.Exists")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND tenant_id = $2)`
+
+	var exists bool
+	err := tx.QueryRowxContext(ctx, query, id, tenant.ID(ctx)).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// FindMissing returns the IDs from the given list that do not match any category of the tenant.
+func (r *Repository) FindMissing(ctx context.Context, tx *sqlx.Tx, ids []int64) ([]int64, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.FindMissing")
+	defer span.End()
+
+	query := `
+		SELECT t.id
+		FROM UNNEST($1::BIGINT[]) AS t(id)
+		WHERE NOT EXISTS (SELECT 1 FROM categories c WHERE c.id = t.id AND c.tenant_id = $2)
+		ORDER BY t.id
+	`
+
+	var missing []int64
+	err := tx.SelectContext(ctx, &missing, query, pq.Array(ids), tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check categories existence: %w", err)
+	}
+
+	return missing, nil
+}
+
+// IsInSubtree checks if the category id is rootID itself or one of its descendants.
+func (r *Repository) IsInSubtree(ctx context.Context, tx *sqlx.Tx, rootID, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.IsInSubtree")
+	defer span.End()
+
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1 AND tenant_id = $3
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id WHERE c.tenant_id = $3
+		)
+		SELECT EXISTS(SELECT 1 FROM subtree WHERE id = $2)
+	`
+
+	var inSubtree bool
+	err := tx.QueryRowxContext(ctx, query, rootID, id, tenant.ID(ctx)).Scan(&inSubtree)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category subtree: %w", err)
+	}
+
+	return inSubtree, nil
+}
+
+// HasChildren checks if a category has any subcategories.
+func (r *Repository) HasChildren(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.HasChildren")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE parent_id = $1 AND tenant_id = $2)`
+
+	var exists bool
+	err := tx.QueryRowxContext(ctx, query, id, tenant.ID(ctx)).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check subcategories existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// HasProducts checks if any product is directly assigned to a category.
+func (r *Repository) HasProducts(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "categories.Repository.HasProducts")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM product_categories WHERE category_id = $1)`
+
+	var exists bool
+	err := tx.QueryRowxContext(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category products existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SetProductCategories replaces the set of categories a product is assigned to.
+func (r *Repository) SetProductCategories(ctx context.Context, tx *sqlx.Tx, productID int64, categoryIDs []int64) error {
+	ctx, span := tracing.Start(ctx, "categories.Repository.SetProductCategories")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM product_categories WHERE product_id = $1`, productID)
+	if err != nil {
+		return fmt.Errorf("failed to clear product categories: %w", err)
+	}
+
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO product_categories (product_id, category_id)
+		SELECT $1, UNNEST($2::BIGINT[])
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err = tx.ExecContext(ctx, query, productID, pq.Array(categoryIDs))
+	if err != nil {
+		return fmt.Errorf("failed to assign product categories: %w", err)
+	}
+
+	return nil
+}
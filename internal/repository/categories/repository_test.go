@@ -0,0 +1,340 @@
+package categories_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/categories"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("create root category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		category, err := repo.Create(ctx, tx, models.CreateCategoryParams{
+			Name:        "Electronics",
+			Description: testutil.StringPtr("Everything with a plug"),
+		})
+		require.NoError(t, err)
+
+		assert.NotZero(t, category.ID)
+		assert.Nil(t, category.ParentID)
+		assert.Equal(t, "Electronics", category.Name)
+		require.NotNil(t, category.Description)
+		assert.Equal(t, "Everything with a plug", *category.Description)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("create child category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		parentID := tdb.CreateTestCategory(t, "Electronics", nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		category, err := repo.Create(ctx, tx, models.CreateCategoryParams{
+			ParentID: &parentID,
+			Name:     "Headphones",
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, category.ParentID)
+		assert.Equal(t, parentID, *category.ParentID)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("get empty category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		categoryID := tdb.CreateTestCategory(t, "Electronics", nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		category, err := repo.GetByID(ctx, tx, categoryID)
+		require.NoError(t, err)
+
+		assert.Equal(t, categoryID, category.ID)
+		assert.Zero(t, category.ProductCount)
+		assert.Zero(t, category.ReviewCount)
+		assert.Nil(t, category.AverageRating)
+	})
+
+	t.Run("aggregates include descendants", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		rootID := tdb.CreateTestCategory(t, "Electronics", nil)
+		childID := tdb.CreateTestCategory(t, "Audio", testutil.Int64Ptr(rootID))
+		grandchildID := tdb.CreateTestCategory(t, "Headphones", testutil.Int64Ptr(childID))
+
+		product1 := tdb.CreateTestProduct(t, "Speaker", nil, 10)
+		product2 := tdb.CreateTestProduct(t, "Headphones", nil, 20)
+		tdb.AssignTestProductCategory(t, product1, childID)
+		tdb.AssignTestProductCategory(t, product2, grandchildID)
+		// Assigned twice within the same subtree, must be counted once
+		tdb.AssignTestProductCategory(t, product2, rootID)
+
+		tdb.CreateTestReview(t, product1, "User1", "Last1", 5, nil)
+		tdb.CreateTestReview(t, product2, "User2", "Last2", 2, nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		root, err := repo.GetByID(ctx, tx, rootID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), root.ProductCount)
+		assert.Equal(t, int64(2), root.ReviewCount)
+		require.NotNil(t, root.AverageRating)
+		assert.InDelta(t, 3.5, *root.AverageRating, 0.001)
+
+		leaf, err := repo.GetByID(ctx, tx, grandchildID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), leaf.ProductCount)
+		require.NotNil(t, leaf.AverageRating)
+		assert.InDelta(t, 2.0, *leaf.AverageRating, 0.001)
+	})
+
+	t.Run("hide categories of other tenants", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		categoryID := tdb.CreateTestTenantCategory(t, "shop-a", "Electronics")
+
+		shopB := tenant.WithID(ctx, "shop-b")
+		tx, err := repo.BeginTx(shopB)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.GetByID(shopB, tx, categoryID)
+		assert.ErrorIs(t, err, categories.ErrNotFound)
+
+		missing, err := repo.FindMissing(shopB, tx, []int64{categoryID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{categoryID}, missing)
+
+		inSubtree, err := repo.IsInSubtree(shopB, tx, categoryID, categoryID)
+		require.NoError(t, err)
+		assert.False(t, inSubtree)
+	})
+
+	t.Run("get non-existing category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.GetByID(ctx, tx, 999999)
+		assert.ErrorIs(t, err, categories.ErrNotFound)
+	})
+}
+
+func TestRepository_List(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("list categories with pagination", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		rootID := tdb.CreateTestCategory(t, "B", nil)
+		tdb.CreateTestCategory(t, "A", testutil.Int64Ptr(rootID))
+		tdb.CreateTestCategory(t, "C", nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		list, err := repo.List(ctx, tx, models.ListCategoriesParams{Limit: 2, Offset: 0})
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "A", list[0].Name)
+		assert.Equal(t, "B", list[1].Name)
+
+		list, err = repo.List(ctx, tx, models.ListCategoriesParams{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "C", list[0].Name)
+	})
+}
+
+func TestRepository_Update(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("move category under another parent", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		parentID := tdb.CreateTestCategory(t, "Electronics", nil)
+		categoryID := tdb.CreateTestCategory(t, "Audio", nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		updated, err := repo.Update(ctx, tx, categoryID, models.UpdateCategoryParams{
+			ParentID: &parentID,
+			Name:     "Audio & Hi-Fi",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Audio & Hi-Fi", updated.Name)
+		require.NotNil(t, updated.ParentID)
+		assert.Equal(t, parentID, *updated.ParentID)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("update non-existing category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Update(ctx, tx, 999999, models.UpdateCategoryParams{Name: "Name"})
+		assert.ErrorIs(t, err, categories.ErrNotFound)
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("delete existing category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		categoryID := tdb.CreateTestCategory(t, "To Delete", nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		require.NoError(t, repo.Delete(ctx, tx, categoryID))
+
+		exists, err := repo.Exists(ctx, tx, categoryID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("delete non-existing category", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		err = repo.Delete(ctx, tx, 999999)
+		assert.ErrorIs(t, err, categories.ErrNotFound)
+	})
+}
+
+func TestRepository_Hierarchy(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("subtree and children checks", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		rootID := tdb.CreateTestCategory(t, "Electronics", nil)
+		childID := tdb.CreateTestCategory(t, "Audio", testutil.Int64Ptr(rootID))
+		otherID := tdb.CreateTestCategory(t, "Books", nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		inSubtree, err := repo.IsInSubtree(ctx, tx, rootID, childID)
+		require.NoError(t, err)
+		assert.True(t, inSubtree)
+
+		inSubtree, err = repo.IsInSubtree(ctx, tx, rootID, rootID)
+		require.NoError(t, err)
+		assert.True(t, inSubtree)
+
+		inSubtree, err = repo.IsInSubtree(ctx, tx, childID, rootID)
+		require.NoError(t, err)
+		assert.False(t, inSubtree)
+
+		hasChildren, err := repo.HasChildren(ctx, tx, rootID)
+		require.NoError(t, err)
+		assert.True(t, hasChildren)
+
+		hasChildren, err = repo.HasChildren(ctx, tx, otherID)
+		require.NoError(t, err)
+		assert.False(t, hasChildren)
+
+		missing, err := repo.FindMissing(ctx, tx, []int64{rootID, 999999, otherID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{999999}, missing)
+	})
+}
+
+func TestRepository_SetProductCategories(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := categories.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("replace product categories", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Product", nil, 10)
+		cat1 := tdb.CreateTestCategory(t, "Cat 1", nil)
+		cat2 := tdb.CreateTestCategory(t, "Cat 2", nil)
+		cat3 := tdb.CreateTestCategory(t, "Cat 3", nil)
+		tdb.AssignTestProductCategory(t, productID, cat1)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		require.NoError(t, repo.SetProductCategories(ctx, tx, productID, []int64{cat2, cat3, cat3}))
+
+		list, err := repo.ListByProductID(ctx, tx, productID)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, cat2, list[0].ID)
+		assert.Equal(t, cat3, list[1].ID)
+
+		hasProducts, err := repo.HasProducts(ctx, tx, cat1)
+		require.NoError(t, err)
+		assert.False(t, hasProducts)
+
+		require.NoError(t, repo.SetProductCategories(ctx, tx, productID, nil))
+
+		list, err = repo.ListByProductID(ctx, tx, productID)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+}
@@ -4,28 +4,60 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync/atomic"
 	"time"
 
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/tracing"
+
 	"github.com/redis/go-redis/v9"
 )
 
 const keyPrefix = "idempotency:"
 
+// redisKey generates the Redis key of an idempotency key. Keys are scoped by tenant so that
+// clients of different storefronts choosing the same key do not see each other's responses.
+func redisKey(ctx context.Context, key string) string {
+	return keyPrefix + tenant.ID(ctx) + ":" + key
+}
+
+// ErrUnavailable is returned by a RedisStore without a Redis client.
+var ErrUnavailable = errors.New("idempotency store is unavailable")
+
 // RedisStore implements Store interface using Redis.
 type RedisStore struct {
-	client *redis.Client
+	client atomic.Pointer[redis.Client]
 }
 
-// NewRedisStore creates a new RedisStore instance.
+// NewRedisStore creates a new RedisStore instance. A nil client creates a store that is
+// unavailable until a client is attached.
 func NewRedisStore(client *redis.Client) *RedisStore {
-	return &RedisStore{
-		client: client,
-	}
+	s := &RedisStore{}
+	s.client.Store(client)
+	return s
+}
+
+// Attach attaches the Redis client of an unavailable store.
+func (s *RedisStore) Attach(client *redis.Client) {
+	s.client.Store(client)
+}
+
+// Available reports whether the store has a Redis client.
+func (s *RedisStore) Available() bool {
+	return s.client.Load() != nil
 }
 
 // Get retrieves a cached response by idempotency key.
 func (s *RedisStore) Get(ctx context.Context, key string) (*CachedResponse, error) {
-	data, err := s.client.Get(ctx, keyPrefix+key).Bytes()
+	ctx, span := tracing.Start(ctx, "idempotency.RedisStore.Get")
+	defer span.End()
+
+	client := s.client.Load()
+	if client == nil {
+		return nil, ErrUnavailable
+	}
+
+	data, err := client.Get(ctx, redisKey(ctx, key)).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, nil
@@ -43,10 +75,18 @@ func (s *RedisStore) Get(ctx context.Context, key string) (*CachedResponse, erro
 
 // Set stores a cached response with the given TTL.
 func (s *RedisStore) Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	ctx, span := tracing.Start(ctx, "idempotency.RedisStore.Set")
+	defer span.End()
+
+	client := s.client.Load()
+	if client == nil {
+		return ErrUnavailable
+	}
+
 	data, err := json.Marshal(resp)
 	if err != nil {
 		return err
 	}
 
-	return s.client.Set(ctx, keyPrefix+key, data, ttl).Err()
+	return client.Set(ctx, redisKey(ctx, key), data, ttl).Err()
 }
@@ -10,6 +10,9 @@ type CachedResponse struct {
 	StatusCode int               `json:"status_code"`
 	Headers    map[string]string `json:"headers"`
 	Body       []byte            `json:"body"`
+	// Caller identifies the caller of the original request; only that caller gets the
+	// response replayed.
+	Caller string `json:"caller"`
 }
 
 // Store defines the interface for idempotency key storage.
@@ -20,4 +23,8 @@ type Store interface {
 
 	// Set stores a cached response with the given TTL.
 	Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+
+	// Available reports whether the store can be used. Requests are processed without
+	// idempotency while it cannot.
+	Available() bool
 }
@@ -0,0 +1,180 @@
+// Package images provides repository for managing product image metadata in the database.
+package images
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Common errors.
+var (
+	ErrNotFound      = errors.New("image not found")
+	ErrOrderMismatch = errors.New("image order must list every image of the product exactly once")
+)
+
+const imageColumns = `id, product_id, position, blob_key, thumbnail_key, content_type, size_bytes, width, height, alt_text, created_at`
+
+// Repository provides methods for managing product image metadata in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new images repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "images.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// Create records a new image after the existing images of the product.
+func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateImageParams) (*models.ProductImage, error) {
+	ctx, span := tracing.Start(ctx, "images.Repository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO product_images (product_id, position, blob_key, thumbnail_key, content_type, size_bytes, width, height, alt_text)
+		VALUES (
+			$1,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM product_images WHERE product_id = $1),
+			$2, $3, $4, $5, $6, $7, $8
+		)
+		RETURNING ` + imageColumns
+
+	var image models.ProductImage
+	err := tx.QueryRowxContext(ctx, query, params.ProductID, params.BlobKey, params.ThumbnailKey, params.ContentType,
+		params.SizeBytes, params.Width, params.Height, params.AltText).StructScan(&image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+
+	return &image, nil
+}
+
+// ListByProductID retrieves the images of a product in display order.
+func (r *Repository) ListByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.ProductImage, error) {
+	ctx, span := tracing.Start(ctx, "images.Repository.ListByProductID")
+	defer span.End()
+
+	query := `SELECT ` + imageColumns + ` FROM product_images WHERE product_id = $1 ORDER BY position, id`
+
+	var productImages []models.ProductImage
+	err := tx.SelectContext(ctx, &productImages, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	return productImages, nil
+}
+
+// ListByProductIDs retrieves the images of several products in display order, keyed by product ID.
+func (r *Repository) ListByProductIDs(ctx context.Context, tx *sqlx.Tx, productIDs []int64) (map[int64][]models.ProductImage, error) {
+	ctx, span := tracing.Start(ctx, "images.Repository.ListByProductIDs")
+	defer span.End()
+
+	result := make(map[int64][]models.ProductImage, len(productIDs))
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	query := `SELECT ` + imageColumns + ` FROM product_images WHERE product_id = ANY($1::BIGINT[]) ORDER BY product_id, position, id`
+
+	var productImages []models.ProductImage
+	err := tx.SelectContext(ctx, &productImages, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	for _, image := range productImages {
+		result[image.ProductID] = append(result[image.ProductID], image)
+	}
+
+	return result, nil
+}
+
+// Reorder sets the display order of the images of a product to the order of ids.
+// ids must contain every image of the product exactly once.
+func (r *Repository) Reorder(ctx context.Context, tx *sqlx.Tx, productID int64, ids []int64) error {
+	ctx, span := tracing.Start(ctx, "images.Repository.Reorder")
+	defer span.End()
+
+	// Lock the images so concurrent uploads cannot change the set while it is being reordered
+	var current []int64
+	err := tx.SelectContext(ctx, &current, `SELECT id FROM product_images WHERE product_id = $1 FOR UPDATE`, productID)
+	if err != nil {
+		return fmt.Errorf("failed to lock images: %w", err)
+	}
+
+	if !sameIDs(current, ids) {
+		return ErrOrderMismatch
+	}
+
+	query := `
+		UPDATE product_images pi
+		SET position = o.position
+		FROM UNNEST($2::BIGINT[]) WITH ORDINALITY AS o(id, position)
+		WHERE pi.id = o.id AND pi.product_id = $1
+	`
+
+	if _, err := tx.ExecContext(ctx, query, productID, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to reorder images: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByIDAndProductID removes an image of a product and returns its metadata so the files can be removed.
+func (r *Repository) DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) (*models.ProductImage, error) {
+	ctx, span := tracing.Start(ctx, "images.Repository.DeleteByIDAndProductID")
+	defer span.End()
+
+	query := `DELETE FROM product_images WHERE id = $1 AND product_id = $2 RETURNING ` + imageColumns
+
+	var image models.ProductImage
+	err := tx.QueryRowxContext(ctx, query, id, productID).StructScan(&image)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	return &image, nil
+}
+
+// sameIDs reports whether ids lists every element of current exactly once.
+func sameIDs(current, ids []int64) bool {
+	if len(current) != len(ids) {
+		return false
+	}
+
+	remaining := make(map[int64]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+
+	return true
+}
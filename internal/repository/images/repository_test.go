@@ -0,0 +1,177 @@
+package images_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/images"
+	"product_review_hub/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := images.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("append images in order", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		altText := "Front view"
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		first, err := repo.Create(ctx, tx, models.CreateImageParams{
+			ProductID:    productID,
+			BlobKey:      "products/1/a.png",
+			ThumbnailKey: "products/1/a_thumb.png",
+			ContentType:  "image/png",
+			SizeBytes:    1024,
+			Width:        800,
+			Height:       600,
+			AltText:      &altText,
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, first.ID)
+		assert.Equal(t, 1, first.Position)
+		assert.Equal(t, "products/1/a.png", first.BlobKey)
+		assert.Equal(t, int64(1024), first.SizeBytes)
+		require.NotNil(t, first.AltText)
+		assert.Equal(t, altText, *first.AltText)
+
+		second, err := repo.Create(ctx, tx, models.CreateImageParams{
+			ProductID:    productID,
+			BlobKey:      "products/1/b.png",
+			ThumbnailKey: "products/1/b_thumb.png",
+			ContentType:  "image/png",
+			SizeBytes:    2048,
+			Width:        10,
+			Height:       10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, second.Position)
+		assert.Nil(t, second.AltText)
+	})
+}
+
+func TestRepository_ListByProductIDs(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := images.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("group images by product in display order", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		product1 := tdb.CreateTestProduct(t, "Product 1", nil, 10)
+		product2 := tdb.CreateTestProduct(t, "Product 2", nil, 10)
+		product3 := tdb.CreateTestProduct(t, "Product 3", nil, 10)
+		second := tdb.CreateTestImage(t, product1, "p1/b.png", 2)
+		first := tdb.CreateTestImage(t, product1, "p1/a.png", 1)
+		other := tdb.CreateTestImage(t, product2, "p2/a.png", 1)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		result, err := repo.ListByProductIDs(ctx, tx, []int64{product1, product2, product3})
+		require.NoError(t, err)
+
+		require.Len(t, result[product1], 2)
+		assert.Equal(t, first, result[product1][0].ID)
+		assert.Equal(t, second, result[product1][1].ID)
+		require.Len(t, result[product2], 1)
+		assert.Equal(t, other, result[product2][0].ID)
+		assert.Empty(t, result[product3])
+	})
+}
+
+func TestRepository_Reorder(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := images.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("reorder images", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		a := tdb.CreateTestImage(t, productID, "a.png", 1)
+		b := tdb.CreateTestImage(t, productID, "b.png", 2)
+		c := tdb.CreateTestImage(t, productID, "c.png", 3)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		require.NoError(t, repo.Reorder(ctx, tx, productID, []int64{c, a, b}))
+
+		productImages, err := repo.ListByProductID(ctx, tx, productID)
+		require.NoError(t, err)
+		require.Len(t, productImages, 3)
+		assert.Equal(t, []int64{c, a, b}, []int64{productImages[0].ID, productImages[1].ID, productImages[2].ID})
+		assert.Equal(t, []int{1, 2, 3}, []int{productImages[0].Position, productImages[1].Position, productImages[2].Position})
+	})
+
+	t.Run("reject incomplete or foreign order", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		otherProductID := tdb.CreateTestProduct(t, "Other Product", nil, 10)
+		a := tdb.CreateTestImage(t, productID, "a.png", 1)
+		b := tdb.CreateTestImage(t, productID, "b.png", 2)
+		foreign := tdb.CreateTestImage(t, otherProductID, "c.png", 1)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		assert.ErrorIs(t, repo.Reorder(ctx, tx, productID, []int64{a}), images.ErrOrderMismatch)
+		assert.ErrorIs(t, repo.Reorder(ctx, tx, productID, []int64{a, a}), images.ErrOrderMismatch)
+		assert.ErrorIs(t, repo.Reorder(ctx, tx, productID, []int64{a, foreign}), images.ErrOrderMismatch)
+		assert.NoError(t, repo.Reorder(ctx, tx, productID, []int64{b, a}))
+	})
+}
+
+func TestRepository_DeleteByIDAndProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := images.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("delete image", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		imageID := tdb.CreateTestImage(t, productID, "a.png", 1)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		image, err := repo.DeleteByIDAndProductID(ctx, tx, imageID, productID)
+		require.NoError(t, err)
+		assert.Equal(t, "a.png", image.BlobKey)
+		assert.Equal(t, "a.png_thumb", image.ThumbnailKey)
+
+		_, err = repo.DeleteByIDAndProductID(ctx, tx, imageID, productID)
+		assert.ErrorIs(t, err, images.ErrNotFound)
+	})
+
+	t.Run("delete image of another product", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		otherProductID := tdb.CreateTestProduct(t, "Other Product", nil, 10)
+		imageID := tdb.CreateTestImage(t, otherProductID, "a.png", 1)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.DeleteByIDAndProductID(ctx, tx, imageID, productID)
+		assert.ErrorIs(t, err, images.ErrNotFound)
+	})
+}
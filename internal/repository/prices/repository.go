@@ -0,0 +1,265 @@
+// Package prices provides repository for managing product price history in the database.
+package prices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Common errors.
+var (
+	ErrNotFound         = errors.New("price not found")
+	ErrCurrencyNotFound = errors.New("currency price not found")
+	ErrAlreadyEffective = errors.New("price change has already taken effect")
+)
+
+// currentPriceID selects the ID of the price in effect for the product p in its currency.
+// Among the prices whose period contains the current time the latest one wins,
+// so a scheduled sale overrides the regular price until it ends.
+const currentPriceID = `(
+	SELECT c.id FROM product_prices c
+	WHERE c.product_id = p.id
+		AND c.currency = p.currency
+		AND c.effective_from <= CURRENT_TIMESTAMP
+		AND (c.effective_to IS NULL OR c.effective_to > CURRENT_TIMESTAMP)
+	ORDER BY c.effective_from DESC, c.id DESC
+	LIMIT 1
+)`
+
+// Repository provides methods for managing product prices in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new prices repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// Record inserts a price in the product currency that takes effect immediately and stays until the next change.
+func (r *Repository) Record(ctx context.Context, tx *sqlx.Tx, productID int64, price money.Amount) (*models.ProductPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.Record")
+	defer span.End()
+
+	query := `
+		INSERT INTO product_prices (product_id, price, currency)
+		SELECT id, $2::DECIMAL, currency FROM products WHERE id = $1
+		RETURNING id, product_id, price, currency, effective_from, effective_to, created_at, TRUE AS is_current
+	`
+
+	var productPrice models.ProductPrice
+	err := tx.QueryRowxContext(ctx, query, productID, price).StructScan(&productPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record price: %w", err)
+	}
+
+	return &productPrice, nil
+}
+
+// Schedule inserts a price in the product currency that is in effect between EffectiveFrom and EffectiveTo.
+func (r *Repository) Schedule(ctx context.Context, tx *sqlx.Tx, params models.SchedulePriceParams) (*models.ProductPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.Schedule")
+	defer span.End()
+
+	query := `
+		INSERT INTO product_prices (product_id, price, currency, effective_from, effective_to)
+		SELECT id, $2::DECIMAL, currency, $3::TIMESTAMPTZ, $4::TIMESTAMPTZ FROM products WHERE id = $1
+		RETURNING id, product_id, price, currency, effective_from, effective_to, created_at, FALSE AS is_current
+	`
+
+	var productPrice models.ProductPrice
+	err := tx.QueryRowxContext(ctx, query, params.ProductID, params.Price, params.EffectiveFrom, params.EffectiveTo).
+		StructScan(&productPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule price: %w", err)
+	}
+
+	// A change scheduled for the past is in effect right away
+	query = `
+		SELECT (pp.id = ` + currentPriceID + `) IS TRUE
+		FROM product_prices pp
+		JOIN products p ON p.id = pp.product_id
+		WHERE pp.id = $1
+	`
+	if err := tx.QueryRowxContext(ctx, query, productPrice.ID).Scan(&productPrice.IsCurrent); err != nil {
+		return nil, fmt.Errorf("failed to check current price: %w", err)
+	}
+
+	return &productPrice, nil
+}
+
+// ListByProductID retrieves the price history of a product with pagination, newest effective date first.
+func (r *Repository) ListByProductID(ctx context.Context, tx *sqlx.Tx, params models.ListPricesParams) ([]models.ProductPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.ListByProductID")
+	defer span.End()
+
+	query := `
+		SELECT pp.id, pp.product_id, pp.price, pp.currency, pp.effective_from, pp.effective_to, pp.created_at,
+			(pp.id = ` + currentPriceID + `) IS TRUE AS is_current
+		FROM product_prices pp
+		JOIN products p ON p.id = pp.product_id
+		WHERE pp.product_id = $1
+		ORDER BY pp.effective_from DESC, pp.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var productPrices []models.ProductPrice
+	err := tx.SelectContext(ctx, &productPrices, query, params.ProductID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prices: %w", err)
+	}
+
+	return productPrices, nil
+}
+
+// DeleteScheduledByIDAndProductID removes a price change that has not taken effect yet.
+func (r *Repository) DeleteScheduledByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) error {
+	ctx, span := tracing.Start(ctx, "prices.Repository.DeleteScheduledByIDAndProductID")
+	defer span.End()
+
+	query := `
+		WITH target AS (
+			SELECT id, effective_from > CURRENT_TIMESTAMP AS pending
+			FROM product_prices
+			WHERE id = $1 AND product_id = $2
+			FOR UPDATE
+		), deleted AS (
+			DELETE FROM product_prices
+			WHERE id IN (SELECT id FROM target WHERE pending)
+		)
+		SELECT pending FROM target
+	`
+
+	var pending bool
+	err := tx.QueryRowxContext(ctx, query, id, productID).Scan(&pending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete scheduled price: %w", err)
+	}
+
+	if !pending {
+		return ErrAlreadyEffective
+	}
+
+	return nil
+}
+
+// SetCurrencyPrice creates or replaces the price of a product in a currency.
+func (r *Repository) SetCurrencyPrice(ctx context.Context, tx *sqlx.Tx, productID int64, currency money.Currency, price money.Amount) (*models.CurrencyPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.SetCurrencyPrice")
+	defer span.End()
+
+	query := `
+		INSERT INTO product_currency_prices (product_id, currency, price)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id, currency) DO UPDATE
+		SET price = EXCLUDED.price, updated_at = CURRENT_TIMESTAMP
+		RETURNING product_id, currency, price, created_at, updated_at
+	`
+
+	var currencyPrice models.CurrencyPrice
+	err := tx.QueryRowxContext(ctx, query, productID, currency, price).StructScan(&currencyPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set currency price: %w", err)
+	}
+
+	return &currencyPrice, nil
+}
+
+// ListCurrencyPrices retrieves the prices of a product in other currencies ordered by currency.
+func (r *Repository) ListCurrencyPrices(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.CurrencyPrice, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.ListCurrencyPrices")
+	defer span.End()
+
+	query := `
+		SELECT product_id, currency, price, created_at, updated_at
+		FROM product_currency_prices
+		WHERE product_id = $1
+		ORDER BY currency
+	`
+
+	var currencyPrices []models.CurrencyPrice
+	err := tx.SelectContext(ctx, &currencyPrices, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list currency prices: %w", err)
+	}
+
+	return currencyPrices, nil
+}
+
+// ListCurrencyPricesByProductIDs retrieves the prices of several products in a currency keyed by product ID.
+// Products without a price in the currency are absent from the result.
+func (r *Repository) ListCurrencyPricesByProductIDs(ctx context.Context, tx *sqlx.Tx, productIDs []int64, currency money.Currency) (map[int64]money.Amount, error) {
+	ctx, span := tracing.Start(ctx, "prices.Repository.ListCurrencyPricesByProductIDs")
+	defer span.End()
+
+	result := make(map[int64]money.Amount, len(productIDs))
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT product_id, currency, price, created_at, updated_at
+		FROM product_currency_prices
+		WHERE product_id = ANY($1::BIGINT[]) AND currency = $2
+	`
+
+	var currencyPrices []models.CurrencyPrice
+	err := tx.SelectContext(ctx, &currencyPrices, query, pq.Array(productIDs), currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list currency prices: %w", err)
+	}
+
+	for _, currencyPrice := range currencyPrices {
+		result[currencyPrice.ProductID] = currencyPrice.Price
+	}
+
+	return result, nil
+}
+
+// DeleteCurrencyPrice removes the price of a product in a currency.
+func (r *Repository) DeleteCurrencyPrice(ctx context.Context, tx *sqlx.Tx, productID int64, currency money.Currency) error {
+	ctx, span := tracing.Start(ctx, "prices.Repository.DeleteCurrencyPrice")
+	defer span.End()
+
+	query := `DELETE FROM product_currency_prices WHERE product_id = $1 AND currency = $2`
+
+	result, err := tx.ExecContext(ctx, query, productID, currency)
+	if err != nil {
+		return fmt.Errorf("failed to delete currency price: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrCurrencyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package prices_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/repository/prices"
+	"product_review_hub/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Record(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := prices.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("record price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productPrice, err := repo.Record(ctx, tx, productID, money.MustParse("89.99"))
+		require.NoError(t, err)
+
+		assert.NotZero(t, productPrice.ID)
+		assert.Equal(t, productID, productPrice.ProductID)
+		assert.Equal(t, money.MustParse("89.99"), productPrice.Price)
+		assert.Nil(t, productPrice.EffectiveTo)
+		assert.True(t, productPrice.IsCurrent)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+}
+
+func TestRepository_Schedule(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := prices.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("schedule future price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		from := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		to := from.Add(24 * time.Hour)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productPrice, err := repo.Schedule(ctx, tx, models.SchedulePriceParams{
+			ProductID:     productID,
+			Price:         money.MustParse("79.99"),
+			EffectiveFrom: from,
+			EffectiveTo:   &to,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, money.MustParse("79.99"), productPrice.Price)
+		assert.True(t, from.Equal(productPrice.EffectiveFrom))
+		require.NotNil(t, productPrice.EffectiveTo)
+		assert.True(t, to.Equal(*productPrice.EffectiveTo))
+		assert.False(t, productPrice.IsCurrent)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("schedule price already in effect", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		tdb.CreateTestPrice(t, productID, 99.99, time.Now().Add(-48*time.Hour), nil)
+		to := time.Now().Add(time.Hour)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productPrice, err := repo.Schedule(ctx, tx, models.SchedulePriceParams{
+			ProductID:     productID,
+			Price:         money.MustParse("79.99"),
+			EffectiveFrom: time.Now().Add(-time.Hour),
+			EffectiveTo:   &to,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, productPrice.IsCurrent)
+	})
+}
+
+func TestRepository_ListByProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := prices.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("list history newest first with current price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		now := time.Now()
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		otherProductID := tdb.CreateTestProduct(t, "Other Product", nil, 10)
+		initialID := tdb.CreateTestPrice(t, productID, 99.99, now.Add(-48*time.Hour), nil)
+		saleID := tdb.CreateTestPrice(t, productID, 79.99, now.Add(-time.Hour), testutil.TimePtr(now.Add(time.Hour)))
+		scheduledID := tdb.CreateTestPrice(t, productID, 89.99, now.Add(24*time.Hour), nil)
+		tdb.CreateTestPrice(t, otherProductID, 10, now.Add(-time.Hour), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		history, err := repo.ListByProductID(ctx, tx, models.ListPricesParams{
+			ProductID: productID,
+			Limit:     10,
+			Offset:    0,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, history, 3)
+		assert.Equal(t, scheduledID, history[0].ID)
+		assert.False(t, history[0].IsCurrent)
+		assert.Equal(t, saleID, history[1].ID)
+		assert.True(t, history[1].IsCurrent)
+		assert.Equal(t, initialID, history[2].ID)
+		assert.False(t, history[2].IsCurrent)
+	})
+
+	t.Run("list history with pagination", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		now := time.Now()
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		for i := 1; i <= 5; i++ {
+			tdb.CreateTestPrice(t, productID, float64(i), now.Add(-time.Duration(i)*time.Hour), nil)
+		}
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		history, err := repo.ListByProductID(ctx, tx, models.ListPricesParams{
+			ProductID: productID,
+			Limit:     2,
+			Offset:    2,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, history, 2)
+		assert.Equal(t, money.MustParse("3"), history[0].Price)
+		assert.Equal(t, money.MustParse("4"), history[1].Price)
+	})
+}
+
+func TestRepository_DeleteScheduledByIDAndProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := prices.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("delete scheduled price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		priceID := tdb.CreateTestPrice(t, productID, 79.99, time.Now().Add(time.Hour), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		err = repo.DeleteScheduledByIDAndProductID(ctx, tx, priceID, productID)
+		require.NoError(t, err)
+
+		history, err := repo.ListByProductID(ctx, tx, models.ListPricesParams{ProductID: productID, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("delete price already in effect", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		priceID := tdb.CreateTestPrice(t, productID, 99.99, time.Now().Add(-time.Hour), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		err = repo.DeleteScheduledByIDAndProductID(ctx, tx, priceID, productID)
+		assert.ErrorIs(t, err, prices.ErrAlreadyEffective)
+
+		history, err := repo.ListByProductID(ctx, tx, models.ListPricesParams{ProductID: productID, Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+	})
+
+	t.Run("delete price of another product", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		otherProductID := tdb.CreateTestProduct(t, "Other Product", nil, 10)
+		priceID := tdb.CreateTestPrice(t, otherProductID, 5, time.Now().Add(time.Hour), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		err = repo.DeleteScheduledByIDAndProductID(ctx, tx, priceID, productID)
+		assert.ErrorIs(t, err, prices.ErrNotFound)
+	})
+}
+
+func TestRepository_CurrencyPrices(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := prices.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("set and replace currency price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.SetCurrencyPrice(ctx, tx, productID, "EUR", money.MustParse("89.00"))
+		require.NoError(t, err)
+		currencyPrice, err := repo.SetCurrencyPrice(ctx, tx, productID, "EUR", money.MustParse("85.50"))
+		require.NoError(t, err)
+		assert.Equal(t, money.Currency("EUR"), currencyPrice.Currency)
+		assert.Equal(t, money.MustParse("85.50"), currencyPrice.Price)
+
+		_, err = repo.SetCurrencyPrice(ctx, tx, productID, "GBP", money.MustParse("75.00"))
+		require.NoError(t, err)
+
+		currencyPrices, err := repo.ListCurrencyPrices(ctx, tx, productID)
+		require.NoError(t, err)
+		require.Len(t, currencyPrices, 2)
+		assert.Equal(t, money.Currency("EUR"), currencyPrices[0].Currency)
+		assert.Equal(t, money.Currency("GBP"), currencyPrices[1].Currency)
+	})
+
+	t.Run("list currency prices by product IDs", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		product1 := tdb.CreateTestProduct(t, "Product 1", nil, 10)
+		product2 := tdb.CreateTestProduct(t, "Product 2", nil, 20)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.SetCurrencyPrice(ctx, tx, product1, "EUR", money.MustParse("9.50"))
+		require.NoError(t, err)
+		_, err = repo.SetCurrencyPrice(ctx, tx, product2, "GBP", money.MustParse("17.00"))
+		require.NoError(t, err)
+
+		result, err := repo.ListCurrencyPricesByProductIDs(ctx, tx, []int64{product1, product2}, "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]money.Amount{product1: money.MustParse("9.50")}, result)
+	})
+
+	t.Run("delete currency price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.SetCurrencyPrice(ctx, tx, productID, "EUR", money.MustParse("89.00"))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeleteCurrencyPrice(ctx, tx, productID, "EUR"))
+
+		err = repo.DeleteCurrencyPrice(ctx, tx, productID, "EUR")
+		assert.ErrorIs(t, err, prices.ErrCurrencyNotFound)
+	})
+}
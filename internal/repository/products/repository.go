@@ -6,10 +6,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
+	"product_review_hub/internal/database"
 	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/tracing"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // Common errors.
@@ -17,7 +23,27 @@ var (
 	ErrNotFound = errors.New("product not found")
 )
 
+// currentPrice computes the price in effect for the product p from its price history in the
+// product currency, falling back to the stored price. Among the prices whose period contains the current
+// time the latest one wins, so a scheduled sale overrides the regular price until it ends.
+const currentPrice = `COALESCE((
+	SELECT pp.price FROM product_prices pp
+	WHERE pp.product_id = p.id
+		AND pp.currency = p.currency
+		AND pp.effective_from <= CURRENT_TIMESTAMP
+		AND (pp.effective_to IS NULL OR pp.effective_to > CURRENT_TIMESTAMP)
+	ORDER BY pp.effective_from DESC, pp.id DESC
+	LIMIT 1
+), p.price)`
+
+// inStock reports whether the product p has stock that is not reserved.
+const inStock = `EXISTS (
+	SELECT 1 FROM product_stock ps
+	WHERE ps.product_id = p.id AND ps.quantity > ps.reserved
+)`
+
 // Repository provides methods for managing products in the database.
+// Every method except ApplyScheduledPrices is scoped to the tenant of its context.
 type Repository struct {
 	db *sqlx.DB
 }
@@ -28,23 +54,38 @@ func NewRepository(db *sqlx.DB) *Repository {
 }
 
 func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
-	return r.db.BeginTxx(ctx, nil)
+	ctx, span := tracing.Start(ctx, "products.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+// BeginAllTenantsTx starts a transaction that sees the products of every tenant, for
+// ApplyScheduledPrices.
+func (r *Repository) BeginAllTenantsTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.BeginAllTenantsTx")
+	defer span.End()
+
+	return database.BeginAllTenantsTx(ctx, r.db)
 }
 
 func (r *Repository) CommitTx(tx *sqlx.Tx) error {
 	return tx.Commit()
 }
 
-// Create inserts a new product into the database.
+// Create inserts a new product of the tenant of ctx into the database.
 func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateProductParams) (*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.Create")
+	defer span.End()
+
 	query := `
-		INSERT INTO products (name, description, price)
-		VALUES ($1, $2, $3)
-		RETURNING id, name, description, price, created_at, updated_at
+		INSERT INTO products (name, description, price, currency, tags, attributes, tenant_id)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'USD'), COALESCE($5::TEXT[], '{}'), COALESCE($6::JSONB, '{}'), $7)
+		RETURNING id, name, description, price, currency, tags, attributes, created_at, updated_at
 	`
 
 	var product models.Product
-	err := tx.QueryRowxContext(ctx, query, params.Name, params.Description, params.Price).
+	err := tx.QueryRowxContext(ctx, query, params.Name, params.Description, params.Price, params.Currency, pq.Array(params.Tags), params.Attributes, tenant.ID(ctx)).
 		StructScan(&product)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
@@ -53,21 +94,25 @@ func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.Crea
 	return &product, nil
 }
 
-// GetByID retrieves a product by its ID with average rating.
+// GetByID retrieves a product by its ID with average rating and the price currently in effect.
 func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.ProductWithRating, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.GetByID")
+	defer span.End()
+
 	//this query is not efficient because it joins the reviews table on every product but for this simple project it is fine
 	query := `
 		SELECT 
-			p.id, p.name, p.description, p.price, p.created_at, p.updated_at,
+			p.id, p.name, p.description, ` + currentPrice + ` AS price, p.currency, p.tags, p.attributes, p.created_at, p.updated_at,
+			` + inStock + ` AS in_stock,
 			AVG(r.rating)::FLOAT AS average_rating
 		FROM products p
 		LEFT JOIN reviews r ON p.id = r.product_id
-		WHERE p.id = $1
+		WHERE p.id = $1 AND p.tenant_id = $2
 		GROUP BY p.id
 	`
 
 	var product models.ProductWithRating
-	err := tx.QueryRowxContext(ctx, query, id).StructScan(&product)
+	err := tx.QueryRowxContext(ctx, query, id, tenant.ID(ctx)).StructScan(&product)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -78,22 +123,31 @@ func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*model
 	return &product, nil
 }
 
-// List retrieves a list of products with pagination.
+// List retrieves a list of products with pagination and optional filters.
 func (r *Repository) List(ctx context.Context, tx *sqlx.Tx, params models.ListProductsParams) ([]models.ProductWithRating, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.List")
+	defer span.End()
+
+	where, args := buildFilter(tenant.ID(ctx), params.ProductFilter)
+
+	args = append(args, params.Limit, params.Offset)
+
 	//this query is not efficient because it joins the reviews table on every product but for this simple project it is fine
-	query := `
+	query := fmt.Sprintf(`
 		SELECT 
-			p.id, p.name, p.description, p.price, p.created_at, p.updated_at,
+			p.id, p.name, p.description, `+currentPrice+` AS price, p.currency, p.tags, p.attributes, p.created_at, p.updated_at,
+			`+inStock+` AS in_stock,
 			AVG(r.rating)::FLOAT AS average_rating
 		FROM products p
 		LEFT JOIN reviews r ON p.id = r.product_id
+		%s
 		GROUP BY p.id
 		ORDER BY p.created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
 
 	var products []models.ProductWithRating
-	err := tx.SelectContext(ctx, &products, query, params.Limit, params.Offset)
+	err := tx.SelectContext(ctx, &products, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
@@ -101,17 +155,111 @@ func (r *Repository) List(ctx context.Context, tx *sqlx.Tx, params models.ListPr
 	return products, nil
 }
 
+// Facets counts products per tag and per attribute value among the products matching the filter.
+func (r *Repository) Facets(ctx context.Context, tx *sqlx.Tx, filter models.ProductFilter) (*models.ProductFacets, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.Facets")
+	defer span.End()
+
+	where, args := buildFilter(tenant.ID(ctx), filter)
+
+	tagsQuery := fmt.Sprintf(`
+		SELECT t.tag AS value, COUNT(*) AS count
+		FROM products p
+		CROSS JOIN LATERAL UNNEST(p.tags) AS t(tag)
+		%s
+		GROUP BY t.tag
+		ORDER BY count DESC, value
+	`, where)
+
+	facets := &models.ProductFacets{
+		Tags:       []models.FacetCount{},
+		Attributes: []models.AttributeFacetCount{},
+	}
+
+	if err := tx.SelectContext(ctx, &facets.Tags, tagsQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to count tag facets: %w", err)
+	}
+
+	attributesQuery := fmt.Sprintf(`
+		SELECT a.key, a.value #>> '{}' AS value, COUNT(*) AS count
+		FROM products p
+		CROSS JOIN LATERAL JSONB_EACH(p.attributes) AS a
+		%s
+		GROUP BY a.key, a.value #>> '{}'
+		ORDER BY a.key, count DESC, value
+	`, where)
+
+	if err := tx.SelectContext(ctx, &facets.Attributes, attributesQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to count attribute facets: %w", err)
+	}
+
+	return facets, nil
+}
+
+// buildFilter builds the WHERE clause and its arguments for the given product filter
+// among the products of the tenant. The products table must be aliased as p.
+func buildFilter(tenantID string, filter models.ProductFilter) (string, []interface{}) {
+	conditions := []string{"p.tenant_id = $1"}
+	args := []interface{}{tenantID}
+
+	if filter.CategoryID != nil {
+		args = append(args, *filter.CategoryID)
+		conditions = append(conditions, fmt.Sprintf(`p.id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = $%d
+				UNION
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT pc.product_id FROM product_categories pc JOIN subtree s ON pc.category_id = s.id
+		)`, len(args)))
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags))
+		conditions = append(conditions, fmt.Sprintf("p.tags @> $%d::TEXT[]", len(args)))
+	}
+
+	// Sort keys to keep the generated query stable
+	keys := make([]string, 0, len(filter.Attributes))
+	for key := range filter.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, key, pq.Array(filter.Attributes[key]))
+		conditions = append(conditions, fmt.Sprintf("p.attributes ->> $%d = ANY($%d::TEXT[])", len(args)-1, len(args)))
+	}
+
+	if filter.InStock != nil {
+		if *filter.InStock {
+			conditions = append(conditions, inStock)
+		} else {
+			conditions = append(conditions, "NOT "+inStock)
+		}
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
 // Update updates an existing product.
 func (r *Repository) Update(ctx context.Context, tx *sqlx.Tx, id int64, params models.UpdateProductParams) (*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.Update")
+	defer span.End()
+
 	query := `
 		UPDATE products
-		SET name = $1, description = $2, price = $3, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $4
-		RETURNING id, name, description, price, created_at, updated_at
+		SET name = $1, description = $2, price = $3,
+			currency = COALESCE(NULLIF($4, ''), currency),
+			tags = COALESCE($5::TEXT[], tags),
+			attributes = COALESCE($6::JSONB, attributes),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7 AND tenant_id = $8
+		RETURNING id, name, description, price, currency, tags, attributes, created_at, updated_at
 	`
 
 	var product models.Product
-	err := tx.QueryRowxContext(ctx, query, params.Name, params.Description, params.Price, id).
+	err := tx.QueryRowxContext(ctx, query, params.Name, params.Description, params.Price, params.Currency, pq.Array(params.Tags), params.Attributes, id, tenant.ID(ctx)).
 		StructScan(&product)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -123,11 +271,44 @@ func (r *Repository) Update(ctx context.Context, tx *sqlx.Tx, id int64, params m
 	return &product, nil
 }
 
+// ApplyScheduledPrices stores the price in effect for every product whose stored price is outdated
+// because a scheduled price change started or ended. It returns the applied changes.
+// Reads compute the current price on their own, so a late run only delays the stored value and events.
+// It applies the changes of all tenants, so tx must be started with BeginAllTenantsTx.
+func (r *Repository) ApplyScheduledPrices(ctx context.Context, tx *sqlx.Tx) ([]models.PriceChange, error) {
+	ctx, span := tracing.Start(ctx, "products.Repository.ApplyScheduledPrices")
+	defer span.End()
+
+	query := `
+		WITH due AS (
+			SELECT p.id, p.price AS old_price, ` + currentPrice + ` AS new_price
+			FROM products p
+			FOR UPDATE
+		)
+		UPDATE products p
+		SET price = due.new_price, updated_at = CURRENT_TIMESTAMP
+		FROM due
+		WHERE p.id = due.id AND due.new_price <> due.old_price
+		RETURNING p.id AS product_id, p.tenant_id, p.currency, due.old_price, due.new_price
+	`
+
+	var changes []models.PriceChange
+	err := tx.SelectContext(ctx, &changes, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply scheduled prices: %w", err)
+	}
+
+	return changes, nil
+}
+
 // Delete removes a product from the database.
 func (r *Repository) Delete(ctx context.Context, tx *sqlx.Tx, id int64) error {
-	query := `DELETE FROM products WHERE id = $1`
+	ctx, span := tracing.Start(ctx, "products.Repository.Delete")
+	defer span.End()
+
+	query := `DELETE FROM products WHERE id = $1 AND tenant_id = $2`
 
-	result, err := tx.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id, tenant.ID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
@@ -146,10 +327,13 @@ func (r *Repository) Delete(ctx context.Context, tx *sqlx.Tx, id int64) error {
 
 // Exists checks if a product with the given ID exists.
 func (r *Repository) Exists(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1)`
+	ctx, span := tracing.Start(ctx, "products.Repository.Exists")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND tenant_id = $2)`
 
 	var exists bool
-	err := tx.QueryRowxContext(ctx, query, id).Scan(&exists)
+	err := tx.QueryRowxContext(ctx, query, id, tenant.ID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check product existence: %w", err)
 	}
@@ -3,9 +3,12 @@ package products_test
 import (
 	"context"
 	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
 	"product_review_hub/internal/repository/products"
+	"product_review_hub/internal/tenant"
 	"product_review_hub/internal/testutil"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,7 +29,7 @@ func TestRepository_Create(t *testing.T) {
 			params: models.CreateProductParams{
 				Name:        "Test Product",
 				Description: testutil.StringPtr("Test Description"),
-				Price:       99.99,
+				Price:       money.MustParse("99.99"),
 			},
 			wantErr: false,
 		},
@@ -35,7 +38,7 @@ func TestRepository_Create(t *testing.T) {
 			params: models.CreateProductParams{
 				Name:        "Product Without Description",
 				Description: nil,
-				Price:       49.99,
+				Price:       money.MustParse("49.99"),
 			},
 			wantErr: false,
 		},
@@ -126,6 +129,63 @@ func TestRepository_GetByID(t *testing.T) {
 		require.NoError(t, repo.CommitTx(tx))
 	})
 
+	t.Run("variant reviews aggregate into product rating", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		variantID := tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+		tdb.CreateTestReview(t, productID, "User1", "Last1", 5, nil)
+		variantReviewID := tdb.CreateTestReview(t, productID, "User2", "Last2", 2, nil)
+		tdb.MustExec(t, `UPDATE reviews SET variant_id = $1 WHERE id = $2`, variantID, variantReviewID)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		product, err := repo.GetByID(ctx, tx, productID)
+		require.NoError(t, err)
+
+		require.NotNil(t, product.AverageRating)
+		assert.Equal(t, 3.5, *product.AverageRating)
+	})
+
+	t.Run("scheduled price in effect overrides stored price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		now := time.Now()
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		tdb.CreateTestPrice(t, productID, 99.99, now.Add(-48*time.Hour), nil)
+		tdb.CreateTestPrice(t, productID, 79.99, now.Add(-time.Hour), testutil.TimePtr(now.Add(time.Hour)))
+		tdb.CreateTestPrice(t, productID, 59.99, now.Add(time.Hour), nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		product, err := repo.GetByID(ctx, tx, productID)
+		require.NoError(t, err)
+
+		assert.Equal(t, money.MustParse("79.99"), product.Price)
+	})
+
+	t.Run("ended scheduled price falls back to previous price", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		now := time.Now()
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 79.99)
+		tdb.CreateTestPrice(t, productID, 99.99, now.Add(-48*time.Hour), nil)
+		tdb.CreateTestPrice(t, productID, 79.99, now.Add(-2*time.Hour), testutil.TimePtr(now.Add(-time.Hour)))
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		product, err := repo.GetByID(ctx, tx, productID)
+		require.NoError(t, err)
+
+		assert.Equal(t, money.MustParse("99.99"), product.Price)
+	})
+
 	t.Run("get non-existing product", func(t *testing.T) {
 		tdb.Cleanup(t)
 
@@ -210,6 +270,200 @@ func TestRepository_List(t *testing.T) {
 	})
 }
 
+func TestRepository_ListByCategory(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := products.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("list products of category and its descendants", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		rootID := tdb.CreateTestCategory(t, "Electronics", nil)
+		childID := tdb.CreateTestCategory(t, "Audio", testutil.Int64Ptr(rootID))
+		otherID := tdb.CreateTestCategory(t, "Books", nil)
+
+		inRoot := tdb.CreateTestProduct(t, "TV", nil, 10)
+		inChild := tdb.CreateTestProduct(t, "Speaker", nil, 10)
+		inOther := tdb.CreateTestProduct(t, "Novel", nil, 10)
+		tdb.CreateTestProduct(t, "Uncategorized", nil, 10)
+		tdb.AssignTestProductCategory(t, inRoot, rootID)
+		tdb.AssignTestProductCategory(t, inChild, childID)
+		tdb.AssignTestProductCategory(t, inOther, otherID)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productsList, err := repo.List(ctx, tx, models.ListProductsParams{Limit: 10, ProductFilter: models.ProductFilter{CategoryID: &rootID}})
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(productsList))
+		for _, p := range productsList {
+			ids = append(ids, p.ID)
+		}
+		assert.ElementsMatch(t, []int64{inRoot, inChild}, ids)
+
+		productsList, err = repo.List(ctx, tx, models.ListProductsParams{Limit: 10, ProductFilter: models.ProductFilter{CategoryID: &childID}})
+		require.NoError(t, err)
+		require.Len(t, productsList, 1)
+		assert.Equal(t, inChild, productsList[0].ID)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+}
+
+func TestRepository_ListByStock(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := products.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("list products with and without available stock", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		inStock := tdb.CreateTestProduct(t, "In Stock", nil, 10)
+		fullyReserved := tdb.CreateTestProduct(t, "Fully Reserved", nil, 10)
+		noStock := tdb.CreateTestProduct(t, "No Stock", nil, 10)
+		tdb.CreateTestStock(t, inStock, 5, 2)
+		tdb.CreateTestStock(t, fullyReserved, 3, 3)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		available := true
+		productsList, err := repo.List(ctx, tx, models.ListProductsParams{Limit: 10, ProductFilter: models.ProductFilter{InStock: &available}})
+		require.NoError(t, err)
+		require.Len(t, productsList, 1)
+		assert.Equal(t, inStock, productsList[0].ID)
+		assert.True(t, productsList[0].InStock)
+
+		available = false
+		productsList, err = repo.List(ctx, tx, models.ListProductsParams{Limit: 10, ProductFilter: models.ProductFilter{InStock: &available}})
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(productsList))
+		for _, p := range productsList {
+			assert.False(t, p.InStock)
+			ids = append(ids, p.ID)
+		}
+		assert.ElementsMatch(t, []int64{fullyReserved, noStock}, ids)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+}
+
+func TestRepository_ListByTagsAndAttributes(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := products.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	tdb.Cleanup(t)
+
+	redWireless := tdb.CreateTestProductWithAttributes(t, "Red Wireless", []string{"wireless", "sale"}, `{"color": "red", "battery": 30}`)
+	blueWireless := tdb.CreateTestProductWithAttributes(t, "Blue Wireless", []string{"wireless"}, `{"color": "blue", "battery": 20}`)
+	redWired := tdb.CreateTestProductWithAttributes(t, "Red Wired", []string{"wired"}, `{"color": "red"}`)
+
+	tests := []struct {
+		name    string
+		filter  models.ProductFilter
+		wantIDs []int64
+	}{
+		{
+			name:    "single tag",
+			filter:  models.ProductFilter{Tags: []string{"wireless"}},
+			wantIDs: []int64{redWireless, blueWireless},
+		},
+		{
+			name:    "all tags must match",
+			filter:  models.ProductFilter{Tags: []string{"wireless", "sale"}},
+			wantIDs: []int64{redWireless},
+		},
+		{
+			name:    "attribute value",
+			filter:  models.ProductFilter{Attributes: map[string][]string{"color": {"red"}}},
+			wantIDs: []int64{redWireless, redWired},
+		},
+		{
+			name:    "any of attribute values",
+			filter:  models.ProductFilter{Attributes: map[string][]string{"color": {"red", "blue"}}},
+			wantIDs: []int64{redWireless, blueWireless, redWired},
+		},
+		{
+			name:    "numeric attribute value",
+			filter:  models.ProductFilter{Attributes: map[string][]string{"battery": {"20"}}},
+			wantIDs: []int64{blueWireless},
+		},
+		{
+			name: "tags and attributes combined",
+			filter: models.ProductFilter{
+				Tags:       []string{"wireless"},
+				Attributes: map[string][]string{"color": {"red"}},
+			},
+			wantIDs: []int64{redWireless},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := repo.BeginTx(ctx)
+			require.NoError(t, err)
+			defer tx.Rollback()
+
+			productsList, err := repo.List(ctx, tx, models.ListProductsParams{Limit: 10, ProductFilter: tt.filter})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(productsList))
+			for _, p := range productsList {
+				ids = append(ids, p.ID)
+			}
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestRepository_Facets(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := products.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("count tags and attribute values for filter", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tdb.CreateTestProductWithAttributes(t, "Red Wireless", []string{"wireless", "sale"}, `{"color": "red"}`)
+		tdb.CreateTestProductWithAttributes(t, "Blue Wireless", []string{"wireless"}, `{"color": "blue", "waterproof": true}`)
+		tdb.CreateTestProductWithAttributes(t, "Red Wired", []string{"wired"}, `{"color": "red"}`)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		facets, err := repo.Facets(ctx, tx, models.ProductFilter{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.FacetCount{
+			{Value: "wireless", Count: 2},
+			{Value: "sale", Count: 1},
+			{Value: "wired", Count: 1},
+		}, facets.Tags)
+		assert.Equal(t, []models.AttributeFacetCount{
+			{Key: "color", FacetCount: models.FacetCount{Value: "red", Count: 2}},
+			{Key: "color", FacetCount: models.FacetCount{Value: "blue", Count: 1}},
+			{Key: "waterproof", FacetCount: models.FacetCount{Value: "true", Count: 1}},
+		}, facets.Attributes)
+
+		facets, err = repo.Facets(ctx, tx, models.ProductFilter{Tags: []string{"wireless"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.FacetCount{
+			{Value: "wireless", Count: 2},
+			{Value: "sale", Count: 1},
+		}, facets.Tags)
+		assert.Equal(t, []models.AttributeFacetCount{
+			{Key: "color", FacetCount: models.FacetCount{Value: "blue", Count: 1}},
+			{Key: "color", FacetCount: models.FacetCount{Value: "red", Count: 1}},
+			{Key: "waterproof", FacetCount: models.FacetCount{Value: "true", Count: 1}},
+		}, facets.Attributes)
+	})
+}
+
 func TestRepository_Update(t *testing.T) {
 	tdb := testutil.SetupTestDB(t)
 	repo := products.NewRepository(tdb.DB)
@@ -228,14 +482,44 @@ func TestRepository_Update(t *testing.T) {
 		updated, err := repo.Update(ctx, tx, productID, models.UpdateProductParams{
 			Name:        "Updated Name",
 			Description: &newDesc,
-			Price:       75.00,
+			Price:       money.MustParse("75.00"),
 		})
 		require.NoError(t, err)
 
 		assert.Equal(t, "Updated Name", updated.Name)
 		require.NotNil(t, updated.Description)
 		assert.Equal(t, newDesc, *updated.Description)
-		assert.Equal(t, 75.00, updated.Price)
+		assert.Equal(t, money.MustParse("75.00"), updated.Price)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("update keeps tags and attributes when omitted", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProductWithAttributes(t, "Headphones", []string{"wireless"}, `{"color": "red"}`)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		updated, err := repo.Update(ctx, tx, productID, models.UpdateProductParams{
+			Name:  "Headphones",
+			Price: money.MustParse("20.00"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wireless"}, []string(updated.Tags))
+		assert.Equal(t, models.Attributes{"color": "red"}, updated.Attributes)
+
+		updated, err = repo.Update(ctx, tx, productID, models.UpdateProductParams{
+			Name:       "Headphones",
+			Price:      money.MustParse("20.00"),
+			Tags:       []string{},
+			Attributes: models.Attributes{"color": "blue", "battery": float64(30)},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Tags)
+		assert.Equal(t, models.Attributes{"color": "blue", "battery": float64(30)}, updated.Attributes)
 
 		require.NoError(t, repo.CommitTx(tx))
 	})
@@ -249,13 +533,67 @@ func TestRepository_Update(t *testing.T) {
 
 		_, err = repo.Update(ctx, tx, 999999, models.UpdateProductParams{
 			Name:  "Name",
-			Price: 10.00,
+			Price: money.MustParse("10.00"),
 		})
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, products.ErrNotFound)
 	})
 }
 
+func TestRepository_ApplyScheduledPrices(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := products.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("apply started and ended price changes", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		now := time.Now()
+		startedID := tdb.CreateTestProduct(t, "Started", nil, 99.99)
+		tdb.CreateTestPrice(t, startedID, 99.99, now.Add(-48*time.Hour), nil)
+		tdb.CreateTestPrice(t, startedID, 79.99, now.Add(-time.Minute), testutil.TimePtr(now.Add(time.Hour)))
+
+		endedID := tdb.CreateTestProduct(t, "Ended", nil, 15)
+		tdb.CreateTestPrice(t, endedID, 20, now.Add(-48*time.Hour), nil)
+		tdb.CreateTestPrice(t, endedID, 15, now.Add(-2*time.Hour), testutil.TimePtr(now.Add(-time.Minute)))
+
+		unchangedID := tdb.CreateTestProduct(t, "Unchanged", nil, 10)
+		tdb.CreateTestPrice(t, unchangedID, 10, now.Add(-48*time.Hour), nil)
+		tdb.CreateTestPrice(t, unchangedID, 5, now.Add(time.Hour), nil)
+
+		tx, err := repo.BeginAllTenantsTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		changes, err := repo.ApplyScheduledPrices(ctx, tx)
+		require.NoError(t, err)
+		require.NoError(t, repo.CommitTx(tx))
+
+		assert.ElementsMatch(t, []models.PriceChange{
+			{ProductID: startedID, TenantID: tenant.Default, Currency: money.DefaultCurrency, OldPrice: money.MustParse("99.99"), NewPrice: money.MustParse("79.99")},
+			{ProductID: endedID, TenantID: tenant.Default, Currency: money.DefaultCurrency, OldPrice: money.MustParse("15"), NewPrice: money.MustParse("20")},
+		}, changes)
+
+		var stored money.Amount
+		require.NoError(t, tdb.DB.Get(&stored, `SELECT price FROM products WHERE id = $1`, startedID))
+		assert.Equal(t, money.MustParse("79.99"), stored)
+	})
+
+	t.Run("nothing to apply", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tdb.CreateTestProduct(t, "Test Product", nil, 10)
+
+		tx, err := repo.BeginAllTenantsTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		changes, err := repo.ApplyScheduledPrices(ctx, tx)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
 func TestRepository_Delete(t *testing.T) {
 	tdb := testutil.SetupTestDB(t)
 	repo := products.NewRepository(tdb.DB)
@@ -354,3 +692,85 @@ func TestRepository_Exists(t *testing.T) {
 		require.NoError(t, repo.CommitTx(tx))
 	})
 }
+
+func TestRepository_TenantIsolation(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := products.NewRepository(tdb.DB)
+	shopA := tenant.WithID(context.Background(), "shop-a")
+	shopB := tenant.WithID(context.Background(), "shop-b")
+
+	t.Run("hide products of other tenants", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestTenantProduct(t, "shop-a", "Shop A Product")
+		tdb.CreateTestTenantProduct(t, "shop-b", "Shop B Product")
+
+		tx, err := repo.BeginTx(shopB)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.GetByID(shopB, tx, productID)
+		assert.ErrorIs(t, err, products.ErrNotFound)
+
+		exists, err := repo.Exists(shopB, tx, productID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		list, err := repo.List(shopB, tx, models.ListProductsParams{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "Shop B Product", list[0].Name)
+
+		_, err = repo.Update(shopB, tx, productID, models.UpdateProductParams{Name: "Hijacked", Price: money.MustParse("1")})
+		assert.ErrorIs(t, err, products.ErrNotFound)
+
+		assert.ErrorIs(t, repo.Delete(shopB, tx, productID), products.ErrNotFound)
+	})
+
+	t.Run("see products of own tenant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestTenantProduct(t, "shop-a", "Shop A Product")
+
+		tx, err := repo.BeginTx(shopA)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		product, err := repo.GetByID(shopA, tx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, "Shop A Product", product.Name)
+	})
+
+	t.Run("create product in tenant of context", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(shopA)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		product, err := repo.Create(shopA, tx, models.CreateProductParams{Name: "New Product", Price: money.MustParse("5")})
+		require.NoError(t, err)
+		require.NoError(t, repo.CommitTx(tx))
+
+		var tenantID string
+		require.NoError(t, tdb.DB.Get(&tenantID, `SELECT tenant_id FROM products WHERE id = $1`, product.ID))
+		assert.Equal(t, "shop-a", tenantID)
+	})
+
+	t.Run("default to default tenant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tdb.CreateTestProduct(t, "Default Product", nil, 10)
+		tdb.CreateTestTenantProduct(t, "shop-a", "Shop A Product")
+
+		ctx := context.Background()
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		list, err := repo.List(ctx, tx, models.ListProductsParams{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "Default Product", list[0].Name)
+	})
+}
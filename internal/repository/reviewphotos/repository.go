@@ -0,0 +1,145 @@
+// Package reviewphotos provides repository for managing review photo metadata in the database.
+package reviewphotos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Common errors.
+var (
+	ErrNotFound       = errors.New("review photo not found")
+	ErrReviewNotFound = errors.New("review not found")
+	ErrTooManyPhotos  = errors.New("review has the maximum number of photos")
+)
+
+const photoColumns = `id, review_id, blob_key, thumbnail_key, content_type, size_bytes, width, height, created_at`
+
+// Repository provides methods for managing review photo metadata in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new review photos repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "reviewphotos.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// Create records a new photo of a review unless the review already has maxPhotos photos.
+func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateReviewPhotoParams, maxPhotos int) (*models.ReviewPhoto, error) {
+	ctx, span := tracing.Start(ctx, "reviewphotos.Repository.Create")
+	defer span.End()
+
+	// Lock the review so concurrent uploads cannot exceed the limit
+	var reviewID int64
+	err := tx.QueryRowxContext(ctx, `SELECT id FROM reviews WHERE id = $1 FOR UPDATE`, params.ReviewID).Scan(&reviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to lock review: %w", err)
+	}
+
+	var count int
+	err = tx.QueryRowxContext(ctx, `SELECT COUNT(*) FROM review_photos WHERE review_id = $1`, params.ReviewID).Scan(&count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count review photos: %w", err)
+	}
+	if count >= maxPhotos {
+		return nil, ErrTooManyPhotos
+	}
+
+	query := `
+		INSERT INTO review_photos (review_id, blob_key, thumbnail_key, content_type, size_bytes, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + photoColumns
+
+	var photo models.ReviewPhoto
+	err = tx.QueryRowxContext(ctx, query, params.ReviewID, params.BlobKey, params.ThumbnailKey, params.ContentType,
+		params.SizeBytes, params.Width, params.Height).StructScan(&photo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review photo: %w", err)
+	}
+
+	return &photo, nil
+}
+
+// ListByReviewID retrieves the photos of a review in upload order.
+func (r *Repository) ListByReviewID(ctx context.Context, tx *sqlx.Tx, reviewID int64) ([]models.ReviewPhoto, error) {
+	ctx, span := tracing.Start(ctx, "reviewphotos.Repository.ListByReviewID")
+	defer span.End()
+
+	query := `SELECT ` + photoColumns + ` FROM review_photos WHERE review_id = $1 ORDER BY id`
+
+	var photos []models.ReviewPhoto
+	err := tx.SelectContext(ctx, &photos, query, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// ListByReviewIDs retrieves the photos of several reviews in upload order, keyed by review ID.
+func (r *Repository) ListByReviewIDs(ctx context.Context, tx *sqlx.Tx, reviewIDs []int64) (map[int64][]models.ReviewPhoto, error) {
+	ctx, span := tracing.Start(ctx, "reviewphotos.Repository.ListByReviewIDs")
+	defer span.End()
+
+	result := make(map[int64][]models.ReviewPhoto, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	query := `SELECT ` + photoColumns + ` FROM review_photos WHERE review_id = ANY($1::BIGINT[]) ORDER BY review_id, id`
+
+	var photos []models.ReviewPhoto
+	err := tx.SelectContext(ctx, &photos, query, pq.Array(reviewIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review photos: %w", err)
+	}
+
+	for _, photo := range photos {
+		result[photo.ReviewID] = append(result[photo.ReviewID], photo)
+	}
+
+	return result, nil
+}
+
+// DeleteByIDAndReviewID removes a photo of a review and returns its metadata so the files can be removed.
+func (r *Repository) DeleteByIDAndReviewID(ctx context.Context, tx *sqlx.Tx, id, reviewID int64) (*models.ReviewPhoto, error) {
+	ctx, span := tracing.Start(ctx, "reviewphotos.Repository.DeleteByIDAndReviewID")
+	defer span.End()
+
+	query := `DELETE FROM review_photos WHERE id = $1 AND review_id = $2 RETURNING ` + photoColumns
+
+	var photo models.ReviewPhoto
+	err := tx.QueryRowxContext(ctx, query, id, reviewID).StructScan(&photo)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to delete review photo: %w", err)
+	}
+
+	return &photo, nil
+}
@@ -0,0 +1,153 @@
+package reviewphotos_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/reviewphotos"
+	"product_review_hub/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func photoParams(reviewID int64, blobKey string) models.CreateReviewPhotoParams {
+	return models.CreateReviewPhotoParams{
+		ReviewID:     reviewID,
+		BlobKey:      blobKey,
+		ThumbnailKey: blobKey + "_thumb",
+		ContentType:  "image/jpeg",
+		SizeBytes:    1024,
+		Width:        800,
+		Height:       600,
+	}
+}
+
+func TestRepository_Create(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviewphotos.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("record photos up to the limit", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		reviewID := tdb.CreateTestReview(t, productID, "John", "Doe", 5, nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		photo, err := repo.Create(ctx, tx, photoParams(reviewID, "reviews/1/a.jpg"), 2)
+		require.NoError(t, err)
+		assert.NotZero(t, photo.ID)
+		assert.Equal(t, reviewID, photo.ReviewID)
+		assert.Equal(t, "reviews/1/a.jpg", photo.BlobKey)
+		assert.Equal(t, int64(1024), photo.SizeBytes)
+
+		_, err = repo.Create(ctx, tx, photoParams(reviewID, "reviews/1/b.jpg"), 2)
+		require.NoError(t, err)
+
+		_, err = repo.Create(ctx, tx, photoParams(reviewID, "reviews/1/c.jpg"), 2)
+		assert.ErrorIs(t, err, reviewphotos.ErrTooManyPhotos)
+	})
+
+	t.Run("return error for non-existent review", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Create(ctx, tx, photoParams(99999, "reviews/99999/a.jpg"), 5)
+		assert.ErrorIs(t, err, reviewphotos.ErrReviewNotFound)
+	})
+}
+
+func TestRepository_ListByReviewIDs(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviewphotos.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("group photos by review in upload order", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		review1 := tdb.CreateTestReview(t, productID, "John", "Doe", 5, nil)
+		review2 := tdb.CreateTestReview(t, productID, "Jane", "Doe", 4, nil)
+		review3 := tdb.CreateTestReview(t, productID, "Jim", "Doe", 3, nil)
+		first := tdb.CreateTestReviewPhoto(t, review1, "r1/a.jpg")
+		second := tdb.CreateTestReviewPhoto(t, review1, "r1/b.jpg")
+		other := tdb.CreateTestReviewPhoto(t, review2, "r2/a.jpg")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		result, err := repo.ListByReviewIDs(ctx, tx, []int64{review1, review2, review3})
+		require.NoError(t, err)
+
+		require.Len(t, result[review1], 2)
+		assert.Equal(t, first, result[review1][0].ID)
+		assert.Equal(t, second, result[review1][1].ID)
+		require.Len(t, result[review2], 1)
+		assert.Equal(t, other, result[review2][0].ID)
+		assert.Empty(t, result[review3])
+
+		photos, err := repo.ListByReviewID(ctx, tx, review1)
+		require.NoError(t, err)
+		assert.Len(t, photos, 2)
+	})
+
+	t.Run("return empty map for no reviews", func(t *testing.T) {
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		result, err := repo.ListByReviewIDs(ctx, tx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestRepository_DeleteByIDAndReviewID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviewphotos.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("delete photo and return its keys", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		reviewID := tdb.CreateTestReview(t, productID, "John", "Doe", 5, nil)
+		photoID := tdb.CreateTestReviewPhoto(t, reviewID, "r1/a.jpg")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		deleted, err := repo.DeleteByIDAndReviewID(ctx, tx, photoID, reviewID)
+		require.NoError(t, err)
+		assert.Equal(t, "r1/a.jpg", deleted.BlobKey)
+		assert.Equal(t, "r1/a.jpg_thumb", deleted.ThumbnailKey)
+
+		_, err = repo.DeleteByIDAndReviewID(ctx, tx, photoID, reviewID)
+		assert.ErrorIs(t, err, reviewphotos.ErrNotFound)
+	})
+
+	t.Run("return error for photo of another review", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		review1 := tdb.CreateTestReview(t, productID, "John", "Doe", 5, nil)
+		review2 := tdb.CreateTestReview(t, productID, "Jane", "Doe", 4, nil)
+		photoID := tdb.CreateTestReviewPhoto(t, review1, "r1/a.jpg")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.DeleteByIDAndReviewID(ctx, tx, photoID, review2)
+		assert.ErrorIs(t, err, reviewphotos.ErrNotFound)
+	})
+}
@@ -7,17 +7,22 @@ import (
 	"errors"
 	"fmt"
 
+	"product_review_hub/internal/database"
 	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/tracing"
 
 	"github.com/jmoiron/sqlx"
 )
 
 // Common errors.
 var (
-	ErrNotFound = errors.New("review not found")
+	ErrNotFound  = errors.New("review not found")
+	ErrDuplicate = errors.New("user has already reviewed this product")
 )
 
 // Repository provides methods for managing reviews in the database.
+// Every method is scoped to the tenant of its context.
 type Repository struct {
 	db *sqlx.DB
 }
@@ -28,25 +33,34 @@ func NewRepository(db *sqlx.DB) *Repository {
 }
 
 func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
-	return r.db.BeginTxx(ctx, nil)
+	ctx, span := tracing.Start(ctx, "reviews.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
 }
 
 func (r *Repository) CommitTx(tx *sqlx.Tx) error {
 	return tx.Commit()
 }
 
-// Create inserts a new review into the database.
+// Create inserts a new review of the tenant of ctx into the database.
 func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateReviewParams) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.Create")
+	defer span.End()
+
 	query := `
-		INSERT INTO reviews (product_id, first_name, last_name, rating, comment)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, product_id, first_name, last_name, rating, comment, created_at, updated_at
+		INSERT INTO reviews (product_id, variant_id, user_id, first_name, last_name, rating, comment, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
 	`
 
 	var review models.Review
-	err := tx.QueryRowxContext(ctx, query, params.ProductID, params.FirstName, params.LastName, params.Rating, params.Comment).
+	err := tx.QueryRowxContext(ctx, query, params.ProductID, params.VariantID, params.UserID, params.FirstName, params.LastName, params.Rating, params.Comment, tenant.ID(ctx)).
 		StructScan(&review)
 	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, ErrDuplicate
+		}
 		return nil, fmt.Errorf("failed to create review: %w", err)
 	}
 
@@ -55,14 +69,17 @@ func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.Crea
 
 // GetByID retrieves a review by its ID.
 func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.GetByID")
+	defer span.End()
+
 	query := `
-		SELECT id, product_id, first_name, last_name, rating, comment, created_at, updated_at
+		SELECT id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
 		FROM reviews
-		WHERE id = $1
+		WHERE id = $1 AND tenant_id = $2
 	`
 
 	var review models.Review
-	err := tx.QueryRowxContext(ctx, query, id).StructScan(&review)
+	err := tx.QueryRowxContext(ctx, query, id, tenant.ID(ctx)).StructScan(&review)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -75,14 +92,17 @@ func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*model
 
 // GetByIDAndProductID retrieves a review by its ID and product ID.
 func (r *Repository) GetByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.GetByIDAndProductID")
+	defer span.End()
+
 	query := `
-		SELECT id, product_id, first_name, last_name, rating, comment, created_at, updated_at
+		SELECT id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
 		FROM reviews
-		WHERE id = $1 AND product_id = $2
+		WHERE id = $1 AND product_id = $2 AND tenant_id = $3
 	`
 
 	var review models.Review
-	err := tx.QueryRowxContext(ctx, query, id, productID).StructScan(&review)
+	err := tx.QueryRowxContext(ctx, query, id, productID, tenant.ID(ctx)).StructScan(&review)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -93,18 +113,44 @@ func (r *Repository) GetByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, p
 	return &review, nil
 }
 
-// ListByProductID retrieves all reviews for a specific product.
+// ListByProductID retrieves all reviews for a specific product, optionally only those with or without photos.
 func (r *Repository) ListByProductID(ctx context.Context, tx *sqlx.Tx, params models.ListReviewsParams) ([]models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.ListByProductID")
+	defer span.End()
+
 	query := `
-		SELECT id, product_id, first_name, last_name, rating, comment, created_at, updated_at
+		SELECT id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
 		FROM reviews
-		WHERE product_id = $1
+		WHERE product_id = $1 AND tenant_id = $5
+			AND ($4::BOOLEAN IS NULL OR EXISTS (SELECT 1 FROM review_photos rp WHERE rp.review_id = reviews.id) = $4)
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
 	var reviews []models.Review
-	err := tx.SelectContext(ctx, &reviews, query, params.ProductID, params.Limit, params.Offset)
+	err := tx.SelectContext(ctx, &reviews, query, params.ProductID, params.Limit, params.Offset, params.HasMedia, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// ListByUserID retrieves the reviews written by a user in the tenant of ctx, newest first.
+func (r *Repository) ListByUserID(ctx context.Context, tx *sqlx.Tx, params models.ListUserReviewsParams) ([]models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.ListByUserID")
+	defer span.End()
+
+	query := `
+		SELECT id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
+		FROM reviews
+		WHERE user_id = $1 AND tenant_id = $4
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var reviews []models.Review
+	err := tx.SelectContext(ctx, &reviews, query, params.UserID, params.Limit, params.Offset, tenant.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list reviews: %w", err)
 	}
@@ -114,15 +160,18 @@ func (r *Repository) ListByProductID(ctx context.Context, tx *sqlx.Tx, params mo
 
 // Update updates an existing review.
 func (r *Repository) Update(ctx context.Context, tx *sqlx.Tx, id int64, params models.UpdateReviewParams) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.Update")
+	defer span.End()
+
 	query := `
 		UPDATE reviews
-		SET first_name = $1, last_name = $2, rating = $3, comment = $4, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $5
-		RETURNING id, product_id, first_name, last_name, rating, comment, created_at, updated_at
+		SET first_name = $1, last_name = $2, rating = $3, comment = $4, variant_id = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6 AND tenant_id = $7
+		RETURNING id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
 	`
 
 	var review models.Review
-	err := tx.QueryRowxContext(ctx, query, params.FirstName, params.LastName, params.Rating, params.Comment, id).
+	err := tx.QueryRowxContext(ctx, query, params.FirstName, params.LastName, params.Rating, params.Comment, params.VariantID, id, tenant.ID(ctx)).
 		StructScan(&review)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -136,15 +185,18 @@ func (r *Repository) Update(ctx context.Context, tx *sqlx.Tx, id int64, params m
 
 // UpdateByIDAndProductID updates a review by its ID and product ID.
 func (r *Repository) UpdateByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64, params models.UpdateReviewParams) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "reviews.Repository.UpdateByIDAndProductID")
+	defer span.End()
+
 	query := `
 		UPDATE reviews
-		SET first_name = $1, last_name = $2, rating = $3, comment = $4, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $5 AND product_id = $6
-		RETURNING id, product_id, first_name, last_name, rating, comment, created_at, updated_at
+		SET first_name = $1, last_name = $2, rating = $3, comment = $4, variant_id = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6 AND product_id = $7 AND tenant_id = $8
+		RETURNING id, product_id, variant_id, user_id, first_name, last_name, rating, comment, created_at, updated_at
 	`
 
 	var review models.Review
-	err := tx.QueryRowxContext(ctx, query, params.FirstName, params.LastName, params.Rating, params.Comment, id, productID).
+	err := tx.QueryRowxContext(ctx, query, params.FirstName, params.LastName, params.Rating, params.Comment, params.VariantID, id, productID, tenant.ID(ctx)).
 		StructScan(&review)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -158,9 +210,12 @@ func (r *Repository) UpdateByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id
 
 // Delete removes a review from the database.
 func (r *Repository) Delete(ctx context.Context, tx *sqlx.Tx, id int64) error {
-	query := `DELETE FROM reviews WHERE id = $1`
+	ctx, span := tracing.Start(ctx, "reviews.Repository.Delete")
+	defer span.End()
+
+	query := `DELETE FROM reviews WHERE id = $1 AND tenant_id = $2`
 
-	result, err := tx.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id, tenant.ID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to delete review: %w", err)
 	}
@@ -179,9 +234,12 @@ func (r *Repository) Delete(ctx context.Context, tx *sqlx.Tx, id int64) error {
 
 // DeleteByIDAndProductID removes a review by its ID and product ID.
 func (r *Repository) DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) error {
-	query := `DELETE FROM reviews WHERE id = $1 AND product_id = $2`
+	ctx, span := tracing.Start(ctx, "reviews.Repository.DeleteByIDAndProductID")
+	defer span.End()
 
-	result, err := tx.ExecContext(ctx, query, id, productID)
+	query := `DELETE FROM reviews WHERE id = $1 AND product_id = $2 AND tenant_id = $3`
+
+	result, err := tx.ExecContext(ctx, query, id, productID, tenant.ID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to delete review: %w", err)
 	}
@@ -200,10 +258,13 @@ func (r *Repository) DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id
 
 // GetAverageRatingByProductID calculates the average rating for a product.
 func (r *Repository) GetAverageRatingByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) (*float64, error) {
-	query := `SELECT AVG(rating)::FLOAT FROM reviews WHERE product_id = $1`
+	ctx, span := tracing.Start(ctx, "reviews.Repository.GetAverageRatingByProductID")
+	defer span.End()
+
+	query := `SELECT AVG(rating)::FLOAT FROM reviews WHERE product_id = $1 AND tenant_id = $2`
 
 	var avgRating *float64
-	err := tx.QueryRowxContext(ctx, query, productID).Scan(&avgRating)
+	err := tx.QueryRowxContext(ctx, query, productID, tenant.ID(ctx)).Scan(&avgRating)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get average rating: %w", err)
 	}
@@ -213,10 +274,13 @@ func (r *Repository) GetAverageRatingByProductID(ctx context.Context, tx *sqlx.T
 
 // HasReviewsByProductID checks if a product has any reviews.
 func (r *Repository) HasReviewsByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM reviews WHERE product_id = $1)`
+	ctx, span := tracing.Start(ctx, "reviews.Repository.HasReviewsByProductID")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM reviews WHERE product_id = $1 AND tenant_id = $2)`
 
 	var exists bool
-	err := tx.QueryRowxContext(ctx, query, productID).Scan(&exists)
+	err := tx.QueryRowxContext(ctx, query, productID, tenant.ID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check reviews existence: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"product_review_hub/internal/models"
 	"product_review_hub/internal/repository/reviews"
+	"product_review_hub/internal/tenant"
 	"product_review_hub/internal/testutil"
 	"testing"
 
@@ -92,6 +93,157 @@ func TestRepository_Create(t *testing.T) {
 	})
 }
 
+func TestRepository_CreateWithVariant(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviews.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("create review for variant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		variantID := tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		review, err := repo.Create(ctx, tx, models.CreateReviewParams{
+			ProductID: productID,
+			VariantID: &variantID,
+			FirstName: "John",
+			LastName:  "Doe",
+			Rating:    4,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, review.VariantID)
+		assert.Equal(t, variantID, *review.VariantID)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("reject variant of another product", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		otherProductID := tdb.CreateTestProduct(t, "Hoodie", nil, 39.99)
+		otherVariantID := tdb.CreateTestVariant(t, otherProductID, "HOODIE-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Create(ctx, tx, models.CreateReviewParams{
+			ProductID: productID,
+			VariantID: &otherVariantID,
+			FirstName: "John",
+			LastName:  "Doe",
+			Rating:    4,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_CreateWithUser(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviews.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("create one review per user and product", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+		userID := tdb.CreateTestUser(t, "john@example.com")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		review, err := repo.Create(ctx, tx, models.CreateReviewParams{
+			ProductID: productID,
+			UserID:    &userID,
+			FirstName: "John",
+			LastName:  "Doe",
+			Rating:    5,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, review.UserID)
+		assert.Equal(t, userID, *review.UserID)
+
+		_, err = repo.Create(ctx, tx, models.CreateReviewParams{
+			ProductID: productID,
+			UserID:    &userID,
+			FirstName: "John",
+			LastName:  "Doe",
+			Rating:    1,
+		})
+		assert.ErrorIs(t, err, reviews.ErrDuplicate)
+	})
+
+	t.Run("allow reviews without user", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 99.99)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		for i := 0; i < 2; i++ {
+			review, err := repo.Create(ctx, tx, models.CreateReviewParams{
+				ProductID: productID,
+				FirstName: "Anonymous",
+				LastName:  "Reviewer",
+				Rating:    3,
+			})
+			require.NoError(t, err)
+			assert.Nil(t, review.UserID)
+		}
+	})
+}
+
+func TestRepository_ListByUserID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviews.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("list reviews of a user across products", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID1 := tdb.CreateTestProduct(t, "Product 1", nil, 99.99)
+		productID2 := tdb.CreateTestProduct(t, "Product 2", nil, 49.99)
+		userID := tdb.CreateTestUser(t, "john@example.com")
+		otherUserID := tdb.CreateTestUser(t, "jane@example.com")
+
+		first := tdb.CreateTestUserReview(t, productID1, userID, 5)
+		second := tdb.CreateTestUserReview(t, productID2, userID, 4)
+		tdb.CreateTestUserReview(t, productID1, otherUserID, 3)
+		tdb.CreateTestReview(t, productID2, "Anonymous", "Reviewer", 2, nil)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		reviewsList, err := repo.ListByUserID(ctx, tx, models.ListUserReviewsParams{
+			UserID: userID,
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		require.Len(t, reviewsList, 2)
+		assert.Equal(t, second, reviewsList[0].ID)
+		assert.Equal(t, first, reviewsList[1].ID)
+
+		reviewsList, err = repo.ListByUserID(ctx, tx, models.ListUserReviewsParams{
+			UserID: userID,
+			Limit:  1,
+			Offset: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, reviewsList, 1)
+		assert.Equal(t, first, reviewsList[0].ID)
+	})
+}
+
 func TestRepository_GetByID(t *testing.T) {
 	tdb := testutil.SetupTestDB(t)
 	repo := reviews.NewRepository(tdb.DB)
@@ -259,6 +411,41 @@ func TestRepository_ListByProductID(t *testing.T) {
 
 		require.NoError(t, repo.CommitTx(tx))
 	})
+
+	t.Run("list reviews by media", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Product 1", nil, 99.99)
+
+		withPhoto := tdb.CreateTestReview(t, productID, "User1", "First", 5, nil)
+		withoutPhoto := tdb.CreateTestReview(t, productID, "User2", "Second", 4, nil)
+		tdb.CreateTestReviewPhoto(t, withPhoto, "r1/a.jpg")
+		tdb.CreateTestReviewPhoto(t, withPhoto, "r1/b.jpg")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		hasMedia := true
+		reviewsList, err := repo.ListByProductID(ctx, tx, models.ListReviewsParams{
+			ProductID: productID,
+			HasMedia:  &hasMedia,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, reviewsList, 1)
+		assert.Equal(t, withPhoto, reviewsList[0].ID)
+
+		hasMedia = false
+		reviewsList, err = repo.ListByProductID(ctx, tx, models.ListReviewsParams{
+			ProductID: productID,
+			HasMedia:  &hasMedia,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, reviewsList, 1)
+		assert.Equal(t, withoutPhoto, reviewsList[0].ID)
+	})
 }
 
 func TestRepository_Update(t *testing.T) {
@@ -498,3 +685,83 @@ func TestRepository_GetAverageRatingByProductID(t *testing.T) {
 		require.NoError(t, repo.CommitTx(tx))
 	})
 }
+
+func TestRepository_TenantIsolation(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := reviews.NewRepository(tdb.DB)
+	shopA := tenant.WithID(context.Background(), "shop-a")
+	shopB := tenant.WithID(context.Background(), "shop-b")
+
+	t.Run("hide reviews of other tenants", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestTenantProduct(t, "shop-a", "Shop A Product")
+		reviewID := tdb.CreateTestTenantReview(t, "shop-a", productID, 5)
+
+		tx, err := repo.BeginTx(shopB)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.GetByID(shopB, tx, reviewID)
+		assert.ErrorIs(t, err, reviews.ErrNotFound)
+
+		_, err = repo.GetByIDAndProductID(shopB, tx, reviewID, productID)
+		assert.ErrorIs(t, err, reviews.ErrNotFound)
+
+		list, err := repo.ListByProductID(shopB, tx, models.ListReviewsParams{ProductID: productID, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, list)
+
+		rating, err := repo.GetAverageRatingByProductID(shopB, tx, productID)
+		require.NoError(t, err)
+		assert.Nil(t, rating)
+
+		has, err := repo.HasReviewsByProductID(shopB, tx, productID)
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		_, err = repo.Update(shopB, tx, reviewID, models.UpdateReviewParams{FirstName: "X", LastName: "Y", Rating: 1})
+		assert.ErrorIs(t, err, reviews.ErrNotFound)
+
+		assert.ErrorIs(t, repo.Delete(shopB, tx, reviewID), reviews.ErrNotFound)
+		assert.ErrorIs(t, repo.DeleteByIDAndProductID(shopB, tx, reviewID, productID), reviews.ErrNotFound)
+	})
+
+	t.Run("see reviews of own tenant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestTenantProduct(t, "shop-a", "Shop A Product")
+		tdb.CreateTestTenantReview(t, "shop-a", productID, 4)
+
+		tx, err := repo.BeginTx(shopA)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		list, err := repo.ListByProductID(shopA, tx, models.ListReviewsParams{ProductID: productID, Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		rating, err := repo.GetAverageRatingByProductID(shopA, tx, productID)
+		require.NoError(t, err)
+		require.NotNil(t, rating)
+		assert.Equal(t, 4.0, *rating)
+	})
+
+	t.Run("reject review of product of other tenant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestTenantProduct(t, "shop-a", "Shop A Product")
+
+		tx, err := repo.BeginTx(shopB)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Create(shopB, tx, models.CreateReviewParams{
+			ProductID: productID,
+			FirstName: "John",
+			LastName:  "Doe",
+			Rating:    5,
+		})
+		assert.Error(t, err)
+	})
+}
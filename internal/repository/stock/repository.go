@@ -0,0 +1,186 @@
+// Package stock provides repository for managing product stock levels in the database.
+package stock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Common errors.
+var (
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrInsufficientReserved = errors.New("insufficient reserved stock")
+)
+
+// Repository provides methods for managing product stock levels in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new stock repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// GetByProductID retrieves the stock level of a product.
+// A product without a stock row has no stock.
+func (r *Repository) GetByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.GetByProductID")
+	defer span.End()
+
+	query := `
+		SELECT product_id, quantity, reserved, low_stock_threshold, updated_at
+		FROM product_stock
+		WHERE product_id = $1
+	`
+
+	var stock models.ProductStock
+	err := tx.QueryRowxContext(ctx, query, productID).StructScan(&stock)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &models.ProductStock{ProductID: productID}, nil
+		}
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+
+	return &stock, nil
+}
+
+// Adjust changes the quantity on hand of a product by delta.
+// The quantity may not drop below the reserved quantity.
+func (r *Repository) Adjust(ctx context.Context, tx *sqlx.Tx, productID int64, delta int) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.Adjust")
+	defer span.End()
+
+	stock, err := r.lockByProductID(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stock.Quantity+delta < stock.Reserved {
+		return nil, ErrInsufficientStock
+	}
+
+	return r.update(ctx, tx, productID, stock.Quantity+delta, stock.Reserved)
+}
+
+// Reserve holds quantity units of the available stock of a product.
+func (r *Repository) Reserve(ctx context.Context, tx *sqlx.Tx, productID int64, quantity int) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.Reserve")
+	defer span.End()
+
+	stock, err := r.lockByProductID(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stock.Available() < quantity {
+		return nil, ErrInsufficientStock
+	}
+
+	return r.update(ctx, tx, productID, stock.Quantity, stock.Reserved+quantity)
+}
+
+// Release gives quantity reserved units of a product back to available stock.
+func (r *Repository) Release(ctx context.Context, tx *sqlx.Tx, productID int64, quantity int) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.Release")
+	defer span.End()
+
+	stock, err := r.lockByProductID(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stock.Reserved < quantity {
+		return nil, ErrInsufficientReserved
+	}
+
+	return r.update(ctx, tx, productID, stock.Quantity, stock.Reserved-quantity)
+}
+
+// SetLowStockThreshold sets the available quantity at or below which the stock of a product is low.
+func (r *Repository) SetLowStockThreshold(ctx context.Context, tx *sqlx.Tx, productID int64, threshold int) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.SetLowStockThreshold")
+	defer span.End()
+
+	query := `
+		INSERT INTO product_stock (product_id, low_stock_threshold)
+		VALUES ($1, $2)
+		ON CONFLICT (product_id) DO UPDATE
+		SET low_stock_threshold = EXCLUDED.low_stock_threshold, updated_at = CURRENT_TIMESTAMP
+		RETURNING product_id, quantity, reserved, low_stock_threshold, updated_at
+	`
+
+	var stock models.ProductStock
+	err := tx.QueryRowxContext(ctx, query, productID, threshold).StructScan(&stock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set low stock threshold: %w", err)
+	}
+
+	return &stock, nil
+}
+
+// lockByProductID locks the stock row of a product until the transaction ends, creating it when missing.
+func (r *Repository) lockByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.lockByProductID")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO product_stock (product_id) VALUES ($1) ON CONFLICT (product_id) DO NOTHING`, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock: %w", err)
+	}
+
+	query := `
+		SELECT product_id, quantity, reserved, low_stock_threshold, updated_at
+		FROM product_stock
+		WHERE product_id = $1
+		FOR UPDATE
+	`
+
+	var stock models.ProductStock
+	if err := tx.QueryRowxContext(ctx, query, productID).StructScan(&stock); err != nil {
+		return nil, fmt.Errorf("failed to lock stock: %w", err)
+	}
+
+	return &stock, nil
+}
+
+// update stores the quantity on hand and the reserved quantity of a locked stock row.
+func (r *Repository) update(ctx context.Context, tx *sqlx.Tx, productID int64, quantity, reserved int) (*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "stock.Repository.update")
+	defer span.End()
+
+	query := `
+		UPDATE product_stock
+		SET quantity = $2, reserved = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = $1
+		RETURNING product_id, quantity, reserved, low_stock_threshold, updated_at
+	`
+
+	var stock models.ProductStock
+	err := tx.QueryRowxContext(ctx, query, productID, quantity, reserved).StructScan(&stock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stock: %w", err)
+	}
+
+	return &stock, nil
+}
@@ -0,0 +1,182 @@
+package stock_test
+
+import (
+	"context"
+	"product_review_hub/internal/repository/stock"
+	"product_review_hub/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GetByProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := stock.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("product without stock", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productStock, err := repo.GetByProductID(ctx, tx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, productID, productStock.ProductID)
+		assert.Zero(t, productStock.Quantity)
+		assert.Zero(t, productStock.Available())
+		assert.Nil(t, productStock.UpdatedAt)
+	})
+
+	t.Run("product with stock", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		tdb.CreateTestStock(t, productID, 10, 4)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productStock, err := repo.GetByProductID(ctx, tx, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, productStock.Quantity)
+		assert.Equal(t, 4, productStock.Reserved)
+		assert.Equal(t, 6, productStock.Available())
+	})
+}
+
+func TestRepository_Adjust(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := stock.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("adjust creates stock", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productStock, err := repo.Adjust(ctx, tx, productID, 15)
+		require.NoError(t, err)
+		assert.Equal(t, 15, productStock.Quantity)
+
+		productStock, err = repo.Adjust(ctx, tx, productID, -5)
+		require.NoError(t, err)
+		assert.Equal(t, 10, productStock.Quantity)
+		assert.NotNil(t, productStock.UpdatedAt)
+	})
+
+	t.Run("adjust below reserved quantity", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		tdb.CreateTestStock(t, productID, 10, 4)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Adjust(ctx, tx, productID, -7)
+		assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+	})
+}
+
+func TestRepository_ReserveAndRelease(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := stock.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("reserve and release", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		tdb.CreateTestStock(t, productID, 5, 0)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productStock, err := repo.Reserve(ctx, tx, productID, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 3, productStock.Reserved)
+		assert.Equal(t, 2, productStock.Available())
+
+		_, err = repo.Reserve(ctx, tx, productID, 3)
+		assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+
+		productStock, err = repo.Release(ctx, tx, productID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, productStock.Reserved)
+
+		_, err = repo.Release(ctx, tx, productID, 2)
+		assert.ErrorIs(t, err, stock.ErrInsufficientReserved)
+	})
+
+	t.Run("concurrent reservations do not oversell", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		tdb.CreateTestStock(t, productID, 5, 0)
+
+		const attempts = 10
+		results := make(chan error, attempts)
+		for i := 0; i < attempts; i++ {
+			go func() {
+				tx, err := repo.BeginTx(ctx)
+				if err != nil {
+					results <- err
+					return
+				}
+				defer tx.Rollback()
+
+				if _, err := repo.Reserve(ctx, tx, productID, 1); err != nil {
+					results <- err
+					return
+				}
+				results <- repo.CommitTx(tx)
+			}()
+		}
+
+		reserved := 0
+		for i := 0; i < attempts; i++ {
+			err := <-results
+			if err == nil {
+				reserved++
+				continue
+			}
+			assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+		}
+		assert.Equal(t, 5, reserved)
+	})
+}
+
+func TestRepository_SetLowStockThreshold(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := stock.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("set threshold", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "Test Product", nil, 10)
+		tdb.CreateTestStock(t, productID, 5, 1)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		productStock, err := repo.SetLowStockThreshold(ctx, tx, productID, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 4, productStock.LowStockThreshold)
+		assert.Equal(t, 5, productStock.Quantity)
+		assert.True(t, productStock.IsLow())
+	})
+}
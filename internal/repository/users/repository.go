@@ -0,0 +1,101 @@
+// Package users provides repository for managing user accounts in the database.
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Common errors.
+var (
+	ErrNotFound       = errors.New("user not found")
+	ErrDuplicateEmail = errors.New("user with this email already exists")
+)
+
+const userColumns = `id, email, first_name, last_name, created_at`
+
+// Repository provides methods for managing user accounts in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new users repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "users.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// Create inserts a new user.
+func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateUserParams) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "users.Repository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO users (email, first_name, last_name)
+		VALUES ($1, $2, $3)
+		RETURNING ` + userColumns
+
+	var user models.User
+	err := tx.QueryRowxContext(ctx, query, params.Email, params.FirstName, params.LastName).StructScan(&user)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, ErrDuplicateEmail
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetByID retrieves a user by its ID.
+func (r *Repository) GetByID(ctx context.Context, tx *sqlx.Tx, id int64) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "users.Repository.GetByID")
+	defer span.End()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	var user models.User
+	err := tx.QueryRowxContext(ctx, query, id).StructScan(&user)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// Exists checks if a user exists.
+func (r *Repository) Exists(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "users.Repository.Exists")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
+
+	var exists bool
+	err := tx.QueryRowxContext(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	return exists, nil
+}
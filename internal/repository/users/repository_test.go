@@ -0,0 +1,96 @@
+package users_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/repository/users"
+	"product_review_hub/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := users.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("create user", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		user, err := repo.Create(ctx, tx, models.CreateUserParams{
+			Email:     "john@example.com",
+			FirstName: "John",
+			LastName:  "Doe",
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, user.ID)
+		assert.Equal(t, "john@example.com", user.Email)
+		assert.Equal(t, "John", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+		assert.NotZero(t, user.CreatedAt)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("reject duplicate email", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tdb.CreateTestUser(t, "john@example.com")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Create(ctx, tx, models.CreateUserParams{
+			Email:     "john@example.com",
+			FirstName: "John",
+			LastName:  "Doe",
+		})
+		assert.ErrorIs(t, err, users.ErrDuplicateEmail)
+	})
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := users.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("get existing user", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		userID := tdb.CreateTestUser(t, "john@example.com")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		user, err := repo.GetByID(ctx, tx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+
+		exists, err := repo.Exists(ctx, tx, userID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("get non-existing user", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.GetByID(ctx, tx, 999999)
+		assert.ErrorIs(t, err, users.ErrNotFound)
+
+		exists, err := repo.Exists(ctx, tx, 999999)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,211 @@
+// Package variants provides repository for managing product variants in the database.
+package variants
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product_review_hub/internal/database"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/tracing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Common errors.
+var (
+	ErrNotFound     = errors.New("variant not found")
+	ErrDuplicateSKU = errors.New("variant with this SKU already exists")
+)
+
+// Repository provides methods for managing product variants in the database.
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new variants repository.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.BeginTx")
+	defer span.End()
+
+	return database.BeginTx(ctx, r.db)
+}
+
+func (r *Repository) CommitTx(tx *sqlx.Tx) error {
+	return tx.Commit()
+}
+
+// Create inserts a new variant of the tenant of ctx into the database. SKUs are unique within
+// a tenant.
+func (r *Repository) Create(ctx context.Context, tx *sqlx.Tx, params models.CreateVariantParams) (*models.ProductVariant, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO product_variants (product_id, sku, options, price, active, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, product_id, sku, options, price, active, created_at, updated_at
+	`
+
+	var variant models.ProductVariant
+	err := tx.QueryRowxContext(ctx, query, params.ProductID, params.SKU, params.Options, params.Price, params.Active, tenant.ID(ctx)).
+		StructScan(&variant)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, ErrDuplicateSKU
+		}
+		return nil, fmt.Errorf("failed to create variant: %w", err)
+	}
+
+	return &variant, nil
+}
+
+// GetByIDAndProductID retrieves a variant by its ID and product ID.
+func (r *Repository) GetByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) (*models.ProductVariant, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.GetByIDAndProductID")
+	defer span.End()
+
+	query := `
+		SELECT id, product_id, sku, options, price, active, created_at, updated_at
+		FROM product_variants
+		WHERE id = $1 AND product_id = $2
+	`
+
+	var variant models.ProductVariant
+	err := tx.QueryRowxContext(ctx, query, id, productID).StructScan(&variant)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get variant: %w", err)
+	}
+
+	return &variant, nil
+}
+
+// ListByProductID retrieves all variants of a product.
+func (r *Repository) ListByProductID(ctx context.Context, tx *sqlx.Tx, productID int64) ([]models.ProductVariant, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.ListByProductID")
+	defer span.End()
+
+	query := `
+		SELECT id, product_id, sku, options, price, active, created_at, updated_at
+		FROM product_variants
+		WHERE product_id = $1
+		ORDER BY created_at, id
+	`
+
+	var variants []models.ProductVariant
+	err := tx.SelectContext(ctx, &variants, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+
+	return variants, nil
+}
+
+// ListByProductIDs retrieves variants of several products grouped by product ID.
+func (r *Repository) ListByProductIDs(ctx context.Context, tx *sqlx.Tx, productIDs []int64) (map[int64][]models.ProductVariant, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.ListByProductIDs")
+	defer span.End()
+
+	result := make(map[int64][]models.ProductVariant, len(productIDs))
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT id, product_id, sku, options, price, active, created_at, updated_at
+		FROM product_variants
+		WHERE product_id = ANY($1::BIGINT[])
+		ORDER BY product_id, created_at, id
+	`
+
+	var variants []models.ProductVariant
+	err := tx.SelectContext(ctx, &variants, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+
+	for _, v := range variants {
+		result[v.ProductID] = append(result[v.ProductID], v)
+	}
+
+	return result, nil
+}
+
+// UpdateByIDAndProductID updates a variant by its ID and product ID.
+func (r *Repository) UpdateByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64, params models.UpdateVariantParams) (*models.ProductVariant, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.UpdateByIDAndProductID")
+	defer span.End()
+
+	query := `
+		UPDATE product_variants
+		SET sku = $1, options = $2, price = $3, active = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5 AND product_id = $6
+		RETURNING id, product_id, sku, options, price, active, created_at, updated_at
+	`
+
+	var variant models.ProductVariant
+	err := tx.QueryRowxContext(ctx, query, params.SKU, params.Options, params.Price, params.Active, id, productID).
+		StructScan(&variant)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if database.IsUniqueViolation(err) {
+			return nil, ErrDuplicateSKU
+		}
+		return nil, fmt.Errorf("failed to update variant: %w", err)
+	}
+
+	return &variant, nil
+}
+
+// DeleteByIDAndProductID removes a variant by its ID and product ID.
+func (r *Repository) DeleteByIDAndProductID(ctx context.Context, tx *sqlx.Tx, id, productID int64) error {
+	ctx, span := tracing.Start(ctx, "variants.Repository.DeleteByIDAndProductID")
+	defer span.End()
+
+	query := `DELETE FROM product_variants WHERE id = $1 AND product_id = $2`
+
+	result, err := tx.ExecContext(ctx, query, id, productID)
+	if err != nil {
+		return fmt.Errorf("failed to delete variant: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// HasReviews checks if a variant has any reviews.
+func (r *Repository) HasReviews(ctx context.Context, tx *sqlx.Tx, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "variants.Repository.HasReviews")
+	defer span.End()
+
+	query := `SELECT EXISTS(SELECT 1 FROM reviews WHERE variant_id = $1)`
+
+	var exists bool
+	err := tx.QueryRowxContext(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check variant reviews existence: %w", err)
+	}
+
+	return exists, nil
+}
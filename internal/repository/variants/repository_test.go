@@ -0,0 +1,268 @@
+package variants_test
+
+import (
+	"context"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/repository/variants"
+	"product_review_hub/internal/tenant"
+	"product_review_hub/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := variants.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("create variant with all fields", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		price := money.MustParse("24.99")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		variant, err := repo.Create(ctx, tx, models.CreateVariantParams{
+			ProductID: productID,
+			SKU:       "TSHIRT-RED-M",
+			Options:   models.VariantOptions{"size": "M", "color": "red"},
+			Price:     &price,
+			Active:    true,
+		})
+		require.NoError(t, err)
+
+		assert.NotZero(t, variant.ID)
+		assert.Equal(t, productID, variant.ProductID)
+		assert.Equal(t, "TSHIRT-RED-M", variant.SKU)
+		assert.Equal(t, models.VariantOptions{"size": "M", "color": "red"}, variant.Options)
+		require.NotNil(t, variant.Price)
+		assert.Equal(t, price, *variant.Price)
+		assert.True(t, variant.Active)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("create variant without price override", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		variant, err := repo.Create(ctx, tx, models.CreateVariantParams{
+			ProductID: productID,
+			SKU:       "TSHIRT-S",
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, variant.Price)
+		assert.Empty(t, variant.Options)
+		assert.False(t, variant.Active)
+	})
+
+	t.Run("create variant with duplicate SKU", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.Create(ctx, tx, models.CreateVariantParams{
+			ProductID: productID,
+			SKU:       "TSHIRT-M",
+		})
+		assert.ErrorIs(t, err, variants.ErrDuplicateSKU)
+	})
+
+	t.Run("create variant with SKU of another tenant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+		shopA := tenant.WithID(ctx, "shop-a")
+		shopProductID := tdb.CreateTestTenantProduct(t, "shop-a", "T-Shirt")
+
+		tx, err := repo.BeginTx(shopA)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		variant, err := repo.Create(shopA, tx, models.CreateVariantParams{
+			ProductID: shopProductID,
+			SKU:       "TSHIRT-M",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "TSHIRT-M", variant.SKU)
+	})
+}
+
+func TestRepository_GetByIDAndProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := variants.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("get variant of another product", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		otherProductID := tdb.CreateTestProduct(t, "Hoodie", nil, 39.99)
+		variantID := tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		variant, err := repo.GetByIDAndProductID(ctx, tx, variantID, productID)
+		require.NoError(t, err)
+		assert.Equal(t, variantID, variant.ID)
+
+		_, err = repo.GetByIDAndProductID(ctx, tx, variantID, otherProductID)
+		assert.ErrorIs(t, err, variants.ErrNotFound)
+	})
+}
+
+func TestRepository_ListByProductIDs(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := variants.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("group variants by product", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		product1 := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		product2 := tdb.CreateTestProduct(t, "Hoodie", nil, 39.99)
+		product3 := tdb.CreateTestProduct(t, "Cap", nil, 9.99)
+		small := tdb.CreateTestVariant(t, product1, "TSHIRT-S")
+		medium := tdb.CreateTestVariant(t, product1, "TSHIRT-M")
+		hoodie := tdb.CreateTestVariant(t, product2, "HOODIE-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		result, err := repo.ListByProductIDs(ctx, tx, []int64{product1, product2, product3})
+		require.NoError(t, err)
+
+		require.Len(t, result[product1], 2)
+		assert.Equal(t, small, result[product1][0].ID)
+		assert.Equal(t, medium, result[product1][1].ID)
+		require.Len(t, result[product2], 1)
+		assert.Equal(t, hoodie, result[product2][0].ID)
+		assert.Empty(t, result[product3])
+
+		list, err := repo.ListByProductID(ctx, tx, product1)
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
+}
+
+func TestRepository_UpdateByIDAndProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := variants.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("update existing variant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		variantID := tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+		price := money.MustParse("17.50")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		updated, err := repo.UpdateByIDAndProductID(ctx, tx, variantID, productID, models.UpdateVariantParams{
+			SKU:     "TSHIRT-BLUE-M",
+			Options: models.VariantOptions{"size": "M", "color": "blue"},
+			Price:   &price,
+			Active:  false,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "TSHIRT-BLUE-M", updated.SKU)
+		assert.Equal(t, "blue", updated.Options["color"])
+		require.NotNil(t, updated.Price)
+		assert.Equal(t, price, *updated.Price)
+		assert.False(t, updated.Active)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("update to duplicate SKU", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		tdb.CreateTestVariant(t, productID, "TSHIRT-S")
+		variantID := tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.UpdateByIDAndProductID(ctx, tx, variantID, productID, models.UpdateVariantParams{SKU: "TSHIRT-S"})
+		assert.ErrorIs(t, err, variants.ErrDuplicateSKU)
+	})
+
+	t.Run("update non-existing variant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = repo.UpdateByIDAndProductID(ctx, tx, 999999, productID, models.UpdateVariantParams{SKU: "SKU"})
+		assert.ErrorIs(t, err, variants.ErrNotFound)
+	})
+}
+
+func TestRepository_DeleteByIDAndProductID(t *testing.T) {
+	tdb := testutil.SetupTestDB(t)
+	repo := variants.NewRepository(tdb.DB)
+	ctx := context.Background()
+
+	t.Run("delete existing variant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		productID := tdb.CreateTestProduct(t, "T-Shirt", nil, 19.99)
+		variantID := tdb.CreateTestVariant(t, productID, "TSHIRT-M")
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		hasReviews, err := repo.HasReviews(ctx, tx, variantID)
+		require.NoError(t, err)
+		assert.False(t, hasReviews)
+
+		require.NoError(t, repo.DeleteByIDAndProductID(ctx, tx, variantID, productID))
+
+		_, err = repo.GetByIDAndProductID(ctx, tx, variantID, productID)
+		assert.ErrorIs(t, err, variants.ErrNotFound)
+
+		require.NoError(t, repo.CommitTx(tx))
+	})
+
+	t.Run("delete non-existing variant", func(t *testing.T) {
+		tdb.Cleanup(t)
+
+		tx, err := repo.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		err = repo.DeleteByIDAndProductID(ctx, tx, 999999, 999999)
+		assert.ErrorIs(t, err, variants.ErrNotFound)
+	})
+}
@@ -0,0 +1,50 @@
+// Package requestid carries the correlation ID of a request through contexts.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header is the HTTP header carrying the request ID.
+const Header = "X-Request-ID"
+
+// maxLength bounds the length of request IDs accepted from clients.
+const maxLength = 128
+
+type requestIDKey struct{}
+
+// WithID returns a copy of ctx carrying the request ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// New generates a random request ID of 32 hex characters.
+func New() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("requestid: failed to read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Valid reports whether a client-supplied request ID may be used: 1 to 128 printable ASCII
+// characters without spaces, so it cannot break log lines or headers.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
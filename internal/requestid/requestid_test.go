@@ -0,0 +1,34 @@
+package requestid_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"product_review_hub/internal/requestid"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext(t *testing.T) {
+	assert.Empty(t, requestid.FromContext(context.Background()))
+
+	ctx := requestid.WithID(context.Background(), "abc-123")
+	assert.Equal(t, "abc-123", requestid.FromContext(ctx))
+}
+
+func TestNew(t *testing.T) {
+	id := requestid.New()
+	assert.Len(t, id, 32)
+	assert.True(t, requestid.Valid(id))
+	assert.NotEqual(t, id, requestid.New())
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, requestid.Valid("3f2b8c1e-trace"))
+	assert.False(t, requestid.Valid(""))
+	assert.False(t, requestid.Valid("has space"))
+	assert.False(t, requestid.Valid("line\nbreak"))
+	assert.False(t, requestid.Valid("ümlaut"))
+	assert.False(t, requestid.Valid(strings.Repeat("a", 129)))
+}
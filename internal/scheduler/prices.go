@@ -0,0 +1,120 @@
+// Package scheduler provides background jobs of the API server.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/models"
+	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/tenant"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PriceRepository defines interface for applying scheduled price changes.
+type PriceRepository interface {
+	BeginAllTenantsTx(ctx context.Context) (*sqlx.Tx, error)
+	CommitTx(tx *sqlx.Tx) error
+
+	ApplyScheduledPrices(ctx context.Context, tx *sqlx.Tx) ([]models.PriceChange, error)
+}
+
+// ProductCache defines interface for invalidating cached products.
+type ProductCache interface {
+	InvalidateProduct(ctx context.Context, productID int64)
+}
+
+// PriceScheduler periodically stores the price in effect for products whose
+// scheduled price changes started or ended, invalidates their cached payloads and publishes
+// price changed events.
+type PriceScheduler struct {
+	repo      PriceRepository
+	publisher *rabbitmq.Publisher
+	cache     ProductCache
+	interval  time.Duration
+}
+
+// NewPriceScheduler creates a new PriceScheduler. A nil publisher disables events and a nil
+// cache disables invalidation.
+func NewPriceScheduler(repo PriceRepository, publisher *rabbitmq.Publisher, cache ProductCache, interval time.Duration) *PriceScheduler {
+	return &PriceScheduler{
+		repo:      repo,
+		publisher: publisher,
+		cache:     cache,
+		interval:  interval,
+	}
+}
+
+// Run applies scheduled price changes every interval until the context is cancelled.
+func (s *PriceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Apply(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed to apply scheduled prices", logging.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Apply stores the prices that are due, invalidates the cached products and publishes an event
+// for every change.
+func (s *PriceScheduler) Apply(ctx context.Context) error {
+	tx, err := s.repo.BeginAllTenantsTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	changes, err := s.repo.ApplyScheduledPrices(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.CommitTx(tx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, change := range changes {
+		slog.InfoContext(ctx, "Applied scheduled price",
+			slog.Int64("product_id", change.ProductID),
+			slog.String("old_price", change.OldPrice.String()),
+			slog.String("new_price", change.NewPrice.String()),
+			slog.String("currency", string(change.Currency)),
+		)
+
+		// Changes span all tenants, so cache keys and events are built in the tenant of each product
+		tenantCtx := tenant.WithID(ctx, change.TenantID)
+		if s.cache != nil {
+			s.cache.InvalidateProduct(tenantCtx, change.ProductID)
+		}
+
+		if s.publisher == nil {
+			continue
+		}
+		event := rabbitmq.NewPriceChangedEvent(
+			tenantCtx,
+			strconv.FormatInt(change.ProductID, 10),
+			change.OldPrice,
+			change.NewPrice,
+			change.Currency,
+			rabbitmq.PriceChangeScheduled,
+		)
+		if err := s.publisher.PublishPriceChanged(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "Failed to publish price changed event", logging.Error(err))
+		}
+	}
+
+	return nil
+}
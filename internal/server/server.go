@@ -2,30 +2,78 @@ package server
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/breaker"
 	"product_review_hub/internal/cache"
 	"product_review_hub/internal/config"
 	"product_review_hub/internal/database"
 	"product_review_hub/internal/handler"
-	idempotencymw "product_review_hub/internal/middleware"
+	"product_review_hub/internal/health"
+	"product_review_hub/internal/logging"
+	"product_review_hub/internal/metrics"
+	appmw "product_review_hub/internal/middleware"
+	"product_review_hub/internal/money"
 	"product_review_hub/internal/rabbitmq"
+	"product_review_hub/internal/ratelimit"
+	"product_review_hub/internal/reconnect"
 	"product_review_hub/internal/redis"
+	"product_review_hub/internal/repository/apikeys"
+	"product_review_hub/internal/repository/categories"
 	"product_review_hub/internal/repository/idempotency"
+	"product_review_hub/internal/repository/images"
+	"product_review_hub/internal/repository/prices"
 	"product_review_hub/internal/repository/products"
+	"product_review_hub/internal/repository/reviewphotos"
 	"product_review_hub/internal/repository/reviews"
+	"product_review_hub/internal/repository/stock"
+	"product_review_hub/internal/repository/users"
+	"product_review_hub/internal/repository/variants"
+	"product_review_hub/internal/scheduler"
+	"product_review_hub/internal/storage"
+	"product_review_hub/internal/tenant"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jmoiron/sqlx"
+	goredis "github.com/redis/go-redis/v9"
 )
 
 type Server struct {
-	httpServer *http.Server
-	config     *config.Config
-	rabbitConn *rabbitmq.Connection
+	httpServer     *http.Server
+	config         *config.Config
+	priceScheduler *scheduler.PriceScheduler
+	stop           context.CancelFunc
+
+	// Redis and RabbitMQ may be unavailable at startup. The services using them start
+	// detached and are attached once the connection succeeds.
+	redisClient      *goredis.Client
+	cacheService     *cache.Service
+	idempotencyStore *idempotency.RedisStore
+	redisLimiter     *ratelimit.RedisLimiter
+	publisher        *rabbitmq.Publisher
+	// Cache commands, rate limit checks and publishes go through circuit breakers, so that a
+	// slow or failing dependency is skipped instead of holding up requests. The cache breaker
+	// guards both users of Redis.
+	cacheBreaker     *breaker.Breaker
+	publisherBreaker *breaker.Breaker
+	rabbitConn       atomic.Pointer[rabbitmq.Connection]
+	// pending are the dependencies to connect to in the background.
+	pending []dependency
+}
+
+// dependency is a dependency the server connects to in the background.
+type dependency struct {
+	name    string
+	connect func(ctx context.Context) error
 }
 
 const idempotencyTTL = time.Minute
@@ -34,69 +82,242 @@ func New(cfg *config.Config) *Server {
 	// Initialize database
 	db, err := database.New(cfg.Database)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		fatal("Failed to initialize database", err)
+	}
+	if err := metrics.RegisterDB(db.DB, cfg.Database.DBName); err != nil {
+		fatal("Failed to register database metrics", err)
+	}
+
+	cacheBreaker := breaker.New("cache", breaker.Config{
+		Failures: cfg.Breaker.Failures,
+		Cooldown: cfg.Breaker.Cooldown,
+		Timeout:  cfg.Breaker.CacheTimeout,
+	})
+	publisherBreaker := breaker.New("publisher", breaker.Config{
+		Failures: cfg.Breaker.Failures,
+		Cooldown: cfg.Breaker.Cooldown,
+		Timeout:  cfg.Breaker.PublishTimeout,
+	})
+
+	cacheService := cache.NewServiceWithConfig(nil, cache.Config{
+		TTL:       cache.DefaultTTL,
+		Breaker:   cacheBreaker,
+		LocalSize: cfg.Cache.LocalSize,
+		LocalTTL:  cfg.Cache.LocalTTL,
+	})
+
+	s := &Server{
+		config:           cfg,
+		redisClient:      redis.NewClient(cfg.Redis),
+		cacheService:     cacheService,
+		idempotencyStore: idempotency.NewRedisStore(nil),
+		redisLimiter:     ratelimit.NewRedisLimiterWithBreaker(nil, cacheBreaker),
+		publisher:        rabbitmq.NewPublisherWithBreaker(nil, publisherBreaker),
+		cacheBreaker:     cacheBreaker,
+		publisherBreaker: publisherBreaker,
+	}
+
+	// Connect to Redis and RabbitMQ; the API starts in degraded mode without them
+	if err := s.connectRedis(context.Background()); err != nil {
+		slog.Warn("Redis is unavailable, starting without cache and idempotency", logging.Error(err))
+		s.pending = append(s.pending, dependency{name: "redis", connect: s.connectRedis})
+	}
+	if err := s.connectRabbitMQ(context.Background()); err != nil {
+		slog.Warn("RabbitMQ is unavailable, starting without events", logging.Error(err))
+		s.pending = append(s.pending, dependency{name: "rabbitmq", connect: s.connectRabbitMQ})
 	}
 
-	// Initialize Redis
-	redisClient, err := redis.New(cfg.Redis)
+	// Initialize exchange rates
+	rates, err := money.ParseRates(cfg.ExchangeRates)
 	if err != nil {
-		log.Fatalf("Failed to initialize redis: %v", err)
+		fatal("Failed to parse exchange rates", err)
 	}
 
-	// Initialize idempotency store
-	idempotencyStore := idempotency.NewRedisStore(redisClient)
+	// Initialize blob store
+	blobStore, err := storage.NewLocalStore(cfg.MediaDir, cfg.MediaBaseURL)
+	if err != nil {
+		fatal("Failed to initialize blob store", err)
+	}
 
-	// Initialize cache service
-	cacheService := cache.NewService(redisClient)
+	// Initialize token verifier
+	verifier, err := newVerifier(cfg.Auth)
+	if err != nil {
+		fatal("Failed to initialize authentication", err)
+	}
 
-	// Initialize RabbitMQ
-	rabbitConn, err := rabbitmq.NewConnection(rabbitmq.Config{
-		Host:     cfg.RabbitMQ.Host,
-		Port:     cfg.RabbitMQ.Port,
-		User:     cfg.RabbitMQ.User,
-		Password: cfg.RabbitMQ.Password,
-	})
+	// Initialize tenant resolver
+	tenantResolver, err := newTenantResolver(cfg.Tenants)
 	if err != nil {
-		log.Fatalf("Failed to initialize RabbitMQ: %v", err)
+		fatal("Failed to initialize tenants", err)
 	}
 
-	// Initialize publisher
-	publisher := rabbitmq.NewPublisher(rabbitConn)
+	// Initialize rate limiter; limits fall back to per-process ones while Redis is unavailable
+	rateLimits, err := ratelimit.ParseRules(cfg.RateLimits)
+	if err != nil {
+		fatal("Failed to parse rate limits", err)
+	}
+	ipRateLimits, err := ratelimit.ParseRules(cfg.IPRateLimits)
+	if err != nil {
+		fatal("Failed to parse IP rate limits", err)
+	}
+	limiter := ratelimit.NewFallbackLimiter(s.redisLimiter, ratelimit.NewMemoryLimiter())
 
 	r := chi.NewRouter()
 
+	r.Use(appmw.RequestID())
+	r.Use(appmw.Tracing())
+	r.Use(appmw.Metrics())
+	r.Use(appmw.AccessLog(slog.Default()))
 	r.Use(middleware.Recoverer)
-	r.Use(idempotencymw.Idempotency(idempotencyStore, idempotencyTTL))
+	r.Use(appmw.Tenant(tenantResolver))
 
 	// Initialize repositories
 	productRepo := products.NewRepository(db)
 	reviewRepo := reviews.NewRepository(db)
+	categoryRepo := categories.NewRepository(db)
+	variantRepo := variants.NewRepository(db)
+	priceRepo := prices.NewRepository(db)
+	stockRepo := stock.NewRepository(db)
+	imageRepo := images.NewRepository(db)
+	photoRepo := reviewphotos.NewRepository(db)
+	userRepo := users.NewRepository(db)
+	apiKeyRepo := apikeys.NewRepository(db)
 
-	h := handler.New(db, productRepo, reviewRepo, publisher, cacheService)
+	h := handler.New(db, productRepo, reviewRepo, categoryRepo, variantRepo, priceRepo, stockRepo, imageRepo, photoRepo, userRepo, apiKeyRepo, s.publisher, s.cacheService, blobStore, rates)
 
-	api.HandlerFromMux(h, r)
-
-	return &Server{
-		httpServer: &http.Server{
-			Addr:         cfg.ServerAddress,
-			Handler:      r,
-			ReadTimeout:  15 * time.Second,
-			WriteTimeout: 15 * time.Second,
-			IdleTimeout:  60 * time.Second,
+	api.HandlerWithOptions(h, api.ChiServerOptions{
+		BaseRouter: r,
+		// The last middleware runs first: requests are limited per IP address before they are
+		// authenticated, then per caller, and responses are only stored and replayed for
+		// authenticated callers
+		Middlewares: []api.MiddlewareFunc{
+			appmw.Idempotency(s.idempotencyStore, idempotencyTTL),
+			appmw.RateLimit(limiter, rateLimits),
+			appmw.Authorize(verifier, appmw.NewAPIKeyAuthenticator(apiKeyRepo, s.cacheService)),
+			appmw.RateLimitByIP(limiter, ipRateLimits),
 		},
-		config:     cfg,
-		rabbitConn: rabbitConn,
+	})
+	r.Handle("/media/*", http.StripPrefix("/media/", storage.Handler(blobStore)))
+	r.Handle("/metrics", metrics.Handler())
+	r.Handle("/livez", health.LiveHandler())
+	r.Handle("/readyz", health.ReadyHandler(s.newHealthChecker(db)))
+
+	s.httpServer = &http.Server{
+		Addr:         cfg.ServerAddress,
+		Handler:      r,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
 	}
+	s.priceScheduler = scheduler.NewPriceScheduler(productRepo, s.publisher, s.cacheService, cfg.PriceSchedulerInterval)
+
+	return s
+}
+
+// connectRedis verifies the Redis connection and attaches the cache, the idempotency store and
+// the shared rate limiter.
+func (s *Server) connectRedis(ctx context.Context) error {
+	if err := s.redisClient.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	s.cacheService.Attach(s.redisClient)
+	s.idempotencyStore.Attach(s.redisClient)
+	s.redisLimiter.Attach(s.redisClient)
+	return nil
+}
+
+// connectRabbitMQ connects to RabbitMQ and attaches the publisher.
+func (s *Server) connectRabbitMQ(_ context.Context) error {
+	conn, err := rabbitmq.NewConnection(rabbitmq.Config{
+		Host:     s.config.RabbitMQ.Host,
+		Port:     s.config.RabbitMQ.Port,
+		User:     s.config.RabbitMQ.User,
+		Password: s.config.RabbitMQ.Password,
+	})
+	if err != nil {
+		return err
+	}
+	s.rabbitConn.Store(conn)
+	s.publisher.Attach(conn)
+	return nil
+}
+
+// fatal logs a startup failure and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.Error(err))
+	os.Exit(1)
+}
+
+// newVerifier creates the JWT verifier from the HS256 secret and the RS256 key set.
+func newVerifier(cfg config.AuthConfig) (*auth.Verifier, error) {
+	opts := auth.Options{
+		Secret:   []byte(cfg.JWTSecret),
+		Issuer:   cfg.Issuer,
+		Audience: cfg.Audience,
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := auth.LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.Keys = keys
+	}
+	return auth.NewVerifier(opts)
+}
+
+// newTenantResolver creates the tenant resolver from the configured tenants and hosts.
+func newTenantResolver(cfg config.TenantConfig) (*tenant.Resolver, error) {
+	hosts, err := tenant.ParseHosts(cfg.Hosts)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.NewResolver(tenant.ParseIDs(cfg.IDs), hosts)
+}
+
+// newHealthChecker creates the readiness checks of the database, Redis, RabbitMQ and the
+// circuit breakers. Redis and RabbitMQ are reported down until the server has connected to
+// them, and the breakers while they are open.
+func (s *Server) newHealthChecker(db *sqlx.DB) *health.Checker {
+	critical := health.ParseCritical(s.config.Health.Critical)
+	return health.NewChecker(s.config.Health.CacheTTL, s.config.Health.Timeout,
+		health.Check{Name: "postgres", Critical: critical["postgres"], Check: db.PingContext},
+		health.Check{Name: "redis", Critical: critical["redis"], Check: func(ctx context.Context) error {
+			if !s.cacheService.Attached() {
+				return errors.New("not connected")
+			}
+			return s.redisClient.Ping(ctx).Err()
+		}},
+		health.Check{Name: "rabbitmq", Critical: critical["rabbitmq"], Check: func(ctx context.Context) error {
+			conn := s.rabbitConn.Load()
+			if conn == nil {
+				return errors.New("not connected")
+			}
+			return conn.Check(ctx)
+		}},
+		health.Check{Name: "cache_breaker", Critical: critical["cache_breaker"], Check: s.cacheBreaker.Check},
+		health.Check{Name: "publisher_breaker", Critical: critical["publisher_breaker"], Check: s.publisherBreaker.Check},
+	)
 }
 
 func (s *Server) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+	for _, dep := range s.pending {
+		go reconnect.Retry(ctx, dep.name, reconnect.DefaultBackoff, dep.connect)
+	}
+	go s.priceScheduler.Run(ctx)
+	go s.cacheService.Listen(ctx)
+
 	return s.httpServer.ListenAndServe()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	if s.rabbitConn != nil {
-		if err := s.rabbitConn.Close(); err != nil {
-			log.Printf("Error closing RabbitMQ connection: %v", err)
+	if s.stop != nil {
+		s.stop()
+	}
+	if conn := s.rabbitConn.Load(); conn != nil {
+		if err := conn.Close(); err != nil {
+			slog.Warn("Failed to close RabbitMQ connection", logging.Error(err))
 		}
 	}
 	return s.httpServer.Shutdown(ctx)
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir(), "/media/")
+	require.NoError(t, err)
+
+	t.Run("put, get and delete", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "products/1/a.png", strings.NewReader("content")))
+
+		blob, err := store.Get(ctx, "products/1/a.png")
+		require.NoError(t, err)
+		data, err := io.ReadAll(blob)
+		require.NoError(t, err)
+		require.NoError(t, blob.Close())
+		assert.Equal(t, "content", string(data))
+
+		require.NoError(t, store.Delete(ctx, "products/1/a.png"))
+		require.NoError(t, store.Delete(ctx, "products/1/a.png"))
+
+		_, err = store.Get(ctx, "products/1/a.png")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("directory is not a blob", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "products/2/a.png", strings.NewReader("content")))
+
+		_, err := store.Get(ctx, "products/2")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects keys outside the root", func(t *testing.T) {
+		for _, key := range []string{"", "/etc/passwd", "../secret", "products/../../secret", "products//a.png"} {
+			assert.ErrorIs(t, store.Put(ctx, key, strings.NewReader("x")), ErrInvalidKey, key)
+		}
+	})
+
+	t.Run("url", func(t *testing.T) {
+		assert.Equal(t, "/media/products/1/a.png", store.URL("products/1/a.png"))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir(), "/media")
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, "products/1/a.png", strings.NewReader("png")))
+
+	server := httptest.NewServer(http.StripPrefix("/media/", Handler(store)))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/media/products/1/a.png")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "png", string(body))
+
+	resp, err = http.Get(server.URL + "/media/products/1/missing.png")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestNewKey(t *testing.T) {
+	key1, err := NewKey("products/1", ".jpg")
+	require.NoError(t, err)
+	key2, err := NewKey("products/1", ".jpg")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key1, "products/1/"))
+	assert.True(t, strings.HasSuffix(key1, ".jpg"))
+	assert.NotEqual(t, key1, key2)
+	assert.NoError(t, validateKey(key1))
+}
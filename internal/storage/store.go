@@ -0,0 +1,84 @@
+// Package storage provides blob storage for uploaded files.
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Common errors.
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore stores files under slash-separated keys.
+type BlobStore interface {
+	// Put stores the content of r under key, replacing any existing blob.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get opens the blob stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of the blob stored under key.
+	URL(key string) string
+}
+
+// NewKey returns a unique key in the directory prefix with the given extension, e.g. "products/1/3f2a….jpg".
+func NewKey(prefix, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return path.Join(prefix, hex.EncodeToString(b)+ext), nil
+}
+
+// validateKey rejects keys that are empty, absolute or escape the store root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// Handler serves blobs from the store by the request path, which is used as the key.
+// Mount it with http.StripPrefix so the path matches the keys passed to the store.
+func Handler(store BlobStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		blob, err := store.Get(r.Context(), key)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidKey) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer blob.Close()
+
+		if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = io.Copy(w, blob)
+	})
+}
@@ -0,0 +1,131 @@
+// Package tenant carries the storefront a request belongs to and resolves it from requests.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Default is the tenant of requests that do not name one, and of data created before
+// tenants existed.
+const Default = "default"
+
+// Header is the HTTP header naming the tenant of a request.
+const Header = "X-Tenant-ID"
+
+// ErrUnknownTenant is returned for requests naming a tenant that is not configured.
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Valid reports whether id is a well-formed tenant ID: up to 63 lowercase letters, digits and
+// dashes, starting with a letter or digit.
+func Valid(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+type tenantKey struct{}
+
+// WithID returns a copy of ctx carrying the tenant ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the tenant ID stored in ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// ID returns the tenant ID stored in ctx, or Default.
+func ID(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return Default
+}
+
+// Resolver determines the tenant of a request from the X-Tenant-ID header or, without it,
+// from the request host. Requests naming neither belong to the tenant of the caller's token,
+// or to Default for anonymous callers.
+type Resolver struct {
+	known map[string]bool
+	hosts map[string]string
+}
+
+// Known reports whether id is a configured tenant.
+func (r *Resolver) Known(id string) bool {
+	return r.known[id]
+}
+
+// NewResolver creates a Resolver for the given tenants and host mapping. Default is always known.
+func NewResolver(tenants []string, hosts map[string]string) (*Resolver, error) {
+	known := map[string]bool{Default: true}
+	for _, id := range tenants {
+		if !Valid(id) {
+			return nil, fmt.Errorf("invalid tenant ID %q", id)
+		}
+		known[id] = true
+	}
+	normalized := make(map[string]string, len(hosts))
+	for host, id := range hosts {
+		if !known[id] {
+			return nil, fmt.Errorf("host %q maps to unknown tenant %q", host, id)
+		}
+		normalized[strings.ToLower(host)] = id
+	}
+	return &Resolver{known: known, hosts: normalized}, nil
+}
+
+// Resolve returns the tenant named by the request, or an empty string if it names none.
+func (r *Resolver) Resolve(req *http.Request) (string, error) {
+	if id := strings.TrimSpace(req.Header.Get(Header)); id != "" {
+		if !r.known[id] {
+			return "", fmt.Errorf("%w %q", ErrUnknownTenant, id)
+		}
+		return id, nil
+	}
+
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if id, ok := r.hosts[strings.ToLower(host)]; ok {
+		return id, nil
+	}
+
+	return "", nil
+}
+
+// ParseHosts parses a host mapping in the form "shop-a.example.com=shop-a,shop-b.example.com=shop-b".
+func ParseHosts(s string) (map[string]string, error) {
+	hosts := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		host, id, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tenant host %q: expected HOST=TENANT", pair)
+		}
+		hosts[strings.TrimSpace(host)] = strings.TrimSpace(id)
+	}
+	return hosts, nil
+}
+
+// ParseIDs parses a comma-separated list of tenant IDs.
+func ParseIDs(s string) []string {
+	var ids []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,86 @@
+package tenant_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"product_review_hub/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, tenant.Default, tenant.ID(context.Background()))
+
+	ctx := tenant.WithID(context.Background(), "shop-a")
+	id, ok := tenant.FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "shop-a", id)
+	assert.Equal(t, "shop-a", tenant.ID(ctx))
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, tenant.Valid("shop-a"))
+	assert.True(t, tenant.Valid("42"))
+	assert.False(t, tenant.Valid(""))
+	assert.False(t, tenant.Valid("-shop"))
+	assert.False(t, tenant.Valid("Shop"))
+	assert.False(t, tenant.Valid("shop:a"))
+}
+
+func TestResolver(t *testing.T) {
+	hosts, err := tenant.ParseHosts("shop-a.example.com=shop-a, Shop-B.example.com = shop-b")
+	require.NoError(t, err)
+	resolver, err := tenant.NewResolver(tenant.ParseIDs("shop-a,shop-b"), hosts)
+	require.NoError(t, err)
+
+	t.Run("prefer header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://shop-a.example.com/api/v1/products", nil)
+		req.Header.Set(tenant.Header, "shop-b")
+		id, err := resolver.Resolve(req)
+		require.NoError(t, err)
+		assert.Equal(t, "shop-b", id)
+	})
+
+	t.Run("resolve host ignoring case and port", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://shop-b.EXAMPLE.com:8080/api/v1/products", nil)
+		id, err := resolver.Resolve(req)
+		require.NoError(t, err)
+		assert.Equal(t, "shop-b", id)
+	})
+
+	t.Run("name no tenant", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://localhost/api/v1/products", nil)
+		id, err := resolver.Resolve(req)
+		require.NoError(t, err)
+		assert.Empty(t, id)
+	})
+
+	t.Run("reject unknown tenant", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://localhost/api/v1/products", nil)
+		req.Header.Set(tenant.Header, "shop-c")
+		_, err := resolver.Resolve(req)
+		assert.ErrorIs(t, err, tenant.ErrUnknownTenant)
+	})
+}
+
+func TestNewResolver(t *testing.T) {
+	t.Run("know default tenant", func(t *testing.T) {
+		resolver, err := tenant.NewResolver(nil, nil)
+		require.NoError(t, err)
+		assert.True(t, resolver.Known(tenant.Default))
+		assert.False(t, resolver.Known("shop-a"))
+	})
+
+	t.Run("reject invalid tenant ID", func(t *testing.T) {
+		_, err := tenant.NewResolver([]string{"Shop A"}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("reject host of unknown tenant", func(t *testing.T) {
+		_, err := tenant.NewResolver(nil, map[string]string{"shop.example.com": "shop-a"})
+		assert.Error(t, err)
+	})
+}
@@ -6,8 +6,10 @@ import (
 	"os"
 	"product_review_hub/internal/database"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,6 +52,9 @@ func (tdb *TestDB) Cleanup(t *testing.T) {
 
 	_, err = tdb.DB.ExecContext(ctx, "DELETE FROM products")
 	require.NoError(t, err, "failed to clean up products")
+
+	_, err = tdb.DB.ExecContext(ctx, "DELETE FROM categories")
+	require.NoError(t, err, "failed to clean up categories")
 }
 
 // Close closes the database connection.
@@ -67,7 +72,7 @@ func (tdb *TestDB) TruncateTables(t *testing.T) {
 
 	ctx := context.Background()
 
-	_, err := tdb.DB.ExecContext(ctx, "TRUNCATE TABLE reviews, products RESTART IDENTITY CASCADE")
+	_, err := tdb.DB.ExecContext(ctx, "TRUNCATE TABLE api_keys, review_photos, reviews, users, product_variants, product_prices, product_currency_prices, product_stock, product_images, product_categories, products, categories RESTART IDENTITY CASCADE")
 	require.NoError(t, err, "failed to truncate tables")
 }
 
@@ -105,6 +110,43 @@ func (tdb *TestDB) CreateTestProduct(t *testing.T, name string, description *str
 	return id
 }
 
+// CreateTestProductWithAttributes creates a test product with tags and JSON-encoded attributes and returns its ID.
+func (tdb *TestDB) CreateTestProductWithAttributes(t *testing.T, name string, tags []string, attributes string) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO products (name, price, tags, attributes) VALUES ($1, 10, $2, $3::JSONB) RETURNING id`
+	err := tdb.DB.QueryRowx(query, name, pq.Array(tags), attributes).Scan(&id)
+	require.NoError(t, err, "failed to create test product")
+
+	return id
+}
+
+// CreateTestTenantProduct creates a test product of the given tenant and returns its ID.
+func (tdb *TestDB) CreateTestTenantProduct(t *testing.T, tenantID, name string) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO products (name, price, tenant_id) VALUES ($1, 10, $2) RETURNING id`
+	err := tdb.DB.QueryRowx(query, name, tenantID).Scan(&id)
+	require.NoError(t, err, "failed to create test product")
+
+	return id
+}
+
+// CreateTestTenantReview creates a test review of the given tenant and returns its ID.
+// The product must belong to the same tenant.
+func (tdb *TestDB) CreateTestTenantReview(t *testing.T, tenantID string, productID int64, rating int) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO reviews (product_id, first_name, last_name, rating, tenant_id) VALUES ($1, 'Test', 'User', $2, $3) RETURNING id`
+	err := tdb.DB.QueryRowx(query, productID, rating, tenantID).Scan(&id)
+	require.NoError(t, err, "failed to create test review")
+
+	return id
+}
+
 // CreateTestReview creates a test review and returns its ID.
 func (tdb *TestDB) CreateTestReview(t *testing.T, productID int64, firstName, lastName string, rating int, comment *string) int64 {
 	t.Helper()
@@ -117,6 +159,136 @@ func (tdb *TestDB) CreateTestReview(t *testing.T, productID int64, firstName, la
 	return id
 }
 
+// CreateTestUser creates a test user and returns its ID.
+func (tdb *TestDB) CreateTestUser(t *testing.T, email string) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO users (email, first_name, last_name) VALUES ($1, 'Test', 'User') RETURNING id`
+	err := tdb.DB.QueryRowx(query, email).Scan(&id)
+	require.NoError(t, err, "failed to create test user")
+
+	return id
+}
+
+// CreateTestUserReview creates a test review written by a user and returns its ID.
+func (tdb *TestDB) CreateTestUserReview(t *testing.T, productID, userID int64, rating int) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO reviews (product_id, user_id, first_name, last_name, rating) VALUES ($1, $2, 'Test', 'User', $3) RETURNING id`
+	err := tdb.DB.QueryRowx(query, productID, userID, rating).Scan(&id)
+	require.NoError(t, err, "failed to create test user review")
+
+	return id
+}
+
+// CreateTestVariant creates a test variant of a product and returns its ID.
+func (tdb *TestDB) CreateTestVariant(t *testing.T, productID int64, sku string) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO product_variants (product_id, sku) VALUES ($1, $2) RETURNING id`
+	err := tdb.DB.QueryRowx(query, productID, sku).Scan(&id)
+	require.NoError(t, err, "failed to create test variant")
+
+	return id
+}
+
+// CreateTestPrice creates a price history entry of a product and returns its ID.
+func (tdb *TestDB) CreateTestPrice(t *testing.T, productID int64, price float64, effectiveFrom time.Time, effectiveTo *time.Time) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO product_prices (product_id, price, effective_from, effective_to) VALUES ($1, $2, $3, $4) RETURNING id`
+	err := tdb.DB.QueryRowx(query, productID, price, effectiveFrom, effectiveTo).Scan(&id)
+	require.NoError(t, err, "failed to create test price")
+
+	return id
+}
+
+// CreateTestStock sets the stock level of a product.
+func (tdb *TestDB) CreateTestStock(t *testing.T, productID int64, quantity, reserved int) {
+	t.Helper()
+
+	query := `INSERT INTO product_stock (product_id, quantity, reserved) VALUES ($1, $2, $3)`
+	_, err := tdb.DB.Exec(query, productID, quantity, reserved)
+	require.NoError(t, err, "failed to create test stock")
+}
+
+// CreateTestImage records an image of a product with the given blob key and returns its ID.
+func (tdb *TestDB) CreateTestImage(t *testing.T, productID int64, blobKey string, position int) int64 {
+	t.Helper()
+
+	var id int64
+	query := `
+		INSERT INTO product_images (product_id, position, blob_key, thumbnail_key, content_type, size_bytes, width, height)
+		VALUES ($1, $2, $3, $3 || '_thumb', 'image/png', 100, 10, 10)
+		RETURNING id
+	`
+	err := tdb.DB.QueryRowx(query, productID, position, blobKey).Scan(&id)
+	require.NoError(t, err, "failed to create test image")
+
+	return id
+}
+
+// CreateTestReviewPhoto records a photo of a review with the given blob key and returns its ID.
+func (tdb *TestDB) CreateTestReviewPhoto(t *testing.T, reviewID int64, blobKey string) int64 {
+	t.Helper()
+
+	var id int64
+	query := `
+		INSERT INTO review_photos (review_id, blob_key, thumbnail_key, content_type, size_bytes, width, height)
+		VALUES ($1, $2, $2 || '_thumb', 'image/jpeg', 100, 10, 10)
+		RETURNING id
+	`
+	err := tdb.DB.QueryRowx(query, reviewID, blobKey).Scan(&id)
+	require.NoError(t, err, "failed to create test review photo")
+
+	return id
+}
+
+// CreateTestCategory creates a test category and returns its ID.
+func (tdb *TestDB) CreateTestCategory(t *testing.T, name string, parentID *int64) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO categories (name, parent_id) VALUES ($1, $2) RETURNING id`
+	err := tdb.DB.QueryRowx(query, name, parentID).Scan(&id)
+	require.NoError(t, err, "failed to create test category")
+
+	return id
+}
+
+// CreateTestTenantCategory creates a test root category of the given tenant and returns its ID.
+func (tdb *TestDB) CreateTestTenantCategory(t *testing.T, tenantID, name string) int64 {
+	t.Helper()
+
+	var id int64
+	query := `INSERT INTO categories (name, tenant_id) VALUES ($1, $2) RETURNING id`
+	err := tdb.DB.QueryRowx(query, name, tenantID).Scan(&id)
+	require.NoError(t, err, "failed to create test category")
+
+	return id
+}
+
+// AssignTestProductCategory assigns a product to a category.
+func (tdb *TestDB) AssignTestProductCategory(t *testing.T, productID, categoryID int64) {
+	t.Helper()
+
+	tdb.MustExec(t, `INSERT INTO product_categories (product_id, category_id) VALUES ($1, $2)`, productID, categoryID)
+}
+
+// TimePtr returns a pointer to the given time.
+func TimePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// Int64Ptr returns a pointer to the given int64.
+func Int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
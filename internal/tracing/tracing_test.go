@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("write spans to stdout", func(t *testing.T) {
+		var buf bytes.Buffer
+		shutdown, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterStdout, SampleRatio: 1}, "test-service", &buf)
+		require.NoError(t, err)
+
+		ctx, parent := tracing.Start(context.Background(), "parent")
+		_, child := tracing.Start(ctx, "reviews.Create", attribute.Int64("product_id", 7))
+		tracing.RecordError(child, errors.New("boom"))
+		child.End()
+		parent.End()
+
+		require.NoError(t, shutdown(context.Background()))
+		out := buf.String()
+		assert.Contains(t, out, `"Name":"reviews.Create"`)
+		assert.Contains(t, out, `"Name":"parent"`)
+		assert.Contains(t, out, "test-service")
+		assert.Contains(t, out, "boom")
+	})
+
+	t.Run("propagate W3C trace context without exporter", func(t *testing.T) {
+		shutdown, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone}, "test-service", &bytes.Buffer{})
+		require.NoError(t, err)
+		defer shutdown(context.Background()) //nolint:errcheck // No-op shutdown
+
+		incoming := http.Header{}
+		incoming.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(incoming))
+
+		outgoing := http.Header{}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(outgoing))
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", outgoing.Get("traceparent"))
+		assert.True(t, trace.SpanContextFromContext(ctx).IsRemote())
+	})
+
+	t.Run("reject unknown exporter", func(t *testing.T) {
+		_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"}, "test-service", &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,3 @@
+-- Drop tables
+DROP TABLE IF EXISTS product_categories;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_products_attributes;
+DROP INDEX IF EXISTS idx_products_tags;
+
+-- Drop columns
+ALTER TABLE products
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,8 @@
+-- Add tags and typed attributes to products
+ALTER TABLE products
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+-- Create indexes
+CREATE INDEX idx_products_tags ON products USING GIN (tags);
+CREATE INDEX idx_products_attributes ON products USING GIN (attributes);
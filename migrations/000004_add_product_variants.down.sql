@@ -0,0 +1,10 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_reviews_variant_id;
+
+-- Drop columns
+ALTER TABLE reviews
+    DROP CONSTRAINT IF EXISTS fk_reviews_variant,
+    DROP COLUMN IF EXISTS variant_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS product_variants;
@@ -0,0 +1,21 @@
+-- Create product_variants table
+CREATE TABLE IF NOT EXISTS product_variants (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    options JSONB NOT NULL DEFAULT '{}',
+    price DECIMAL(10, 2),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, id)
+);
+
+-- Link reviews to variants of the same product
+ALTER TABLE reviews
+    ADD COLUMN variant_id INTEGER,
+    ADD CONSTRAINT fk_reviews_variant FOREIGN KEY (product_id, variant_id) REFERENCES product_variants(product_id, id);
+
+-- Create indexes
+CREATE INDEX idx_product_variants_product_id ON product_variants(product_id);
+CREATE INDEX idx_reviews_variant_id ON reviews(variant_id);
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_product_prices_product_id_effective_from;
+
+-- Drop tables
+DROP TABLE IF EXISTS product_prices;
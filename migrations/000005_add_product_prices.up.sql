@@ -0,0 +1,18 @@
+-- Create product_prices table
+-- Every price change is recorded here. Rows with effective_from in the future are scheduled changes.
+CREATE TABLE IF NOT EXISTS product_prices (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price DECIMAL(10, 2) NOT NULL CHECK (price > 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    effective_to TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+-- Record the current price of existing products
+INSERT INTO product_prices (product_id, price, effective_from)
+SELECT id, price, created_at FROM products;
+
+-- Create indexes
+CREATE INDEX idx_product_prices_product_id_effective_from ON product_prices(product_id, effective_from DESC);
@@ -0,0 +1,6 @@
+-- Drop tables
+DROP TABLE IF EXISTS product_currency_prices;
+
+-- Drop columns
+ALTER TABLE product_prices DROP COLUMN IF EXISTS currency;
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,15 @@
+-- Add currency to products
+ALTER TABLE products ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+-- Record the currency of each price change
+ALTER TABLE product_prices ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+-- Create product_currency_prices table
+CREATE TABLE IF NOT EXISTS product_currency_prices (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    price DECIMAL(10, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, currency)
+);
@@ -0,0 +1,13 @@
+-- Create product_stock table
+-- Products without a row have no stock. Reserved units are held for pending orders and are not available for sale.
+CREATE TABLE IF NOT EXISTS product_stock (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    reserved INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    low_stock_threshold INTEGER NOT NULL DEFAULT 0 CHECK (low_stock_threshold >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (quantity >= reserved)
+);
+
+-- Create indexes
+CREATE INDEX idx_product_stock_available ON product_stock(product_id) WHERE quantity > reserved;
@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_reviews_user_id;
+DROP INDEX IF EXISTS idx_reviews_product_id_user_id;
+
+-- Drop columns
+ALTER TABLE reviews DROP COLUMN IF EXISTS user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,17 @@
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    first_name VARCHAR(255) NOT NULL,
+    last_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Link reviews to their authors
+-- Reviews written before accounts existed keep a NULL user_id and their free-text names.
+ALTER TABLE reviews ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- Create indexes
+-- A user can review a product only once; NULL user IDs never conflict.
+CREATE UNIQUE INDEX idx_reviews_product_id_user_id ON reviews(product_id, user_id);
+CREATE INDEX idx_reviews_user_id ON reviews(user_id);
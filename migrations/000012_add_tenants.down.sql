@@ -0,0 +1,38 @@
+-- Drop row-level security
+DROP POLICY IF EXISTS product_variants_tenant_isolation ON product_variants;
+DROP POLICY IF EXISTS categories_tenant_isolation ON categories;
+DROP POLICY IF EXISTS reviews_tenant_isolation ON reviews;
+DROP POLICY IF EXISTS products_tenant_isolation ON products;
+ALTER TABLE product_variants NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE product_variants DISABLE ROW LEVEL SECURITY;
+ALTER TABLE categories NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE categories DISABLE ROW LEVEL SECURITY;
+ALTER TABLE reviews NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE reviews DISABLE ROW LEVEL SECURITY;
+ALTER TABLE products NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE products DISABLE ROW LEVEL SECURITY;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_categories_tenant_id_name;
+DROP INDEX IF EXISTS idx_reviews_tenant_id_user_id;
+DROP INDEX IF EXISTS idx_products_tenant_id_created_at;
+
+-- Restore global category parents and SKUs
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_tenant_id_parent_id_fkey;
+ALTER TABLE categories ADD CONSTRAINT categories_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES categories(id);
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_tenant_id_id_key;
+ALTER TABLE product_variants DROP CONSTRAINT IF EXISTS product_variants_tenant_id_sku_key;
+ALTER TABLE product_variants ADD CONSTRAINT product_variants_sku_key UNIQUE (sku);
+
+-- Drop constraints
+ALTER TABLE product_variants DROP CONSTRAINT IF EXISTS product_variants_tenant_id_product_id_fkey;
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_tenant_id_product_id_fkey;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_tenant_id_id_key;
+
+-- Drop columns
+ALTER TABLE api_keys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE product_variants DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE categories DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE reviews DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE products DROP COLUMN IF EXISTS tenant_id;
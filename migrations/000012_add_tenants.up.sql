@@ -0,0 +1,59 @@
+-- Assign products, reviews, categories, variants and API keys to tenants
+-- Rows created before tenants existed belong to the "default" tenant.
+ALTER TABLE products ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE reviews ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE categories ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE product_variants ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+-- Reviews and variants belong to the tenant of their product
+ALTER TABLE products ADD CONSTRAINT products_tenant_id_id_key UNIQUE (tenant_id, id);
+ALTER TABLE reviews ADD CONSTRAINT reviews_tenant_id_product_id_fkey
+    FOREIGN KEY (tenant_id, product_id) REFERENCES products(tenant_id, id) ON DELETE CASCADE;
+ALTER TABLE product_variants ADD CONSTRAINT product_variants_tenant_id_product_id_fkey
+    FOREIGN KEY (tenant_id, product_id) REFERENCES products(tenant_id, id) ON DELETE CASCADE;
+
+-- SKUs are unique within a tenant
+ALTER TABLE product_variants DROP CONSTRAINT product_variants_sku_key;
+ALTER TABLE product_variants ADD CONSTRAINT product_variants_tenant_id_sku_key UNIQUE (tenant_id, sku);
+
+-- A subcategory belongs to the tenant of its parent
+ALTER TABLE categories ADD CONSTRAINT categories_tenant_id_id_key UNIQUE (tenant_id, id);
+ALTER TABLE categories DROP CONSTRAINT categories_parent_id_fkey;
+ALTER TABLE categories ADD CONSTRAINT categories_tenant_id_parent_id_fkey
+    FOREIGN KEY (tenant_id, parent_id) REFERENCES categories(tenant_id, id);
+
+-- Create indexes
+CREATE INDEX idx_products_tenant_id_created_at ON products(tenant_id, created_at DESC);
+CREATE INDEX idx_reviews_tenant_id_user_id ON reviews(tenant_id, user_id);
+CREATE INDEX idx_categories_tenant_id_name ON categories(tenant_id, name);
+
+-- Enforce tenant isolation with row-level security
+-- Transactions bound to a tenant set app.tenant_id and only see and write that tenant's rows.
+-- Jobs spanning all tenants, such as the price scheduler, set app.all_tenants explicitly;
+-- sessions setting neither see no rows.
+-- Superusers and roles with BYPASSRLS are exempt from the policies; the repositories filter
+-- by tenant as well, so isolation holds for them too.
+ALTER TABLE products ENABLE ROW LEVEL SECURITY;
+ALTER TABLE products FORCE ROW LEVEL SECURITY;
+CREATE POLICY products_tenant_isolation ON products
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE reviews ENABLE ROW LEVEL SECURITY;
+ALTER TABLE reviews FORCE ROW LEVEL SECURITY;
+CREATE POLICY reviews_tenant_isolation ON reviews
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE categories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE categories FORCE ROW LEVEL SECURITY;
+CREATE POLICY categories_tenant_isolation ON categories
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE product_variants ENABLE ROW LEVEL SECURITY;
+ALTER TABLE product_variants FORCE ROW LEVEL SECURITY;
+CREATE POLICY product_variants_tenant_isolation ON product_variants
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
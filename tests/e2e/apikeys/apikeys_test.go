@@ -0,0 +1,352 @@
+package apikeys_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	apiKeysEndpoint  = "/api/v1/api-keys"
+	productsEndpoint = "/api/v1/products"
+)
+
+func reviewsEndpoint(productID string) string {
+	return fmt.Sprintf("%s/%s/reviews", productsEndpoint, productID)
+}
+
+func assertError(t *testing.T, resp *http.Response, status int, message string) {
+	t.Helper()
+	defer resp.Body.Close()
+	require.Equal(t, status, resp.StatusCode, "Unexpected status code")
+	errorResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+	assert.Equal(t, message, errorResp.Error)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should issue key and list it without the plaintext", func(t *testing.T) {
+			user := e2e.CreateTestUser(t, client)
+			expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+			resp := client.Post(apiKeysEndpoint, api.APIKeyCreate{
+				UserId:    user.Id,
+				Name:      "  Partner feed ",
+				Scopes:    []api.APIKeyScope{api.ProductsRead, api.ReviewsWrite, api.ProductsRead},
+				ExpiresAt: &expiresAt,
+			})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"), "Plaintext key must not be stored")
+			issued := e2e.ParseJSON[api.APIKeyIssued](t, resp)
+
+			assert.NotEmpty(t, issued.Id)
+			assert.Equal(t, user.Id, issued.UserId)
+			assert.Equal(t, "Partner feed", issued.Name)
+			assert.True(t, strings.HasPrefix(issued.Key, auth.APIKeyPrefix))
+			assert.True(t, strings.HasPrefix(issued.Key, issued.Prefix))
+			assert.Equal(t, []api.APIKeyScope{api.ProductsRead, api.ReviewsWrite}, issued.Scopes)
+			require.NotNil(t, issued.ExpiresAt)
+			assert.True(t, expiresAt.Equal(*issued.ExpiresAt))
+			assert.Nil(t, issued.RevokedAt)
+			assert.Nil(t, issued.LastUsedAt)
+
+			resp = client.Get(apiKeysEndpoint)
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			body := e2e.ReadBody(t, resp)
+			assert.Contains(t, body, issued.Prefix)
+			assert.NotContains(t, body, issued.Key, "Plaintext key must not be listed")
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+
+		tests := []struct {
+			name    string
+			req     api.APIKeyCreate
+			message string
+		}{
+			{
+				name:    "missing name",
+				req:     api.APIKeyCreate{UserId: user.Id, Name: " ", Scopes: []api.APIKeyScope{api.ProductsRead}},
+				message: "validation error: name - name is required",
+			},
+			{
+				name:    "missing scopes",
+				req:     api.APIKeyCreate{UserId: user.Id, Name: "Feed"},
+				message: "validation error: scopes - at least one scope is required",
+			},
+			{
+				name:    "unknown scope",
+				req:     api.APIKeyCreate{UserId: user.Id, Name: "Feed", Scopes: []api.APIKeyScope{"admin"}},
+				message: `validation error: scopes - unknown scope "admin"`,
+			},
+			{
+				name:    "invalid user ID",
+				req:     api.APIKeyCreate{UserId: "abc", Name: "Feed", Scopes: []api.APIKeyScope{api.ProductsRead}},
+				message: "validation error: user_id - user_id must be a valid user ID",
+			},
+		}
+		for _, tt := range tests {
+			t.Run("should return 400 for "+tt.name, func(t *testing.T) {
+				resp := client.Post(apiKeysEndpoint, tt.req)
+				assertions.AssertBadRequestWithMessage(resp, tt.message)
+			})
+		}
+
+		t.Run("should return 400 for past expiry", func(t *testing.T) {
+			past := time.Now().Add(-time.Hour)
+			resp := client.Post(apiKeysEndpoint, api.APIKeyCreate{UserId: user.Id, Name: "Feed", Scopes: []api.APIKeyScope{api.ProductsRead}, ExpiresAt: &past})
+			assertions.AssertBadRequestWithMessage(resp, "validation error: expires_at - expires_at must be in the future")
+		})
+
+		t.Run("should return 404 for non-existent user", func(t *testing.T) {
+			resp := client.Post(apiKeysEndpoint, api.APIKeyCreate{UserId: "999999", Name: "Feed", Scopes: []api.APIKeyScope{api.ProductsRead}})
+			assertions.AssertNotFoundWithMessage(resp, "User not found")
+		})
+	})
+
+	t.Run("Authorization", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		req := api.APIKeyCreate{UserId: user.Id, Name: "Feed", Scopes: []api.APIKeyScope{api.ProductsRead}}
+
+		t.Run("should return 401 without token", func(t *testing.T) {
+			resp := client.Anonymous().Post(apiKeysEndpoint, req)
+			assertions.AssertStatusCode(resp, http.StatusUnauthorized)
+			resp.Body.Close()
+		})
+
+		t.Run("should return 403 for customers", func(t *testing.T) {
+			resp := client.As(user.Id).Post(apiKeysEndpoint, req)
+			assertions.AssertStatusCode(resp, http.StatusForbidden)
+			resp.Body.Close()
+		})
+
+		t.Run("should return 403 for API keys", func(t *testing.T) {
+			key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead, api.ProductsWrite)
+
+			resp := client.WithAPIKey(key.Key).Get(apiKeysEndpoint)
+			assertError(t, resp, http.StatusForbidden, "API keys cannot access this operation")
+		})
+	})
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewProductFixtures()
+	reviewFixtures := e2e.NewReviewFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should allow operations covered by the scopes", func(t *testing.T) {
+		env.CleanupProducts(t)
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead, api.ProductsWrite)
+		machine := client.WithAPIKey(key.Key)
+
+		resp := machine.Post(productsEndpoint, fixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusCreated)
+		product := e2e.ParseJSON[api.Product](t, resp)
+
+		resp = machine.Get(productsEndpoint + "/" + product.Id)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 403 for operations outside the scopes", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ReviewsRead)
+		machine := client.WithAPIKey(key.Key)
+
+		resp := machine.Post(productsEndpoint, fixtures.ValidCreateRequest())
+		assertError(t, resp, http.StatusForbidden, "Insufficient scope")
+
+		resp = machine.Get(productsEndpoint + "/" + productID)
+		assertError(t, resp, http.StatusForbidden, "Insufficient scope")
+
+		resp = machine.Get(reviewsEndpoint(productID))
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 401 for unknown key", func(t *testing.T) {
+		resp := client.WithAPIKey(auth.APIKeyPrefix+"unknownunknownunknown").Post(productsEndpoint, fixtures.ValidCreateRequest())
+		assertError(t, resp, http.StatusUnauthorized, "Invalid API key")
+
+		resp = client.WithAPIKey("garbage").Get(productsEndpoint)
+		assertError(t, resp, http.StatusUnauthorized, "Invalid API key")
+	})
+
+	t.Run("should return 401 for expired key", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := env.DB.ExecContext(ctx, "UPDATE api_keys SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", key.Id)
+		require.NoError(t, err)
+		env.FlushCache(t)
+
+		resp := client.WithAPIKey(key.Key).Get(productsEndpoint)
+		assertError(t, resp, http.StatusUnauthorized, "API key has expired")
+	})
+
+	t.Run("should write reviews as the key's user", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ReviewsWrite)
+
+		resp := client.WithAPIKey(key.Key).Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+		review := assertions.AssertReviewCreated(resp, reviewFixtures.ValidCreateRequest(), productID)
+		require.NotNil(t, review.UserId)
+		assert.Equal(t, user.Id, *review.UserId)
+
+		// The key acts as its user only, so other users' reviews stay off limits
+		other := e2e.CreateTestReview(t, client, productID)
+		resp = client.WithAPIKey(key.Key).Delete(reviewsEndpoint(productID) + "/" + other.Id)
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("should record last use", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+
+		resp := client.WithAPIKey(key.Key).Get(productsEndpoint)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = client.Get(apiKeysEndpoint)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		keys := e2e.ParseJSON[[]api.APIKey](t, resp)
+		for _, k := range keys {
+			if k.Id == key.Id {
+				assert.NotNil(t, k.LastUsedAt)
+				return
+			}
+		}
+		t.Fatalf("API key %s not listed", key.Id)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should reject revoked key", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+
+		resp := client.Delete(apiKeysEndpoint + "/" + key.Id)
+		assertions.AssertNoContent(resp)
+		resp.Body.Close()
+
+		resp = client.WithAPIKey(key.Key).Get(productsEndpoint)
+		assertError(t, resp, http.StatusUnauthorized, "Invalid API key")
+
+		resp = client.Delete(apiKeysEndpoint + "/" + key.Id)
+		assertions.AssertNoContent(resp)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 404 for non-existent key", func(t *testing.T) {
+		resp := client.Delete(apiKeysEndpoint + "/999999")
+		assertions.AssertNotFoundWithMessage(resp, "API key not found")
+	})
+
+	t.Run("should return 400 for invalid key ID", func(t *testing.T) {
+		resp := client.Delete(apiKeysEndpoint + "/invalid")
+		assertions.AssertBadRequestWithMessage(resp, "Invalid API key ID")
+	})
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+
+	rotate := func(t *testing.T, keyID string, body any) api.APIKeyIssued {
+		t.Helper()
+		resp := client.Post(apiKeysEndpoint+"/"+keyID+"/rotate", body)
+		assertions.AssertStatusCode(resp, http.StatusCreated)
+		return e2e.ParseJSON[api.APIKeyIssued](t, resp)
+	}
+
+	t.Run("should revoke old key immediately by default", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		old := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+
+		replacement := rotate(t, old.Id, nil)
+		assert.NotEqual(t, old.Id, replacement.Id)
+		assert.NotEqual(t, old.Key, replacement.Key)
+		assert.Equal(t, old.UserId, replacement.UserId)
+		assert.Equal(t, old.Name, replacement.Name)
+		assert.Equal(t, old.Scopes, replacement.Scopes)
+
+		resp := client.WithAPIKey(old.Key).Get(productsEndpoint)
+		assertError(t, resp, http.StatusUnauthorized, "Invalid API key")
+
+		resp = client.WithAPIKey(replacement.Key).Get(productsEndpoint)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("should keep old key working during grace period", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		old := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+		grace := 3600
+
+		replacement := rotate(t, old.Id, api.APIKeyRotate{GracePeriodSeconds: &grace})
+
+		for _, key := range []string{old.Key, replacement.Key} {
+			resp := client.WithAPIKey(key).Get(productsEndpoint)
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("should return 409 for revoked key", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+
+		resp := client.Delete(apiKeysEndpoint + "/" + key.Id)
+		assertions.AssertNoContent(resp)
+		resp.Body.Close()
+
+		resp = client.Post(apiKeysEndpoint+"/"+key.Id+"/rotate", nil)
+		assertions.AssertConflictWithMessage(resp, "Only active API keys can be rotated")
+	})
+
+	t.Run("should return 400 for too long grace period", func(t *testing.T) {
+		user := e2e.CreateTestUser(t, client)
+		key := e2e.CreateTestAPIKey(t, client, user.Id, api.ProductsRead)
+		grace := 8 * 24 * 3600
+
+		resp := client.Post(apiKeysEndpoint+"/"+key.Id+"/rotate", api.APIKeyRotate{GracePeriodSeconds: &grace})
+		assertions.AssertBadRequestWithMessage(resp, "validation error: grace_period_seconds - grace_period_seconds must be between 0 and 604800")
+	})
+}
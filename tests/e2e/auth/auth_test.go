@@ -0,0 +1,113 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const productsEndpoint = "/api/v1/products"
+
+func TestAuthentication(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewProductFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	assertUnauthorized := func(t *testing.T, resp *http.Response, message string) {
+		t.Helper()
+		assertions.AssertStatusCode(resp, http.StatusUnauthorized)
+		assert.Contains(t, resp.Header.Get("WWW-Authenticate"), "Bearer")
+		errorResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+		assert.Equal(t, message, errorResp.Error)
+	}
+
+	t.Run("should allow anonymous reads", func(t *testing.T) {
+		resp := client.Anonymous().Get(productsEndpoint)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 401 without token", func(t *testing.T) {
+		resp := client.Anonymous().Post(productsEndpoint, fixtures.ValidCreateRequest())
+		assertUnauthorized(t, resp, "Authentication required")
+	})
+
+	t.Run("should return 401 for non-bearer authorization", func(t *testing.T) {
+		resp := client.Anonymous().Post(productsEndpoint, fixtures.ValidCreateRequest(), e2e.WithHeader("Authorization", "Basic YWRtaW46YWRtaW4="))
+		assertUnauthorized(t, resp, "Authentication required")
+	})
+
+	t.Run("should return 401 for token signed with another secret", func(t *testing.T) {
+		token := e2e.SignToken(t, []byte("wrong-secret"), auth.Claims{
+			Subject:   "admin",
+			Roles:     []string{auth.RoleAdmin},
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		})
+		resp := client.Anonymous().Post(productsEndpoint, fixtures.ValidCreateRequest(), e2e.WithHeader("Authorization", "Bearer "+token))
+		assertUnauthorized(t, resp, "Invalid token")
+	})
+
+	t.Run("should return 401 for expired token", func(t *testing.T) {
+		token := e2e.SignToken(t, []byte(e2e.TestJWTSecret), auth.Claims{
+			Subject:   "admin",
+			Roles:     []string{auth.RoleAdmin},
+			ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+		})
+		resp := client.Anonymous().Post(productsEndpoint, fixtures.ValidCreateRequest(), e2e.WithHeader("Authorization", "Bearer "+token))
+		assertUnauthorized(t, resp, "Token has expired")
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewProductFixtures()
+	reviewFixtures := e2e.NewReviewFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should return 403 when customer manages products", func(t *testing.T) {
+		env.CleanupProducts(t)
+		productID := e2e.CreateTestProductWithoutCleanup(t, client)
+		customer := client.AsRoles("42", auth.RoleCustomer)
+
+		resp := customer.Post(productsEndpoint, fixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		errorResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+		assert.Equal(t, "Insufficient permissions", errorResp.Error)
+
+		resp = customer.Delete(productsEndpoint + "/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = customer.Post("/api/v1/categories", api.CategoryCreate{Name: "Electronics"})
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 403 when moderator creates review", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+
+		resp := client.AsRoles("moderator", auth.RoleModerator).Post(productsEndpoint+"/"+productID+"/reviews", reviewFixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("should let admin manage products", func(t *testing.T) {
+		env.CleanupProducts(t)
+
+		resp := client.Post(productsEndpoint, fixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusCreated)
+		resp.Body.Close()
+	})
+}
@@ -0,0 +1,305 @@
+package categories_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const categoriesEndpoint = "/api/v1/categories"
+
+func cleanup(t *testing.T, env *e2e.TestEnv) {
+	t.Helper()
+	env.CleanupProducts(t)
+	env.CleanupCategories(t)
+}
+
+func TestCreateCategory(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewCategoryFixtures()
+	assertions := e2e.NewCategoryAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should create root category", func(t *testing.T) {
+			cleanup(t, env)
+
+			req := fixtures.ValidCreateRequest("Electronics")
+			resp := client.Post(categoriesEndpoint, req)
+			category := assertions.AssertCategoryCreated(resp, req)
+
+			assert.Zero(t, category.ProductCount)
+			assert.Zero(t, category.ReviewCount)
+			assert.Nil(t, category.AverageRating)
+		})
+
+		t.Run("should create child category", func(t *testing.T) {
+			cleanup(t, env)
+
+			parentID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+
+			req := fixtures.ValidCreateRequestWithParent("Audio", parentID)
+			resp := client.Post(categoriesEndpoint, req)
+			assertions.AssertCategoryCreated(resp, req)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for empty name", func(t *testing.T) {
+			cleanup(t, env)
+
+			resp := client.Post(categoriesEndpoint, api.CategoryCreate{Name: ""})
+			assertions.AssertErrorWithMessage(resp, http.StatusBadRequest, "name")
+		})
+
+		t.Run("should return 400 for non-existing parent", func(t *testing.T) {
+			cleanup(t, env)
+
+			req := fixtures.ValidCreateRequestWithParent("Audio", "999999")
+			resp := client.Post(categoriesEndpoint, req)
+			assertions.AssertErrorWithMessage(resp, http.StatusBadRequest, "parent category does not exist")
+		})
+	})
+}
+
+func TestGetCategories(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewCategoryAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should list categories with pagination", func(t *testing.T) {
+			cleanup(t, env)
+
+			e2e.CreateTestCategory(t, client, "B", nil)
+			e2e.CreateTestCategory(t, client, "A", nil)
+			e2e.CreateTestCategory(t, client, "C", nil)
+
+			resp := client.Get(categoriesEndpoint + "?limit=2")
+			categories := assertions.AssertCategoriesList(resp, 2)
+			assert.Equal(t, "A", categories[0].Name)
+			assert.Equal(t, "B", categories[1].Name)
+
+			resp = client.Get(categoriesEndpoint + "?limit=2&offset=2")
+			categories = assertions.AssertCategoriesList(resp, 1)
+			assert.Equal(t, "C", categories[0].Name)
+		})
+
+		t.Run("should aggregate ratings across descendants", func(t *testing.T) {
+			cleanup(t, env)
+
+			rootID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+			childID := e2e.CreateTestCategory(t, client, "Audio", &rootID)
+
+			product1 := e2e.CreateTestProductWithoutCleanup(t, client)
+			product2 := e2e.CreateTestProductWithoutCleanup(t, client)
+			e2e.AssignTestProductCategories(t, client, product1, rootID)
+			e2e.AssignTestProductCategories(t, client, product2, childID)
+
+			e2e.CreateTestReviewWithRating(t, client, product1, 5)
+			e2e.CreateTestReviewWithRating(t, client, product2, 3)
+
+			root := assertions.AssertCategoryByID(client.Get(categoriesEndpoint+"/"+rootID), rootID)
+			assert.Equal(t, 2, root.ProductCount)
+			assert.Equal(t, 2, root.ReviewCount)
+			require.NotNil(t, root.AverageRating)
+			assert.InDelta(t, 4.0, *root.AverageRating, 0.01)
+
+			child := assertions.AssertCategoryByID(client.Get(categoriesEndpoint+"/"+childID), childID)
+			assert.Equal(t, 1, child.ProductCount)
+			require.NotNil(t, child.AverageRating)
+			assert.InDelta(t, 3.0, *child.AverageRating, 0.01)
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing category", func(t *testing.T) {
+			cleanup(t, env)
+
+			resp := client.Get(categoriesEndpoint + "/999999")
+			assertions.AssertErrorWithMessage(resp, http.StatusNotFound, "Category not found")
+		})
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewCategoryAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should rename and move category", func(t *testing.T) {
+			cleanup(t, env)
+
+			parentID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+			categoryID := e2e.CreateTestCategory(t, client, "Audio", nil)
+
+			resp := client.Put(categoriesEndpoint+"/"+categoryID, api.CategoryUpdate{
+				Name:     "Audio & Hi-Fi",
+				ParentId: &parentID,
+			})
+			category := assertions.AssertCategoryByID(resp, categoryID)
+			assert.Equal(t, "Audio & Hi-Fi", category.Name)
+			require.NotNil(t, category.ParentId)
+			assert.Equal(t, parentID, *category.ParentId)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 when moving category under its descendant", func(t *testing.T) {
+			cleanup(t, env)
+
+			rootID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+			childID := e2e.CreateTestCategory(t, client, "Audio", &rootID)
+
+			resp := client.Put(categoriesEndpoint+"/"+rootID, api.CategoryUpdate{
+				Name:     "Electronics",
+				ParentId: &childID,
+			})
+			assertions.AssertErrorWithMessage(resp, http.StatusBadRequest, "descendants")
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing category", func(t *testing.T) {
+			cleanup(t, env)
+
+			resp := client.Put(categoriesEndpoint+"/999999", api.CategoryUpdate{Name: "Name"})
+			assertions.AssertErrorWithMessage(resp, http.StatusNotFound, "Category not found")
+		})
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewCategoryAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should delete empty category", func(t *testing.T) {
+			cleanup(t, env)
+
+			categoryID := e2e.CreateTestCategory(t, client, "To Delete", nil)
+
+			assertions.AssertNoContent(client.Delete(categoriesEndpoint + "/" + categoryID))
+
+			resp := client.Get(categoriesEndpoint + "/" + categoryID)
+			assertions.AssertErrorWithMessage(resp, http.StatusNotFound, "Category not found")
+		})
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Run("should return 409 when category has products", func(t *testing.T) {
+			cleanup(t, env)
+
+			categoryID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+			productID := e2e.CreateTestProductWithoutCleanup(t, client)
+			e2e.AssignTestProductCategories(t, client, productID, categoryID)
+
+			resp := client.Delete(categoriesEndpoint + "/" + categoryID)
+			assertions.AssertErrorWithMessage(resp, http.StatusConflict, "Cannot delete category with existing products")
+		})
+
+		t.Run("should return 409 when category has subcategories", func(t *testing.T) {
+			cleanup(t, env)
+
+			rootID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+			e2e.CreateTestCategory(t, client, "Audio", &rootID)
+
+			resp := client.Delete(categoriesEndpoint + "/" + rootID)
+			assertions.AssertErrorWithMessage(resp, http.StatusConflict, "Cannot delete category with existing subcategories")
+		})
+	})
+}
+
+func TestProductCategories(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewCategoryAssertions(t)
+	productAssertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should assign and list product categories", func(t *testing.T) {
+			cleanup(t, env)
+
+			productID := e2e.CreateTestProductWithoutCleanup(t, client)
+			cat1 := e2e.CreateTestCategory(t, client, "Audio", nil)
+			cat2 := e2e.CreateTestCategory(t, client, "Books", nil)
+
+			e2e.AssignTestProductCategories(t, client, productID, cat1, cat2)
+
+			resp := client.Get("/api/v1/products/" + productID + "/categories")
+			categories := assertions.AssertCategoriesList(resp, 2)
+			assert.Equal(t, cat1, categories[0].Id)
+			assert.Equal(t, cat2, categories[1].Id)
+		})
+
+		t.Run("should filter products by category including descendants", func(t *testing.T) {
+			cleanup(t, env)
+
+			rootID := e2e.CreateTestCategory(t, client, "Electronics", nil)
+			childID := e2e.CreateTestCategory(t, client, "Audio", &rootID)
+			otherID := e2e.CreateTestCategory(t, client, "Books", nil)
+
+			product1 := e2e.CreateTestProductWithoutCleanup(t, client)
+			product2 := e2e.CreateTestProductWithoutCleanup(t, client)
+			product3 := e2e.CreateTestProductWithoutCleanup(t, client)
+			e2e.AssignTestProductCategories(t, client, product1, rootID)
+			e2e.AssignTestProductCategories(t, client, product2, childID)
+			e2e.AssignTestProductCategories(t, client, product3, otherID)
+
+			resp := client.Get("/api/v1/products?category=" + rootID)
+			products := productAssertions.AssertProductsListExact(resp, 2)
+			ids := []string{products[0].Id, products[1].Id}
+			assert.ElementsMatch(t, []string{product1, product2}, ids)
+
+			resp = client.Get("/api/v1/products?category=" + childID)
+			products = productAssertions.AssertProductsListExact(resp, 1)
+			assert.Equal(t, product2, products[0].Id)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for non-existing category", func(t *testing.T) {
+			cleanup(t, env)
+
+			productID := e2e.CreateTestProductWithoutCleanup(t, client)
+
+			resp := client.Put("/api/v1/products/"+productID+"/categories", api.ProductCategoriesUpdate{
+				CategoryIds: []string{"999999"},
+			})
+			assertions.AssertErrorWithMessage(resp, http.StatusBadRequest, "category 999999 does not exist")
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 when filtering by non-existing category", func(t *testing.T) {
+			cleanup(t, env)
+
+			resp := client.Get("/api/v1/products?category=999999")
+			assertions.AssertErrorWithMessage(resp, http.StatusNotFound, "Category not found")
+		})
+
+		t.Run("should return 404 for non-existing product", func(t *testing.T) {
+			cleanup(t, env)
+
+			resp := client.Get("/api/v1/products/999999/categories")
+			assertions.AssertErrorWithMessage(resp, http.StatusNotFound, "Product not found")
+		})
+	})
+}
@@ -1,7 +1,12 @@
 package e2e
 
 import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/money"
 )
 
 // ProductFixtures provides test data for product-related tests.
@@ -17,7 +22,7 @@ func (f *ProductFixtures) ValidCreateRequest() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Test Product",
 		Description: "A high-quality test product",
-		Price:       99.99,
+		Price:       money.MustParse("99.99"),
 	}
 }
 
@@ -26,12 +31,12 @@ func (f *ProductFixtures) ValidCreateRequestWithName(name string) api.ProductCre
 	return api.ProductCreate{
 		Name:        name,
 		Description: "A high-quality test product",
-		Price:       99.99,
+		Price:       money.MustParse("99.99"),
 	}
 }
 
 // ValidCreateRequestWithPrice returns a valid ProductCreate request with custom price.
-func (f *ProductFixtures) ValidCreateRequestWithPrice(price float32) api.ProductCreate {
+func (f *ProductFixtures) ValidCreateRequestWithPrice(price money.Amount) api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Test Product",
 		Description: "A high-quality test product",
@@ -39,12 +44,25 @@ func (f *ProductFixtures) ValidCreateRequestWithPrice(price float32) api.Product
 	}
 }
 
+// ValidCreateRequestWithTags returns a valid ProductCreate request with tags and attributes.
+func (f *ProductFixtures) ValidCreateRequestWithTags(tags []string, attributes map[string]interface{}) api.ProductCreate {
+	productTags := api.ProductTags(tags)
+	productAttributes := api.ProductAttributes(attributes)
+	return api.ProductCreate{
+		Name:        "Test Product",
+		Description: "A high-quality test product",
+		Price:       money.MustParse("99.99"),
+		Tags:        &productTags,
+		Attributes:  &productAttributes,
+	}
+}
+
 // CreateRequestWithEmptyName returns a ProductCreate request with empty name.
 func (f *ProductFixtures) CreateRequestWithEmptyName() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "",
 		Description: "A test product description",
-		Price:       99.99,
+		Price:       money.MustParse("99.99"),
 	}
 }
 
@@ -62,7 +80,7 @@ func (f *ProductFixtures) CreateRequestWithNegativePrice() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Test Product",
 		Description: "A test product description",
-		Price:       -10.00,
+		Price:       money.MustParse("-10.00"),
 	}
 }
 
@@ -75,7 +93,7 @@ func (f *ProductFixtures) CreateRequestWithLongName() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        string(longName),
 		Description: "A test product description",
-		Price:       99.99,
+		Price:       money.MustParse("99.99"),
 	}
 }
 
@@ -84,7 +102,7 @@ func (f *ProductFixtures) CreateRequestWithSpecialChars() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Product with 'quotes' & <special> \"chars\"",
 		Description: "Description with \n newlines \t and tabs",
-		Price:       99.99,
+		Price:       money.MustParse("99.99"),
 	}
 }
 
@@ -93,7 +111,7 @@ func (f *ProductFixtures) CreateRequestWithUnicode() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Product 产品 🎉",
 		Description: "Product description with emoji 😀",
-		Price:       199.99,
+		Price:       money.MustParse("199.99"),
 	}
 }
 
@@ -102,7 +120,7 @@ func (f *ProductFixtures) CreateRequestWithMinPrice() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Cheap Product",
 		Description: "A very affordable product",
-		Price:       0.01,
+		Price:       money.MustParse("0.01"),
 	}
 }
 
@@ -112,7 +130,7 @@ func (f *ProductFixtures) CreateRequestWithMaxPrice() api.ProductCreate {
 	return api.ProductCreate{
 		Name:        "Expensive Product",
 		Description: "A luxury product",
-		Price:       9999999.99,
+		Price:       money.MustParse("9999999.99"),
 	}
 }
 
@@ -121,12 +139,12 @@ func (f *ProductFixtures) ValidUpdateRequest() api.ProductUpdate {
 	return api.ProductUpdate{
 		Name:        "Updated Product",
 		Description: "An updated product description",
-		Price:       149.99,
+		Price:       money.MustParse("149.99"),
 	}
 }
 
 // ValidUpdateRequestWithData returns a ProductUpdate with custom data.
-func (f *ProductFixtures) ValidUpdateRequestWithData(name, desc string, price float32) api.ProductUpdate {
+func (f *ProductFixtures) ValidUpdateRequestWithData(name, desc string, price money.Amount) api.ProductUpdate {
 	return api.ProductUpdate{
 		Name:        name,
 		Description: desc,
@@ -139,7 +157,7 @@ func (f *ProductFixtures) UpdateRequestWithEmptyName() api.ProductUpdate {
 	return api.ProductUpdate{
 		Name:        "",
 		Description: "A test product description",
-		Price:       99.99,
+		Price:       money.MustParse("99.99"),
 	}
 }
 
@@ -157,7 +175,7 @@ func (f *ProductFixtures) UpdateRequestWithNegativePrice() api.ProductUpdate {
 	return api.ProductUpdate{
 		Name:        "Test Product",
 		Description: "A test product description",
-		Price:       -10.00,
+		Price:       money.MustParse("-10.00"),
 	}
 }
 
@@ -185,23 +203,10 @@ func (f *ReviewFixtures) ValidCreateRequestWithRating(rating int) api.ReviewCrea
 
 // ValidCreateRequestFull returns a valid ReviewCreate request with all fields.
 func (f *ReviewFixtures) ValidCreateRequestFull() api.ReviewCreate {
-	firstName := "John"
-	lastName := "Doe"
 	comment := "Great product! Highly recommended."
 	return api.ReviewCreate{
-		Rating:    5,
-		FirstName: &firstName,
-		LastName:  &lastName,
-		Comment:   &comment,
-	}
-}
-
-// ValidCreateRequestWithAuthor returns a valid ReviewCreate request with first and last name.
-func (f *ReviewFixtures) ValidCreateRequestWithAuthor(firstName, lastName string) api.ReviewCreate {
-	return api.ReviewCreate{
-		Rating:    4,
-		FirstName: &firstName,
-		LastName:  &lastName,
+		Rating:  5,
+		Comment: &comment,
 	}
 }
 
@@ -243,14 +248,10 @@ func (f *ReviewFixtures) ValidUpdateRequest() api.ReviewUpdate {
 
 // ValidUpdateRequestFull returns a valid ReviewUpdate request with all fields.
 func (f *ReviewFixtures) ValidUpdateRequestFull() api.ReviewUpdate {
-	firstName := "Jane"
-	lastName := "Doe"
 	comment := "Updated review - still great!"
 	return api.ReviewUpdate{
-		Rating:    4,
-		FirstName: &firstName,
-		LastName:  &lastName,
-		Comment:   &comment,
+		Rating:  4,
+		Comment: &comment,
 	}
 }
 
@@ -270,26 +271,63 @@ func (f *ReviewFixtures) UpdateRequestWithRatingAboveMax() api.ReviewUpdate {
 
 // CreateRequestWithUnicode returns a ReviewCreate request with unicode characters.
 func (f *ReviewFixtures) CreateRequestWithUnicode() api.ReviewCreate {
-	firstName := "Ivan"
-	lastName := "Petrov"
 	comment := "Great product! 五星好评 🌟"
 	return api.ReviewCreate{
-		Rating:    5,
-		FirstName: &firstName,
-		LastName:  &lastName,
-		Comment:   &comment,
+		Rating:  5,
+		Comment: &comment,
 	}
 }
 
 // CreateRequestWithSpecialChars returns a ReviewCreate request with special characters.
 func (f *ReviewFixtures) CreateRequestWithSpecialChars() api.ReviewCreate {
-	firstName := "John 'The Reviewer'"
-	lastName := "Doe"
 	comment := "Great <product> with \"quotes\" & special chars!"
 	return api.ReviewCreate{
-		Rating:    5,
-		FirstName: &firstName,
-		LastName:  &lastName,
-		Comment:   &comment,
+		Rating:  5,
+		Comment: &comment,
+	}
+}
+
+// CategoryFixtures provides test data for category-related tests.
+type CategoryFixtures struct{}
+
+// NewCategoryFixtures creates a new CategoryFixtures instance.
+func NewCategoryFixtures() *CategoryFixtures {
+	return &CategoryFixtures{}
+}
+
+// ValidCreateRequest returns a valid root CategoryCreate request.
+func (f *CategoryFixtures) ValidCreateRequest(name string) api.CategoryCreate {
+	description := "A test category"
+	return api.CategoryCreate{
+		Name:        name,
+		Description: &description,
+	}
+}
+
+// ValidCreateRequestWithParent returns a valid CategoryCreate request nested under parentID.
+func (f *CategoryFixtures) ValidCreateRequestWithParent(name, parentID string) api.CategoryCreate {
+	return api.CategoryCreate{
+		Name:     name,
+		ParentId: &parentID,
+	}
+}
+
+// userSeq makes generated emails unique within a test run.
+var userSeq atomic.Int64
+
+// UserFixtures provides test data for user-related tests.
+type UserFixtures struct{}
+
+// NewUserFixtures creates a new UserFixtures instance.
+func NewUserFixtures() *UserFixtures {
+	return &UserFixtures{}
+}
+
+// ValidCreateRequest returns a valid UserCreate request with a unique email.
+func (f *UserFixtures) ValidCreateRequest() api.UserCreate {
+	return api.UserCreate{
+		Email:     fmt.Sprintf("user-%d@example.com", userSeq.Add(1)+time.Now().UnixNano()),
+		FirstName: "John",
+		LastName:  "Doe",
 	}
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	appmw "product_review_hub/internal/middleware"
+	"product_review_hub/internal/tenant"
 )
 
 // RequestOption is a function that modifies an HTTP request.
@@ -30,18 +33,87 @@ func WithContentType(contentType string) RequestOption {
 }
 
 // HTTPClient provides helper methods for making HTTP requests in tests.
+// Requests carry the bearer token or API key of the client, if any.
 type HTTPClient struct {
 	t       *testing.T
 	client  *http.Client
 	baseURL string
+	token   string
+	apiKey  string
+	tenant  string
 }
 
-// NewHTTPClient creates a new test HTTP client.
+// NewHTTPClient creates a new test HTTP client authenticated as an admin, so catalog
+// setup calls pass authorization. Use As, AsRoles or Anonymous to act as someone else.
 func NewHTTPClient(t *testing.T, env *TestEnv) *HTTPClient {
 	return &HTTPClient{
 		t:       t,
 		client:  env.Client,
 		baseURL: env.BaseURL,
+		token:   Token(t, "admin", auth.RoleAdmin),
+	}
+}
+
+// As returns a copy of the client that acts as the given user with the customer role.
+func (c *HTTPClient) As(userID string) *HTTPClient {
+	return c.AsRoles(userID, auth.RoleCustomer)
+}
+
+// AsRoles returns a copy of the client that acts as the given subject with the given roles.
+func (c *HTTPClient) AsRoles(subject string, roles ...string) *HTTPClient {
+	clone := *c
+	clone.token = Token(c.t, subject, roles...)
+	return &clone
+}
+
+// AsNewUser creates a fresh user and returns a copy of the client acting as them.
+func (c *HTTPClient) AsNewUser() *HTTPClient {
+	c.t.Helper()
+	return c.As(CreateTestUser(c.t, c).Id)
+}
+
+// Anonymous returns a copy of the client that sends no bearer token.
+func (c *HTTPClient) Anonymous() *HTTPClient {
+	clone := *c
+	clone.token = ""
+	clone.apiKey = ""
+	return &clone
+}
+
+// WithAPIKey returns a copy of the client that authenticates with the given API key only.
+func (c *HTTPClient) WithAPIKey(key string) *HTTPClient {
+	clone := c.Anonymous()
+	clone.apiKey = key
+	return clone
+}
+
+// WithToken returns a copy of the client that authenticates with the given bearer token.
+func (c *HTTPClient) WithToken(token string) *HTTPClient {
+	clone := c.Anonymous()
+	clone.token = token
+	return clone
+}
+
+// ForTenant returns a copy of the client whose requests name the given tenant.
+func (c *HTTPClient) ForTenant(id string) *HTTPClient {
+	clone := *c
+	clone.tenant = id
+	return &clone
+}
+
+// apply sets the bearer token or API key and the tenant and then runs the per-request options.
+func (c *HTTPClient) apply(req *http.Request, opts []RequestOption) {
+	if c.tenant != "" {
+		req.Header.Set(tenant.Header, c.tenant)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(appmw.APIKeyHeader, c.apiKey)
+	}
+	for _, opt := range opts {
+		opt(req)
 	}
 }
 
@@ -57,9 +129,7 @@ func (c *HTTPClient) Post(path string, body interface{}, opts ...RequestOption)
 
 	req.Header.Set("Content-Type", "application/json")
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	c.apply(req, opts)
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err, "Failed to send request")
@@ -74,9 +144,7 @@ func (c *HTTPClient) PostRaw(path string, body []byte, opts ...RequestOption) *h
 	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewBuffer(body))
 	require.NoError(c.t, err, "Failed to create request")
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	c.apply(req, opts)
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err, "Failed to send request")
@@ -91,9 +159,7 @@ func (c *HTTPClient) Get(path string, opts ...RequestOption) *http.Response {
 	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
 	require.NoError(c.t, err, "Failed to create request")
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	c.apply(req, opts)
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err, "Failed to send request")
@@ -113,9 +179,7 @@ func (c *HTTPClient) Put(path string, body interface{}, opts ...RequestOption) *
 
 	req.Header.Set("Content-Type", "application/json")
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	c.apply(req, opts)
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err, "Failed to send request")
@@ -130,9 +194,7 @@ func (c *HTTPClient) PutRaw(path string, body []byte, opts ...RequestOption) *ht
 	req, err := http.NewRequest(http.MethodPut, c.baseURL+path, bytes.NewBuffer(body))
 	require.NoError(c.t, err, "Failed to create request")
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	c.apply(req, opts)
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err, "Failed to send request")
@@ -147,9 +209,7 @@ func (c *HTTPClient) Delete(path string, opts ...RequestOption) *http.Response {
 	req, err := http.NewRequest(http.MethodDelete, c.baseURL+path, nil)
 	require.NoError(c.t, err, "Failed to create request")
 
-	for _, opt := range opts {
-		opt(req)
-	}
+	c.apply(req, opts)
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err, "Failed to send request")
@@ -198,10 +258,27 @@ func CreateTestProduct(t *testing.T, env *TestEnv, client *HTTPClient) string {
 	return product.Id
 }
 
+// CreateTestUser creates a user with a unique email and returns it.
+func CreateTestUser(t *testing.T, client *HTTPClient) api.User {
+	t.Helper()
+	resp := client.Post("/api/v1/users", NewUserFixtures().ValidCreateRequest())
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create user")
+	return ParseJSON[api.User](t, resp)
+}
+
+// CreateTestAPIKey issues an API key for the user with the given scopes and returns it.
+func CreateTestAPIKey(t *testing.T, client *HTTPClient, userID string, scopes ...api.APIKeyScope) api.APIKeyIssued {
+	t.Helper()
+	resp := client.Post("/api/v1/api-keys", api.APIKeyCreate{UserId: userID, Name: "Test client", Scopes: scopes})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create API key")
+	return ParseJSON[api.APIKeyIssued](t, resp)
+}
+
 // createTestReviewWithRequest is an internal helper that creates a review with the provided request.
+// Each review is written by a freshly created user, since a user may review a product only once.
 func createTestReviewWithRequest(t *testing.T, client *HTTPClient, productID string, req interface{}) api.Review {
 	t.Helper()
-	resp := client.Post("/api/v1/products/"+productID+"/reviews", req)
+	resp := client.AsNewUser().Post("/api/v1/products/"+productID+"/reviews", req)
 	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create review")
 	return ParseJSON[api.Review](t, resp)
 }
@@ -236,3 +313,29 @@ func CreateTestProductWithoutCleanup(t *testing.T, client *HTTPClient) string {
 	product := ParseJSON[api.Product](t, resp)
 	return product.Id
 }
+
+// CreateTestCategory creates a category, optionally under parentID, and returns its ID.
+func CreateTestCategory(t *testing.T, client *HTTPClient, name string, parentID *string) string {
+	t.Helper()
+	req := api.CategoryCreate{Name: name, ParentId: parentID}
+	resp := client.Post("/api/v1/categories", req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create category")
+	category := ParseJSON[api.Category](t, resp)
+	return category.Id
+}
+
+// AssignTestProductCategories replaces the categories of a product.
+func AssignTestProductCategories(t *testing.T, client *HTTPClient, productID string, categoryIDs ...string) {
+	t.Helper()
+	resp := client.Put("/api/v1/products/"+productID+"/categories", api.ProductCategoriesUpdate{CategoryIds: categoryIDs})
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Failed to assign product categories")
+	resp.Body.Close()
+}
+
+// CreateTestVariant creates a variant of a product with the given SKU and returns it.
+func CreateTestVariant(t *testing.T, client *HTTPClient, productID, sku string) api.ProductVariant {
+	t.Helper()
+	resp := client.Post("/api/v1/products/"+productID+"/variants", api.ProductVariantCreate{Sku: sku})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create variant")
+	return ParseJSON[api.ProductVariant](t, resp)
+}
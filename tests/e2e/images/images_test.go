@@ -0,0 +1,191 @@
+package images_test
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func imagesEndpoint(productID string) string {
+	return "/api/v1/products/" + productID + "/images"
+}
+
+// uploadImage uploads data as the image of a product.
+func uploadImage(client *e2e.HTTPClient, t *testing.T, productID string, data []byte, fields map[string]string) *http.Response {
+	t.Helper()
+	body, contentType := e2e.MultipartBody(t, fields, e2e.UploadFile{Field: "file", FileName: "image.png", Data: data})
+	return client.PostRaw(imagesEndpoint(productID), body, e2e.WithContentType(contentType))
+}
+
+// fetchImage downloads an image by URL and decodes its dimensions.
+func fetchImage(client *e2e.HTTPClient, t *testing.T, url string) image.Config {
+	t.Helper()
+	resp := client.Get(url)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestProductImages(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should upload image with thumbnail", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := uploadImage(client, t, productID, e2e.TestPNG(t, 640, 480), map[string]string{"alt_text": "Front view"})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			productImage := e2e.ParseJSON[api.ProductImage](t, resp)
+
+			assert.Equal(t, productID, productImage.ProductId)
+			assert.Equal(t, "image/png", productImage.ContentType)
+			assert.Equal(t, 640, productImage.Width)
+			assert.Equal(t, 480, productImage.Height)
+			assert.Equal(t, 1, productImage.Position)
+			require.NotNil(t, productImage.AltText)
+			assert.Equal(t, "Front view", *productImage.AltText)
+
+			original := fetchImage(client, t, productImage.Url)
+			assert.Equal(t, 640, original.Width)
+
+			thumbnail := fetchImage(client, t, productImage.ThumbnailUrl)
+			assert.Equal(t, 320, thumbnail.Width)
+			assert.Equal(t, 240, thumbnail.Height)
+		})
+
+		t.Run("should expose images on product in order", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			var ids []string
+			for i := 0; i < 3; i++ {
+				resp := uploadImage(client, t, productID, e2e.TestPNG(t, 10, 10), nil)
+				assertions.AssertStatusCode(resp, http.StatusCreated)
+				ids = append(ids, e2e.ParseJSON[api.ProductImage](t, resp).Id)
+			}
+
+			resp := client.Put(imagesEndpoint(productID)+"/order", api.ProductImageOrder{ImageIds: []string{ids[2], ids[0], ids[1]}})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			reordered := e2e.ParseJSON[[]api.ProductImage](t, resp)
+			require.Len(t, reordered, 3)
+			assert.Equal(t, ids[2], reordered[0].Id)
+
+			resp = client.Get("/api/v1/products/" + productID)
+			product := assertions.AssertProductByID(resp, productID)
+			require.Len(t, product.Images, 3)
+			assert.Equal(t, []string{ids[2], ids[0], ids[1]}, []string{product.Images[0].Id, product.Images[1].Id, product.Images[2].Id})
+
+			resp = client.Get("/api/v1/products")
+			productsList := assertions.AssertProductsListExact(resp, 1)
+			assert.Len(t, productsList[0].Images, 3)
+		})
+
+		t.Run("should delete image and its files", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := uploadImage(client, t, productID, e2e.TestPNG(t, 10, 10), nil)
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			productImage := e2e.ParseJSON[api.ProductImage](t, resp)
+
+			resp = client.Delete(imagesEndpoint(productID) + "/" + productImage.Id)
+			assertions.AssertNoContent(resp)
+
+			resp = client.Get(productImage.Url)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+			resp = client.Get(imagesEndpoint(productID))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			assert.Empty(t, e2e.ParseJSON[[]api.ProductImage](t, resp))
+		})
+
+		t.Run("should remove files when product is deleted", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := uploadImage(client, t, productID, e2e.TestPNG(t, 10, 10), nil)
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			productImage := e2e.ParseJSON[api.ProductImage](t, resp)
+
+			resp = client.Delete("/api/v1/products/" + productID)
+			assertions.AssertNoContent(resp)
+
+			for _, url := range []string{productImage.Url, productImage.ThumbnailUrl} {
+				resp = client.Get(url)
+				resp.Body.Close()
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			}
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for unsupported type", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := uploadImage(client, t, productID, []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), nil)
+			assertions.AssertBadRequestWithMessage(resp, "validation error: file - only JPEG, PNG and GIF images are supported")
+		})
+
+		t.Run("should return 400 for missing file", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			body, contentType := e2e.MultipartBody(t, map[string]string{"alt_text": "No file"})
+			resp := client.PostRaw(imagesEndpoint(productID), body, e2e.WithContentType(contentType))
+			assertions.AssertBadRequestWithMessage(resp, "validation error: file - file is required")
+		})
+
+		t.Run("should return 413 for too large file", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := uploadImage(client, t, productID, make([]byte, 6<<20), nil)
+			assertions.AssertStatusCode(resp, http.StatusRequestEntityTooLarge)
+			resp.Body.Close()
+		})
+
+		t.Run("should return 400 for incomplete order", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := uploadImage(client, t, productID, e2e.TestPNG(t, 10, 10), nil)
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			resp.Body.Close()
+
+			resp = client.Put(imagesEndpoint(productID)+"/order", api.ProductImageOrder{ImageIds: []string{}})
+			assertions.AssertBadRequestWithMessage(resp, "validation error: image_ids - image_ids must list every image of the product exactly once")
+		})
+
+		t.Run("should return 404 for non-existent product", func(t *testing.T) {
+			resp := uploadImage(client, t, "999999", e2e.TestPNG(t, 10, 10), nil)
+			assertions.AssertNotFoundWithMessage(resp, "Product not found")
+		})
+	})
+}
@@ -0,0 +1,35 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env).Anonymous()
+
+	t.Run("should expose request latency by route pattern and status", func(t *testing.T) {
+		resp := client.Get("/api/v1/products/999999")
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = client.Get("/metrics")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `product_review_hub_http_request_duration_seconds_count{method="GET",route="/api/v1/products/{productId}",status="404"}`)
+	})
+
+}
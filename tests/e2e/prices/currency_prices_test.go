@@ -0,0 +1,141 @@
+package prices_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/money"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func currencyPricesEndpoint(productID string) string {
+	return "/api/v1/products/" + productID + "/prices"
+}
+
+func TestProductCurrency(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+	fixtures := e2e.NewProductFixtures()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should default to USD", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Post("/api/v1/products", fixtures.ValidCreateRequest())
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			product := e2e.ParseJSON[api.Product](t, resp)
+
+			assert.Equal(t, "USD", product.Currency)
+		})
+
+		t.Run("should create and update product in another currency", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			req := fixtures.ValidCreateRequest()
+			eur := "eur"
+			req.Currency = &eur
+			resp := client.Post("/api/v1/products", req)
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			product := e2e.ParseJSON[api.Product](t, resp)
+			assert.Equal(t, "EUR", product.Currency)
+
+			// Updates without a currency keep the stored one
+			resp = client.Put("/api/v1/products/"+product.Id, fixtures.ValidUpdateRequest())
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			updated := e2e.ParseJSON[api.Product](t, resp)
+			assert.Equal(t, "EUR", updated.Currency)
+
+			resp = client.Get(priceHistoryEndpoint(product.Id))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			history := e2e.ParseJSON[[]api.PriceHistoryEntry](t, resp)
+			require.NotEmpty(t, history)
+			assert.Equal(t, "EUR", history[0].Currency)
+		})
+
+		t.Run("should convert prices to the requested currency", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			// 99.99 USD at a rate of 0.5 rounds half away from zero
+			resp := client.Get("/api/v1/products/" + productID + "?currency=EUR")
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			product := e2e.ParseJSON[api.Product](t, resp)
+			assert.Equal(t, "EUR", product.Currency)
+			assert.Equal(t, money.MustParse("50.00"), product.Price)
+
+			resp = client.Get("/api/v1/products?currency=GBP")
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productsList := e2e.ParseJSON[[]api.Product](t, resp)
+			require.Len(t, productsList, 1)
+			assert.Equal(t, "GBP", productsList[0].Currency)
+			assert.Equal(t, money.MustParse("79.99"), productsList[0].Price)
+		})
+
+		t.Run("should prefer explicit currency price over conversion", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Put(currencyPricesEndpoint(productID)+"/EUR", api.CurrencyPriceUpdate{Price: money.MustParse("45.00")})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			currencyPrice := e2e.ParseJSON[api.CurrencyPrice](t, resp)
+			assert.Equal(t, "EUR", currencyPrice.Currency)
+			assert.Equal(t, money.MustParse("45.00"), currencyPrice.Price)
+
+			resp = client.Get("/api/v1/products/" + productID + "?currency=EUR")
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			product := e2e.ParseJSON[api.Product](t, resp)
+			assert.Equal(t, money.MustParse("45.00"), product.Price)
+
+			resp = client.Get(currencyPricesEndpoint(productID))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			currencyPrices := e2e.ParseJSON[[]api.CurrencyPrice](t, resp)
+			require.Len(t, currencyPrices, 1)
+
+			resp = client.Delete(currencyPricesEndpoint(productID) + "/EUR")
+			assertions.AssertNoContent(resp)
+
+			resp = client.Get("/api/v1/products/" + productID + "?currency=EUR")
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			product = e2e.ParseJSON[api.Product](t, resp)
+			assert.Equal(t, money.MustParse("50.00"), product.Price)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for unsupported currency", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Get("/api/v1/products/" + productID + "?currency=JPY")
+			assertions.AssertBadRequestWithMessage(resp, "validation error: currency - currency is not supported")
+		})
+
+		t.Run("should return 400 for currency price in the product currency", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Put(currencyPricesEndpoint(productID)+"/USD", api.CurrencyPriceUpdate{Price: money.MustParse("10.00")})
+			assertions.AssertBadRequestWithMessage(resp, "validation error: currency - currency must differ from the product currency")
+		})
+
+		t.Run("should return 404 for missing currency price", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Delete(currencyPricesEndpoint(productID) + "/EUR")
+			assertions.AssertNotFoundWithMessage(resp, "Currency price not found")
+		})
+	})
+}
@@ -0,0 +1,251 @@
+package prices_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/money"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func priceHistoryEndpoint(productID string) string {
+	return "/api/v1/products/" + productID + "/price-history"
+}
+
+func scheduledPricesEndpoint(productID string) string {
+	return "/api/v1/products/" + productID + "/scheduled-prices"
+}
+
+func TestGetProductPriceHistory(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+	fixtures := e2e.NewProductFixtures()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should record initial price and every price change", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Put("/api/v1/products/"+productID, fixtures.ValidUpdateRequestWithData("Test Product", "Updated", money.MustParse("89.99")))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			// Same price again does not create a history entry
+			resp = client.Put("/api/v1/products/"+productID, fixtures.ValidUpdateRequestWithData("Renamed", "Updated", money.MustParse("89.99")))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			resp = client.Get(priceHistoryEndpoint(productID))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			history := e2e.ParseJSON[[]api.PriceHistoryEntry](t, resp)
+
+			require.Len(t, history, 2)
+			assert.Equal(t, money.MustParse("89.99"), history[0].Price)
+			assert.True(t, history[0].IsCurrent)
+			assert.Equal(t, money.MustParse("99.99"), history[1].Price)
+			assert.False(t, history[1].IsCurrent)
+		})
+
+		t.Run("should paginate history", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			for _, price := range []money.Amount{money.MustParse("10"), money.MustParse("20"), money.MustParse("30")} {
+				resp := client.Put("/api/v1/products/"+productID, fixtures.ValidUpdateRequestWithData("Test Product", "Updated", price))
+				assertions.AssertStatusCode(resp, http.StatusOK)
+				resp.Body.Close()
+			}
+
+			resp := client.Get(priceHistoryEndpoint(productID) + "?limit=2&offset=1")
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			history := e2e.ParseJSON[[]api.PriceHistoryEntry](t, resp)
+
+			require.Len(t, history, 2)
+			assert.Equal(t, money.MustParse("20"), history[0].Price)
+			assert.Equal(t, money.MustParse("10"), history[1].Price)
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing product", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Get(priceHistoryEndpoint("999999"))
+			assertions.AssertNotFoundWithMessage(resp, "Product not found")
+		})
+	})
+}
+
+func TestCreateScheduledPrice(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should apply price in effect at read time", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			effectiveTo := time.Now().Add(time.Hour)
+
+			resp := client.Post(scheduledPricesEndpoint(productID), api.ScheduledPriceCreate{
+				Price:         money.MustParse("79.99"),
+				EffectiveFrom: time.Now().Add(-time.Minute),
+				EffectiveTo:   &effectiveTo,
+			})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			scheduled := e2e.ParseJSON[api.PriceHistoryEntry](t, resp)
+			assert.True(t, scheduled.IsCurrent)
+
+			product := assertions.AssertProductByID(client.Get("/api/v1/products/"+productID), productID)
+			assert.Equal(t, money.MustParse("79.99"), product.Price)
+		})
+
+		t.Run("should keep current price until a future change starts", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(scheduledPricesEndpoint(productID), api.ScheduledPriceCreate{
+				Price:         money.MustParse("59.99"),
+				EffectiveFrom: time.Now().Add(24 * time.Hour),
+			})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			scheduled := e2e.ParseJSON[api.PriceHistoryEntry](t, resp)
+
+			assert.NotEmpty(t, scheduled.Id)
+			assert.Equal(t, productID, scheduled.ProductId)
+			assert.Equal(t, money.MustParse("59.99"), scheduled.Price)
+			assert.Nil(t, scheduled.EffectiveTo)
+			assert.False(t, scheduled.IsCurrent)
+
+			product := assertions.AssertProductByID(client.Get("/api/v1/products/"+productID), productID)
+			assert.Equal(t, money.MustParse("99.99"), product.Price)
+
+			resp = client.Get(priceHistoryEndpoint(productID))
+			history := e2e.ParseJSON[[]api.PriceHistoryEntry](t, resp)
+			require.Len(t, history, 2)
+			assert.Equal(t, scheduled.Id, history[0].Id)
+			assert.True(t, history[1].IsCurrent)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for non-positive price", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(scheduledPricesEndpoint(productID), api.ScheduledPriceCreate{
+				Price:         0,
+				EffectiveFrom: time.Now().Add(time.Hour),
+			})
+			assertions.AssertBadRequestWithMessage(resp, "price must be greater than 0")
+		})
+
+		t.Run("should return 400 when effective_to is before effective_from", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			effectiveTo := time.Now().Add(time.Hour)
+
+			resp := client.Post(scheduledPricesEndpoint(productID), api.ScheduledPriceCreate{
+				Price:         money.MustParse("10"),
+				EffectiveFrom: time.Now().Add(2 * time.Hour),
+				EffectiveTo:   &effectiveTo,
+			})
+			assertions.AssertBadRequestWithMessage(resp, "effective_to must be after effective_from")
+		})
+
+		t.Run("should return 400 when effective_to is in the past", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			effectiveTo := time.Now().Add(-time.Hour)
+
+			resp := client.Post(scheduledPricesEndpoint(productID), api.ScheduledPriceCreate{
+				Price:         money.MustParse("10"),
+				EffectiveFrom: time.Now().Add(-2 * time.Hour),
+				EffectiveTo:   &effectiveTo,
+			})
+			assertions.AssertBadRequestWithMessage(resp, "effective_to must be in the future")
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing product", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Post(scheduledPricesEndpoint("999999"), api.ScheduledPriceCreate{
+				Price:         money.MustParse("10"),
+				EffectiveFrom: time.Now().Add(time.Hour),
+			})
+			assertions.AssertNotFoundWithMessage(resp, "Product not found")
+		})
+	})
+}
+
+func TestDeleteScheduledPrice(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should cancel a future price change", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			resp := client.Post(scheduledPricesEndpoint(productID), api.ScheduledPriceCreate{
+				Price:         money.MustParse("59.99"),
+				EffectiveFrom: time.Now().Add(time.Hour),
+			})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			scheduled := e2e.ParseJSON[api.PriceHistoryEntry](t, resp)
+
+			resp = client.Delete(scheduledPricesEndpoint(productID) + "/" + scheduled.Id)
+			assertions.AssertNoContent(resp)
+
+			resp = client.Get(priceHistoryEndpoint(productID))
+			history := e2e.ParseJSON[[]api.PriceHistoryEntry](t, resp)
+			assert.Len(t, history, 1)
+		})
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Run("should return 409 for a price already in effect", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			resp := client.Get(priceHistoryEndpoint(productID))
+			history := e2e.ParseJSON[[]api.PriceHistoryEntry](t, resp)
+			require.Len(t, history, 1)
+
+			resp = client.Delete(scheduledPricesEndpoint(productID) + "/" + history[0].Id)
+			assertions.AssertConflictWithMessage(resp, "Cannot cancel a price change that has already taken effect")
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing price", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Delete(scheduledPricesEndpoint(productID) + "/999999")
+			assertions.AssertNotFoundWithMessage(resp, "Scheduled price not found")
+		})
+	})
+}
@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/money"
 	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
 )
 
 const productsEndpoint = "/api/v1/products"
@@ -116,6 +119,13 @@ func TestCreateProduct(t *testing.T) {
 
 			assertions.AssertBadRequestWithMessage(resp, "price")
 		})
+
+		t.Run("should return 400 for price above the column range", func(t *testing.T) {
+			req := fixtures.ValidCreateRequestWithPrice(money.MaxAmount + 1)
+			resp := client.Post(productsEndpoint, req)
+
+			assertions.AssertBadRequestWithMessage(resp, "price")
+		})
 	})
 
 	t.Run("Invalid Request Body", func(t *testing.T) {
@@ -144,6 +154,15 @@ func TestCreateProduct(t *testing.T) {
 			assertions.AssertBadRequest(resp)
 		})
 
+		t.Run("should return 400 for prices that are not plain decimals", func(t *testing.T) {
+			for _, price := range []string{`"1/4"`, `"0x10"`, `1e2`} {
+				body := []byte(`{"name": "Test", "description": "Test", "price": ` + price + `}`)
+				resp := client.PostRaw(productsEndpoint, body, e2e.WithContentType("application/json"))
+
+				assertions.AssertBadRequest(resp)
+			}
+		})
+
 		t.Run("should return 400 for missing required fields", func(t *testing.T) {
 			body := []byte(`{"description": "Only description"}`)
 			resp := client.PostRaw(productsEndpoint, body, e2e.WithContentType("application/json"))
@@ -179,7 +198,7 @@ func TestCreateProduct(t *testing.T) {
 			req := api.ProductCreate{
 				Name:        "Product without description",
 				Description: "",
-				Price:       50.00,
+				Price:       money.MustParse("50.00"),
 			}
 			resp := client.Post(productsEndpoint, req)
 
@@ -187,23 +206,22 @@ func TestCreateProduct(t *testing.T) {
 			assertions.AssertProductCreated(resp, req)
 		})
 
-		t.Run("should handle float precision in price", func(t *testing.T) {
+		t.Run("should preserve decimal price exactly", func(t *testing.T) {
 			env.CleanupProducts(t)
 
-			req := api.ProductCreate{
-				Name:        "Precision Test Product",
-				Description: "Testing float precision",
-				Price:       123.456789,
-			}
-			resp := client.Post(productsEndpoint, req)
+			body := []byte(`{"name": "Precision Test Product", "description": "Testing decimal precision", "price": 19.99}`)
+			resp := client.PostRaw(productsEndpoint, body, e2e.WithContentType("application/json"))
+			assertions.AssertStatusCode(resp, http.StatusCreated)
 
-			if resp.StatusCode == http.StatusCreated {
-				product := e2e.ParseJSON[api.Product](t, resp)
-				// Price should be stored with reasonable precision
-				if product.Price < 123.0 || product.Price > 124.0 {
-					t.Errorf("Price was not preserved correctly: got %f", product.Price)
-				}
-			}
+			raw := e2e.ReadBody(t, resp)
+			assert.Contains(t, raw, `"price":19.99`)
+		})
+
+		t.Run("should reject price with more than two decimal places", func(t *testing.T) {
+			body := []byte(`{"name": "Precision Test Product", "description": "Testing decimal precision", "price": 123.456789}`)
+			resp := client.PostRaw(productsEndpoint, body, e2e.WithContentType("application/json"))
+
+			assertions.AssertBadRequest(resp)
 		})
 	})
 }
@@ -250,7 +268,7 @@ func TestCreateProductConcurrency(t *testing.T) {
 		for i := 0; i < numProducts; i++ {
 			go func(index int) {
 				client := e2e.NewHTTPClient(t, env)
-				req := fixtures.ValidCreateRequestWithPrice(float32(100 + index))
+				req := fixtures.ValidCreateRequestWithPrice(money.FromMinor(int64(100+index) * money.Scale))
 				resp := client.Post(productsEndpoint, req)
 				results <- resp
 			}(i)
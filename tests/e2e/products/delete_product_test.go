@@ -119,7 +119,7 @@ func TestDeleteProduct(t *testing.T) {
 			assertions.AssertConflictWithMessage(failResp, "Cannot delete product with existing reviews")
 
 			// Delete review
-			deleteReviewResp := client.Delete(productsEndpoint + "/" + productID + "/reviews/" + review.Id)
+			deleteReviewResp := client.As(*review.UserId).Delete(productsEndpoint + "/" + productID + "/reviews/" + review.Id)
 			require.Equal(t, http.StatusNoContent, deleteReviewResp.StatusCode)
 
 			// Now delete product (should succeed)
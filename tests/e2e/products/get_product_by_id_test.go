@@ -33,7 +33,8 @@ func TestGetProductById(t *testing.T) {
 			product := assertions.AssertProductByID(resp, createdProduct.Id)
 			assert.Equal(t, req.Name, product.Name)
 			assert.Equal(t, req.Description, product.Description)
-			assert.InDelta(t, req.Price, product.Price, 0.01)
+			assert.Equal(t, req.Price, product.Price)
+			assert.Regexp(t, `^private, max-age=\d+$`, resp.Header.Get("Cache-Control"), "Tenant-specific payloads must not be stored by shared caches")
 		})
 
 		t.Run("should return product with nil average_rating when no reviews", func(t *testing.T) {
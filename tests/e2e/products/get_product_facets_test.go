@@ -0,0 +1,136 @@
+package products_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProductsByTagsAndAttributes(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewProductFixtures()
+	assertions := e2e.NewProductAssertions(t)
+
+	createProduct := func(t *testing.T, tags []string, attributes map[string]interface{}) string {
+		t.Helper()
+		resp := client.Post(productsEndpoint, fixtures.ValidCreateRequestWithTags(tags, attributes))
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		return e2e.ParseJSON[api.Product](t, resp).Id
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should return tags and attributes", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			req := fixtures.ValidCreateRequestWithTags(
+				[]string{" wireless ", "wireless", "sale"},
+				map[string]interface{}{"color": "red", "battery": 30, "waterproof": true},
+			)
+			resp := client.Post(productsEndpoint, req)
+			product := assertions.AssertProductCreated(resp, req)
+
+			assert.Equal(t, api.ProductTags{"wireless", "sale"}, product.Tags)
+			assert.Equal(t, api.ProductAttributes{"color": "red", "battery": float64(30), "waterproof": true}, product.Attributes)
+		})
+
+		t.Run("should filter by tag and attribute", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			redWireless := createProduct(t, []string{"wireless"}, map[string]interface{}{"color": "red"})
+			blueWireless := createProduct(t, []string{"wireless"}, map[string]interface{}{"color": "blue"})
+			createProduct(t, []string{"wired"}, map[string]interface{}{"color": "red"})
+
+			products := assertions.AssertProductsListExact(client.Get(productsEndpoint+"?tag=wireless"), 2)
+			assert.ElementsMatch(t, []string{redWireless, blueWireless}, []string{products[0].Id, products[1].Id})
+
+			products = assertions.AssertProductsListExact(client.Get(productsEndpoint+"?tag=wireless&attr.color=red"), 1)
+			assert.Equal(t, redWireless, products[0].Id)
+
+			assertions.AssertProductsListExact(client.Get(productsEndpoint+"?attr.color=red&attr.color=blue"), 3)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for nested attribute value", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			req := fixtures.ValidCreateRequestWithTags(nil, map[string]interface{}{"size": map[string]interface{}{"w": 1}})
+			resp := client.Post(productsEndpoint, req)
+			assertions.AssertBadRequestWithMessage(resp, "must be a string, number or boolean")
+		})
+
+		t.Run("should return 400 for empty attribute key in filter", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Get(productsEndpoint + "?attr.=red")
+			assertions.AssertBadRequestWithMessage(resp, "attribute key must not be empty")
+		})
+	})
+}
+
+func TestGetProductFacets(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewProductFixtures()
+
+	createProduct := func(t *testing.T, tags []string, attributes map[string]interface{}) {
+		t.Helper()
+		resp := client.Post(productsEndpoint, fixtures.ValidCreateRequestWithTags(tags, attributes))
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should return counts for current filter set", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			createProduct(t, []string{"wireless", "sale"}, map[string]interface{}{"color": "red"})
+			createProduct(t, []string{"wireless"}, map[string]interface{}{"color": "blue"})
+			createProduct(t, []string{"wired"}, map[string]interface{}{"color": "red"})
+
+			resp := client.Get(productsEndpoint + "/facets?attr.color=red")
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			facets := e2e.ParseJSON[api.ProductFacets](t, resp)
+
+			assert.Equal(t, []api.FacetCount{
+				{Value: "sale", Count: 1},
+				{Value: "wired", Count: 1},
+				{Value: "wireless", Count: 1},
+			}, facets.Tags)
+			assert.Equal(t, []api.AttributeFacet{
+				{Key: "color", Values: []api.FacetCount{{Value: "red", Count: 2}}},
+			}, facets.Attributes)
+		})
+
+		t.Run("should return empty facets when no products exist", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Get(productsEndpoint + "/facets")
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			facets := e2e.ParseJSON[api.ProductFacets](t, resp)
+
+			assert.Empty(t, facets.Tags)
+			assert.Empty(t, facets.Attributes)
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing category", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Get(productsEndpoint + "/facets?category=999999")
+			require.Equal(t, http.StatusNotFound, resp.StatusCode)
+			resp.Body.Close()
+		})
+	})
+}
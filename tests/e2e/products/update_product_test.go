@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/money"
 	"product_review_hub/tests/e2e"
 
 	"github.com/stretchr/testify/assert"
@@ -35,7 +36,7 @@ func TestUpdateProduct(t *testing.T) {
 			updatedProduct := assertions.AssertProductUpdated(resp, updateReq, createdProduct.Id)
 			assert.Equal(t, updateReq.Name, updatedProduct.Name)
 			assert.Equal(t, updateReq.Description, updatedProduct.Description)
-			assert.InDelta(t, updateReq.Price, updatedProduct.Price, 0.01)
+			assert.Equal(t, updateReq.Price, updatedProduct.Price)
 		})
 
 		t.Run("should update all fields", func(t *testing.T) {
@@ -50,14 +51,14 @@ func TestUpdateProduct(t *testing.T) {
 			updateReq := fixtures.ValidUpdateRequestWithData(
 				"Completely New Name",
 				"Completely new description",
-				999.99,
+				money.MustParse("999.99"),
 			)
 			resp := client.Put(productsEndpoint+"/"+createdProduct.Id, updateReq)
 
 			updatedProduct := assertions.AssertProductUpdated(resp, updateReq, createdProduct.Id)
 			assert.Equal(t, "Completely New Name", updatedProduct.Name)
 			assert.Equal(t, "Completely new description", updatedProduct.Description)
-			assert.InDelta(t, 999.99, updatedProduct.Price, 0.01)
+			assert.Equal(t, money.MustParse("999.99"), updatedProduct.Price)
 		})
 
 		t.Run("should preserve average_rating after update", func(t *testing.T) {
@@ -94,7 +95,7 @@ func TestUpdateProduct(t *testing.T) {
 			updateReq := fixtures.ValidUpdateRequestWithData(
 				"Product 产品 🎉",
 				"Product description with emoji 😀",
-				199.99,
+				money.MustParse("199.99"),
 			)
 			resp := client.Put(productsEndpoint+"/"+createdProduct.Id, updateReq)
 
@@ -114,7 +115,7 @@ func TestUpdateProduct(t *testing.T) {
 			updateReq := fixtures.ValidUpdateRequestWithData(
 				"Product with 'quotes' & <special> \"chars\"",
 				"Description with special chars",
-				99.99,
+				money.MustParse("99.99"),
 			)
 			resp := client.Put(productsEndpoint+"/"+createdProduct.Id, updateReq)
 
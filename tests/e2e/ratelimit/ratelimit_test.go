@@ -0,0 +1,110 @@
+package ratelimit_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/config"
+	"product_review_hub/internal/ratelimit"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reviewLimit is the default number of reviews a caller may create per minute.
+const reviewLimit = 5
+
+func reviewsEndpoint(productID string) string {
+	return fmt.Sprintf("/api/v1/products/%s/reviews", productID)
+}
+
+func TestReviewRateLimit(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	reviewFixtures := e2e.NewReviewFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should limit review creation per user", func(t *testing.T) {
+		env.CleanupProducts(t)
+		author := client.AsNewUser()
+
+		for i := 0; i < reviewLimit; i++ {
+			productID := e2e.CreateTestProductWithoutCleanup(t, client)
+			resp := author.Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			assert.Equal(t, strconv.Itoa(reviewLimit), resp.Header.Get("RateLimit-Limit"))
+			assert.Equal(t, strconv.Itoa(reviewLimit-1-i), resp.Header.Get("RateLimit-Remaining"))
+			assert.Equal(t, "5;w=60", resp.Header.Get("RateLimit-Policy"))
+			resp.Body.Close()
+		}
+
+		productID := e2e.CreateTestProductWithoutCleanup(t, client)
+		resp := author.Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+		defer resp.Body.Close()
+		assertions.AssertStatusCode(resp, http.StatusTooManyRequests)
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+
+		retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		require.NoError(t, err)
+		assert.Positive(t, retryAfter)
+		assert.LessOrEqual(t, retryAfter, 12)
+
+		problem := e2e.ParseJSON[api.Problem](t, resp)
+		assert.Equal(t, http.StatusTooManyRequests, problem.Status)
+		assert.Equal(t, "Too Many Requests", problem.Title)
+
+		// Other users have their own budget
+		resp = client.AsNewUser().Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusCreated)
+		resp.Body.Close()
+	})
+
+	t.Run("should not limit routes without a rule", func(t *testing.T) {
+		resp := client.Get("/api/v1/products")
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+		resp.Body.Close()
+	})
+}
+
+func TestIPRateLimit(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should limit requests with invalid credentials per IP address", func(t *testing.T) {
+		rules, err := ratelimit.ParseRules(config.DefaultIPRateLimits)
+		require.NoError(t, err)
+		limit, ok := rules.For(http.MethodPost, "/api/v1/products")
+		require.True(t, ok)
+
+		intruder := client.WithAPIKey(auth.APIKeyPrefix + "unknownunknownunknown")
+		productFixtures := e2e.NewProductFixtures()
+		// One more request becomes available every Period/Requests while the flood is running
+		var resp *http.Response
+		rejected := 0
+		for ; rejected < 2*limit.Requests; rejected++ {
+			resp = intruder.Post("/api/v1/products", productFixtures.ValidCreateRequest())
+			if resp.StatusCode != http.StatusUnauthorized {
+				break
+			}
+			assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+			resp.Body.Close()
+		}
+		defer resp.Body.Close()
+		assert.GreaterOrEqual(t, rejected, limit.Requests)
+		assertions.AssertStatusCode(resp, http.StatusTooManyRequests)
+		assert.Equal(t, strconv.Itoa(limit.Requests), resp.Header.Get("RateLimit-Limit"))
+		assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+	})
+}
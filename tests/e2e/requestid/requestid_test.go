@@ -0,0 +1,46 @@
+package requestid_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/requestid"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env).Anonymous()
+
+	t.Run("should echo the request ID of the client", func(t *testing.T) {
+		resp := client.Get("/api/v1/products", e2e.WithHeader(requestid.Header, "client-request-1"))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "client-request-1", resp.Header.Get(requestid.Header))
+	})
+
+	t.Run("should generate a request ID when missing", func(t *testing.T) {
+		resp := client.Get("/api/v1/products")
+		defer resp.Body.Close()
+		assert.Len(t, resp.Header.Get(requestid.Header), 32)
+	})
+
+	t.Run("should replace an invalid request ID", func(t *testing.T) {
+		resp := client.Get("/api/v1/products", e2e.WithHeader(requestid.Header, "has spaces in it"))
+		defer resp.Body.Close()
+		id := resp.Header.Get(requestid.Header)
+		assert.NotEqual(t, "has spaces in it", id)
+		assert.True(t, requestid.Valid(id))
+	})
+
+	t.Run("should return the request ID on errors", func(t *testing.T) {
+		resp := client.Get("/api/v1/products/999999", e2e.WithHeader(requestid.Header, "client-request-2"))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "client-request-2", resp.Header.Get(requestid.Header))
+	})
+}
@@ -24,7 +24,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.ValidCreateRequest()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertContentTypeJSON(resp)
 			assertions.AssertReviewCreated(resp, req, productID)
@@ -34,7 +34,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.ValidCreateRequestFull()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertReviewCreated(resp, req, productID)
 		})
@@ -43,7 +43,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.ValidCreateRequestWithRating(1)
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertReviewCreated(resp, req, productID)
 		})
@@ -52,7 +52,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.ValidCreateRequestWithRating(5)
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertReviewCreated(resp, req, productID)
 		})
@@ -61,7 +61,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.CreateRequestWithUnicode()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertReviewCreated(resp, req, productID)
 		})
@@ -70,7 +70,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.CreateRequestWithSpecialChars()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertReviewCreated(resp, req, productID)
 		})
@@ -80,7 +80,7 @@ func TestCreateProductReview(t *testing.T) {
 
 			for i := 1; i <= 5; i++ {
 				req := reviewFixtures.ValidCreateRequestWithRating(i)
-				resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+				resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 				review := assertions.AssertReviewCreated(resp, req, productID)
 				if review.Id == "" {
@@ -95,7 +95,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.CreateRequestWithZeroRating()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertBadRequestWithMessage(resp, "rating")
 		})
@@ -104,7 +104,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.CreateRequestWithNegativeRating()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertBadRequestWithMessage(resp, "rating")
 		})
@@ -113,7 +113,7 @@ func TestCreateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			req := reviewFixtures.CreateRequestWithRatingAboveMax()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 
 			assertions.AssertBadRequestWithMessage(resp, "rating")
 		})
@@ -124,7 +124,7 @@ func TestCreateProductReview(t *testing.T) {
 			env.CleanupProducts(t)
 
 			req := reviewFixtures.ValidCreateRequest()
-			resp := client.Post("/api/v1/products/99999/reviews", req)
+			resp := client.AsNewUser().Post("/api/v1/products/99999/reviews", req)
 
 			assertions.AssertNotFoundWithMessage(resp, "Product not found")
 		})
@@ -197,9 +197,15 @@ func TestCreateProductReviewConcurrency(t *testing.T) {
 		results := make(chan *http.Response, numReviews)
 		reviewFixtures := e2e.NewReviewFixtures()
 
+		// Each user may review a product only once, so every request needs its own author
+		authors := make([]string, numReviews)
+		for i := range authors {
+			authors[i] = e2e.CreateTestUser(t, client).Id
+		}
+
 		for i := 0; i < numReviews; i++ {
 			go func(rating int) {
-				client := e2e.NewHTTPClient(t, env)
+				client := e2e.NewHTTPClient(t, env).As(authors[rating])
 				req := reviewFixtures.ValidCreateRequestWithRating((rating % 5) + 1)
 				resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 				results <- resp
@@ -24,7 +24,7 @@ func TestDeleteProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 			review := e2e.CreateTestReview(t, client, productID)
 
-			resp := client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			resp := client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
 
 			assertions.AssertNoContent(resp)
 			resp.Body.Close()
@@ -40,7 +40,7 @@ func TestDeleteProductReview(t *testing.T) {
 			require.Len(t, reviews, 1, "Expected 1 review")
 
 			// Delete the review
-			resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
 			assertions.AssertNoContent(resp)
 			resp.Body.Close()
 
@@ -66,7 +66,7 @@ func TestDeleteProductReview(t *testing.T) {
 			require.Len(t, reviews, 3, "Expected 3 reviews")
 
 			// Delete the first review
-			resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review1.Id))
+			resp = client.As(*review1.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review1.Id))
 			assertions.AssertNoContent(resp)
 			resp.Body.Close()
 
@@ -90,7 +90,7 @@ func TestDeleteProductReview(t *testing.T) {
 		t.Run("should return 404 for non-existent review", func(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
-			resp := client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/99999", productID))
+			resp := client.AsNewUser().Delete(fmt.Sprintf("/api/v1/products/%s/reviews/99999", productID))
 
 			assertions.AssertNotFoundWithMessage(resp, "Review not found")
 		})
@@ -100,12 +100,12 @@ func TestDeleteProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			// Delete the review
-			resp := client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			resp := client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
 			assertions.AssertNoContent(resp)
 			resp.Body.Close()
 
 			// Try to delete again
-			resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
 			assertions.AssertNotFoundWithMessage(resp, "Review not found")
 		})
 
@@ -120,7 +120,7 @@ func TestDeleteProductReview(t *testing.T) {
 			anotherProduct := e2e.ParseJSON[api.Product](t, resp)
 
 			// Try to delete review using wrong product ID
-			resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", anotherProduct.Id, review.Id))
+			resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", anotherProduct.Id, review.Id))
 
 			assertions.AssertNotFoundWithMessage(resp, "Review not found")
 		})
@@ -146,11 +146,11 @@ func TestDeleteProductReview(t *testing.T) {
 
 			// Create review with special characters
 			req := reviewFixtures.CreateRequestWithSpecialChars()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 			review := e2e.ParseJSON[api.Review](t, resp)
 
 			// Delete the review
-			resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
 
 			assertions.AssertNoContent(resp)
 			resp.Body.Close()
@@ -161,11 +161,11 @@ func TestDeleteProductReview(t *testing.T) {
 
 			// Create review with unicode
 			req := reviewFixtures.CreateRequestWithUnicode()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 			review := e2e.ParseJSON[api.Review](t, resp)
 
 			// Delete the review
-			resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
 
 			assertions.AssertNoContent(resp)
 			resp.Body.Close()
@@ -189,23 +189,22 @@ func TestDeleteProductReviewConcurrency(t *testing.T) {
 
 	// Create multiple reviews
 	const numReviews = 10
-	reviewIDs := make([]string, numReviews)
+	created := make([]api.Review, numReviews)
 	for i := 0; i < numReviews; i++ {
 		req := reviewFixtures.ValidCreateRequestWithRating((i % 5) + 1)
-		resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), req)
-		review := e2e.ParseJSON[api.Review](t, resp)
-		reviewIDs[i] = review.Id
+		resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), req)
+		created[i] = e2e.ParseJSON[api.Review](t, resp)
 	}
 
 	t.Run("should handle concurrent deletions", func(t *testing.T) {
 		results := make(chan *http.Response, numReviews)
 
-		for _, reviewID := range reviewIDs {
-			go func(id string) {
-				client := e2e.NewHTTPClient(t, env)
-				resp := client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, id))
+		for _, review := range created {
+			go func(review api.Review) {
+				client := e2e.NewHTTPClient(t, env).As(*review.UserId)
+				resp := client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
 				results <- resp
-			}(reviewID)
+			}(review)
 		}
 
 		// Collect results
@@ -249,20 +248,20 @@ func TestDeleteReviewIdempotency(t *testing.T) {
 		product := e2e.ParseJSON[api.Product](t, resp)
 
 		reviewReq := reviewFixtures.ValidCreateRequest()
-		resp = client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), reviewReq)
+		resp = client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), reviewReq)
 		review := e2e.ParseJSON[api.Review](t, resp)
 
 		// First delete should succeed
-		resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
+		resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
 		assertions.AssertNoContent(resp)
 		resp.Body.Close()
 
 		// Second delete should return 404
-		resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
+		resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
 		assertions.AssertNotFoundWithMessage(resp, "Review not found")
 
 		// Third delete should also return 404
-		resp = client.Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
+		resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id))
 		assertions.AssertNotFoundWithMessage(resp, "Review not found")
 	})
 }
@@ -54,7 +54,7 @@ func TestGetProductReviews(t *testing.T) {
 
 			// Create review with full data
 			req := reviewFixtures.ValidCreateRequestFull()
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", productID), req)
 			require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create review")
 
 			resp = client.Get(fmt.Sprintf("/api/v1/products/%s/reviews", productID))
@@ -193,7 +193,7 @@ func TestGetProductReviewsOrdering(t *testing.T) {
 		createdIDs := make([]string, len(ratings))
 		for i, rating := range ratings {
 			req := reviewFixtures.ValidCreateRequestWithRating(rating)
-			resp := client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), req)
+			resp := client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), req)
 			review := e2e.ParseJSON[api.Review](t, resp)
 			createdIDs[i] = review.Id
 		}
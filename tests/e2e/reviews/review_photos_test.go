@@ -0,0 +1,180 @@
+package reviews_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func photosEndpoint(productID, reviewID string) string {
+	return fmt.Sprintf("/api/v1/products/%s/reviews/%s/photos", productID, reviewID)
+}
+
+// uploadPhoto attaches data as a photo to a review on behalf of its author.
+func uploadPhoto(client *e2e.HTTPClient, t *testing.T, productID string, review api.Review, data []byte) *http.Response {
+	t.Helper()
+	body, contentType := e2e.MultipartBody(t, nil, e2e.UploadFile{Field: "file", FileName: "photo.jpg", Data: data})
+	return client.As(*review.UserId).PostRaw(photosEndpoint(productID, review.Id), body, e2e.WithContentType(contentType))
+}
+
+// fetchFile downloads a file by URL.
+func fetchFile(client *e2e.HTTPClient, t *testing.T, url string) []byte {
+	t.Helper()
+	resp := client.Get(url)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestReviewPhotos(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should attach photo without metadata", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+			assert.Empty(t, review.Photos)
+
+			// Orientation 6 means the camera was rotated 90° clockwise
+			resp := uploadPhoto(client, t, productID, review, e2e.TestJPEGWithExif(t, 40, 20, 6))
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			photo := e2e.ParseJSON[api.ReviewPhoto](t, resp)
+
+			assert.Equal(t, review.Id, photo.ReviewId)
+			assert.Equal(t, "image/jpeg", photo.ContentType)
+			assert.Equal(t, 20, photo.Width)
+			assert.Equal(t, 40, photo.Height)
+
+			data := fetchFile(client, t, photo.Url)
+			assert.NotContains(t, string(data), "Exif")
+			assert.NotContains(t, string(data), "TestCam")
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+			require.NoError(t, err)
+			assert.Equal(t, 20, cfg.Width)
+			assert.Equal(t, 40, cfg.Height)
+
+			fetchFile(client, t, photo.ThumbnailUrl)
+
+			resp = client.Get(fmt.Sprintf("/api/v1/products/%s/reviews", productID))
+			reviews := assertions.AssertReviewsList(resp, 1)
+			require.Len(t, reviews[0].Photos, 1)
+			assert.Equal(t, photo.Id, reviews[0].Photos[0].Id)
+			assert.Equal(t, photo.Url, reviews[0].Photos[0].Url)
+		})
+
+		t.Run("should filter reviews by media", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			withPhoto := e2e.CreateTestReview(t, client, productID)
+			withoutPhoto := e2e.CreateTestReview(t, client, productID)
+
+			resp := uploadPhoto(client, t, productID, withPhoto, e2e.TestPNG(t, 10, 10))
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			resp.Body.Close()
+
+			resp = client.Get(fmt.Sprintf("/api/v1/products/%s/reviews?has_media=true", productID))
+			reviews := assertions.AssertReviewsList(resp, 1)
+			assert.Equal(t, withPhoto.Id, reviews[0].Id)
+
+			resp = client.Get(fmt.Sprintf("/api/v1/products/%s/reviews?has_media=false", productID))
+			reviews = assertions.AssertReviewsList(resp, 1)
+			assert.Equal(t, withoutPhoto.Id, reviews[0].Id)
+		})
+
+		t.Run("should delete photo and its files", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			resp := uploadPhoto(client, t, productID, review, e2e.TestPNG(t, 10, 10))
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			photo := e2e.ParseJSON[api.ReviewPhoto](t, resp)
+
+			resp = client.As(*review.UserId).Delete(photosEndpoint(productID, review.Id) + "/" + photo.Id)
+			assertions.AssertNoContent(resp)
+
+			resp = client.Get(photo.Url)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+
+		t.Run("should remove photo files when review is deleted", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			resp := uploadPhoto(client, t, productID, review, e2e.TestPNG(t, 10, 10))
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			photo := e2e.ParseJSON[api.ReviewPhoto](t, resp)
+
+			resp = client.As(*review.UserId).Delete(fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id))
+			assertions.AssertNoContent(resp)
+
+			resp = client.Get(photo.ThumbnailUrl)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 409 when review has the maximum number of photos", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			for i := 0; i < 5; i++ {
+				resp := uploadPhoto(client, t, productID, review, e2e.TestPNG(t, 10, 10))
+				assertions.AssertStatusCode(resp, http.StatusCreated)
+				resp.Body.Close()
+			}
+
+			resp := uploadPhoto(client, t, productID, review, e2e.TestPNG(t, 10, 10))
+			assertions.AssertConflictWithMessage(resp, "Review already has the maximum of 5 photos")
+		})
+
+		t.Run("should return 413 for too large photo", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			resp := uploadPhoto(client, t, productID, review, make([]byte, 6<<20))
+			assertions.AssertStatusCode(resp, http.StatusRequestEntityTooLarge)
+			resp.Body.Close()
+		})
+
+		t.Run("should return 400 for unsupported type", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			resp := uploadPhoto(client, t, productID, review, []byte("not an image"))
+			assertions.AssertBadRequestWithMessage(resp, "validation error: file - only JPEG, PNG and GIF images are supported")
+		})
+
+		t.Run("should return 404 for review of another product", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			otherProductID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			resp := uploadPhoto(client, t, otherProductID, review, e2e.TestPNG(t, 10, 10))
+			assertions.AssertNotFoundWithMessage(resp, "Review not found")
+		})
+
+		t.Run("should return 404 for non-existent photo", func(t *testing.T) {
+			productID := e2e.CreateTestProduct(t, env, client)
+			review := e2e.CreateTestReview(t, client, productID)
+
+			resp := client.As(*review.UserId).Delete(photosEndpoint(productID, review.Id) + "/999999")
+			assertions.AssertNotFoundWithMessage(resp, "Photo not found")
+		})
+	})
+}
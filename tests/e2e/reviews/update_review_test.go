@@ -25,7 +25,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := reviewFixtures.ValidUpdateRequest()
-			resp := client.Put(
+			resp := client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				updateReq,
 			)
@@ -39,7 +39,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := reviewFixtures.ValidUpdateRequestFull()
-			resp := client.Put(
+			resp := client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				updateReq,
 			)
@@ -52,7 +52,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := api.ReviewUpdate{Rating: 1}
-			resp := client.Put(
+			resp := client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				updateReq,
 			)
@@ -65,7 +65,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := api.ReviewUpdate{Rating: 5}
-			resp := client.Put(
+			resp := client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				updateReq,
 			)
@@ -78,7 +78,7 @@ func TestUpdateProductReview(t *testing.T) {
 			originalReview := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := reviewFixtures.ValidUpdateRequestFull()
-			resp := client.Put(
+			resp := client.As(*originalReview.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, originalReview.Id),
 				updateReq,
 			)
@@ -98,7 +98,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := reviewFixtures.UpdateRequestWithZeroRating()
-			resp := client.Put(
+			resp := client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				updateReq,
 			)
@@ -111,7 +111,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			updateReq := reviewFixtures.UpdateRequestWithRatingAboveMax()
-			resp := client.Put(
+			resp := client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				updateReq,
 			)
@@ -125,7 +125,7 @@ func TestUpdateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 
 			updateReq := reviewFixtures.ValidUpdateRequest()
-			resp := client.Put(
+			resp := client.AsNewUser().Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/99999", productID),
 				updateReq,
 			)
@@ -145,7 +145,7 @@ func TestUpdateProductReview(t *testing.T) {
 
 			// Try to update review using wrong product ID
 			updateReq := reviewFixtures.ValidUpdateRequest()
-			resp = client.Put(
+			resp = client.As(*review.UserId).Put(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", anotherProduct.Id, review.Id),
 				updateReq,
 			)
@@ -178,7 +178,7 @@ func TestUpdateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 			review := e2e.CreateTestReview(t, client, productID)
 
-			resp := client.PutRaw(
+			resp := client.As(*review.UserId).PutRaw(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				[]byte("{invalid json}"),
 				e2e.WithContentType("application/json"),
@@ -191,7 +191,7 @@ func TestUpdateProductReview(t *testing.T) {
 			productID := e2e.CreateTestProduct(t, env, client)
 			review := e2e.CreateTestReview(t, client, productID)
 
-			resp := client.PutRaw(
+			resp := client.As(*review.UserId).PutRaw(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				[]byte(""),
 				e2e.WithContentType("application/json"),
@@ -205,7 +205,7 @@ func TestUpdateProductReview(t *testing.T) {
 			review := e2e.CreateTestReview(t, client, productID)
 
 			body := []byte(`{"rating": "not a number"}`)
-			resp := client.PutRaw(
+			resp := client.As(*review.UserId).PutRaw(
 				fmt.Sprintf("/api/v1/products/%s/reviews/%s", productID, review.Id),
 				body,
 				e2e.WithContentType("application/json"),
@@ -231,7 +231,7 @@ func TestUpdateProductReviewConcurrency(t *testing.T) {
 	product := e2e.ParseJSON[api.Product](t, resp)
 
 	reviewReq := reviewFixtures.ValidCreateRequestFull()
-	resp = client.Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), reviewReq)
+	resp = client.AsNewUser().Post(fmt.Sprintf("/api/v1/products/%s/reviews", product.Id), reviewReq)
 	review := e2e.ParseJSON[api.Review](t, resp)
 
 	t.Run("should handle concurrent updates", func(t *testing.T) {
@@ -242,7 +242,7 @@ func TestUpdateProductReviewConcurrency(t *testing.T) {
 			go func(rating int) {
 				client := e2e.NewHTTPClient(t, env)
 				updateReq := api.ReviewUpdate{Rating: (rating % 5) + 1}
-				resp := client.Put(
+				resp := client.As(*review.UserId).Put(
 					fmt.Sprintf("/api/v1/products/%s/reviews/%s", product.Id, review.Id),
 					updateReq,
 				)
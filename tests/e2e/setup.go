@@ -4,6 +4,8 @@ package e2e
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -11,38 +13,87 @@ import (
 	"time"
 
 	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/internal/cache"
+	"product_review_hub/internal/config"
 	"product_review_hub/internal/database"
 	"product_review_hub/internal/handler"
+	"product_review_hub/internal/metrics"
+	appmw "product_review_hub/internal/middleware"
+	"product_review_hub/internal/money"
+	"product_review_hub/internal/ratelimit"
+	"product_review_hub/internal/repository/apikeys"
+	"product_review_hub/internal/repository/categories"
+	"product_review_hub/internal/repository/images"
+	"product_review_hub/internal/repository/prices"
 	"product_review_hub/internal/repository/products"
+	"product_review_hub/internal/repository/reviewphotos"
 	"product_review_hub/internal/repository/reviews"
+	"product_review_hub/internal/repository/stock"
+	"product_review_hub/internal/repository/users"
+	"product_review_hub/internal/repository/variants"
+	"product_review_hub/internal/storage"
+	"product_review_hub/internal/tenant"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 )
 
+// TestTenants are the tenants the test server knows besides the default one.
+const TestTenants = "shop-a,shop-b"
+
+// TestExchangeRates is the exchange-rate table of the test server.
+const TestExchangeRates = "USD=1,EUR=0.5,GBP=0.8"
+
+// TestJWTSecret is the HS256 secret the test server verifies tokens with.
+const TestJWTSecret = "e2e-test-secret"
+
+// Cache backends of the test server, selected with TEST_CACHE.
+const (
+	CacheMemory = "memory"
+	CacheRedis  = "redis"
+)
+
+// testCache is the cache of the test server, used by the handlers and for API keys.
+type testCache interface {
+	handler.Cache
+	appmw.APIKeyCache
+}
+
 // TestEnv holds the test environment configuration and resources.
 type TestEnv struct {
 	DB      *sqlx.DB
 	Server  *httptest.Server
 	Client  *http.Client
 	BaseURL string
+
+	// flushCache empties the cache of the test server.
+	flushCache func(ctx context.Context) error
 }
 
-// Setup creates a new test environment with database connection and HTTP server.
+// Setup creates a new test environment with database connection, cache and HTTP server.
+// The cache backend is CacheMemory unless TEST_CACHE selects CacheRedis.
 func Setup(t *testing.T) *TestEnv {
 	t.Helper()
 
 	db := setupDatabase(t)
-	server := setupServer(t, db)
+	cacheBackend, flushCache := setupCache(t)
+	server := setupServer(t, db, cacheBackend)
 
-	return &TestEnv{
-		DB:      db,
-		Server:  server,
-		Client:  &http.Client{Timeout: 10 * time.Second},
-		BaseURL: server.URL,
+	env := &TestEnv{
+		DB:         db,
+		Server:     server,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		BaseURL:    server.URL,
+		flushCache: flushCache,
 	}
+	// Entries of earlier runs may name rows that were recreated since
+	env.FlushCache(t)
+
+	return env
 }
 
 // Teardown cleans up test environment resources.
@@ -58,7 +109,19 @@ func (env *TestEnv) Teardown(t *testing.T) {
 	}
 }
 
-// CleanupProducts removes all products from the database.
+// FlushCache empties the cache of the test server. Writes made directly to the database
+// bypass cache invalidation, so tests doing so must flush the cache before reading the data
+// through the API.
+func (env *TestEnv) FlushCache(t *testing.T) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, env.flushCache(ctx), "Failed to flush cache")
+}
+
+// CleanupProducts removes all products from the database and the cache.
 func (env *TestEnv) CleanupProducts(t *testing.T) {
 	t.Helper()
 
@@ -67,9 +130,10 @@ func (env *TestEnv) CleanupProducts(t *testing.T) {
 
 	_, err := env.DB.ExecContext(ctx, "TRUNCATE TABLE products CASCADE")
 	require.NoError(t, err, "Failed to cleanup products")
+	env.FlushCache(t)
 }
 
-// CleanupReviews removes all reviews from the database.
+// CleanupReviews removes all reviews from the database and the cache.
 func (env *TestEnv) CleanupReviews(t *testing.T) {
 	t.Helper()
 
@@ -78,6 +142,20 @@ func (env *TestEnv) CleanupReviews(t *testing.T) {
 
 	_, err := env.DB.ExecContext(ctx, "TRUNCATE TABLE reviews CASCADE")
 	require.NoError(t, err, "Failed to cleanup reviews")
+	env.FlushCache(t)
+}
+
+// CleanupCategories removes all categories and product assignments from the database and the
+// cache.
+func (env *TestEnv) CleanupCategories(t *testing.T) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := env.DB.ExecContext(ctx, "TRUNCATE TABLE categories CASCADE")
+	require.NoError(t, err, "Failed to cleanup categories")
+	env.FlushCache(t)
 }
 
 // setupDatabase creates a database connection using test environment variables.
@@ -102,19 +180,100 @@ func setupDatabase(t *testing.T) *sqlx.DB {
 	return db
 }
 
+// setupCache creates the cache backend selected with TEST_CACHE and a function emptying it.
+func setupCache(t *testing.T) (testCache, func(ctx context.Context) error) {
+	t.Helper()
+
+	switch backend := getEnvOrDefault("TEST_CACHE", CacheMemory); backend {
+	case CacheMemory:
+		memory := cache.NewMemory(cache.DefaultTTL)
+		return memory, func(ctx context.Context) error {
+			memory.Clear()
+			return nil
+		}
+	case CacheRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr: net.JoinHostPort(getEnvOrDefault("TEST_REDIS_HOST", "localhost"), getEnvOrDefault("TEST_REDIS_PORT", "6380")),
+		})
+		t.Cleanup(func() { client.Close() })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, client.Ping(ctx).Err(), "Failed to connect to test Redis")
+
+		// Run with the in-process tier of production, CACHE_LOCAL_SIZE and CACHE_LOCAL_TTL defaults
+		service := cache.NewServiceWithConfig(client, cache.Config{
+			TTL:       cache.DefaultTTL,
+			LocalSize: 10000,
+			LocalTTL:  5 * time.Second,
+		})
+		listenCtx, stopListening := context.WithCancel(context.Background())
+		t.Cleanup(stopListening)
+		go service.Listen(listenCtx)
+
+		return service, func(ctx context.Context) error {
+			if err := client.FlushDB(ctx).Err(); err != nil {
+				return err
+			}
+			service.ClearLocal()
+			return nil
+		}
+	default:
+		t.Fatalf("Unknown TEST_CACHE %q, want %q or %q", backend, CacheMemory, CacheRedis)
+		return nil, nil
+	}
+}
+
 // setupServer creates a test HTTP server with all routes.
-func setupServer(t *testing.T, db *sqlx.DB) *httptest.Server {
+func setupServer(t *testing.T, db *sqlx.DB, cacheBackend testCache) *httptest.Server {
 	t.Helper()
 
+	tenants, err := tenant.NewResolver(tenant.ParseIDs(TestTenants), nil)
+	require.NoError(t, err, "Failed to create tenant resolver")
+
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(appmw.RequestID())
+	r.Use(appmw.Tracing())
+	r.Use(appmw.Metrics())
+	r.Use(appmw.AccessLog(slog.Default()))
 	r.Use(middleware.Recoverer)
+	r.Use(appmw.Tenant(tenants))
 
 	productRepo := products.NewRepository(db)
 	reviewRepo := reviews.NewRepository(db)
-	h := handler.New(db, productRepo, reviewRepo, nil, nil) // nil publisher and cache for tests
+	categoryRepo := categories.NewRepository(db)
+	variantRepo := variants.NewRepository(db)
+	priceRepo := prices.NewRepository(db)
+	stockRepo := stock.NewRepository(db)
+	imageRepo := images.NewRepository(db)
+	photoRepo := reviewphotos.NewRepository(db)
+	userRepo := users.NewRepository(db)
+	apiKeyRepo := apikeys.NewRepository(db)
+	blobStore, err := storage.NewLocalStore(t.TempDir(), "/media")
+	require.NoError(t, err, "Failed to create blob store")
+	rates, err := money.ParseRates(TestExchangeRates)
+	require.NoError(t, err, "Failed to parse exchange rates")
+	h := handler.New(db, productRepo, reviewRepo, categoryRepo, variantRepo, priceRepo, stockRepo, imageRepo, photoRepo, userRepo, apiKeyRepo, nil, cacheBackend, blobStore, rates) // nil publisher for tests
+
+	verifier, err := auth.NewVerifier(auth.Options{Secret: []byte(TestJWTSecret)})
+	require.NoError(t, err, "Failed to create token verifier")
+
+	rateLimits, err := ratelimit.ParseRules(config.DefaultRateLimits)
+	require.NoError(t, err, "Failed to parse rate limits")
+	ipRateLimits, err := ratelimit.ParseRules(config.DefaultIPRateLimits)
+	require.NoError(t, err, "Failed to parse IP rate limits")
+	limiter := ratelimit.NewMemoryLimiter()
 
-	api.HandlerFromMux(h, r)
+	api.HandlerWithOptions(h, api.ChiServerOptions{
+		BaseRouter: r,
+		Middlewares: []api.MiddlewareFunc{
+			appmw.RateLimit(limiter, rateLimits),
+			appmw.Authorize(verifier, appmw.NewAPIKeyAuthenticator(apiKeyRepo, cacheBackend)),
+			appmw.RateLimitByIP(limiter, ipRateLimits),
+		},
+	})
+	r.Handle("/media/*", http.StripPrefix("/media/", storage.Handler(blobStore)))
+	r.Handle("/metrics", metrics.Handler())
 
 	return httptest.NewServer(r)
 }
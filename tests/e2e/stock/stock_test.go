@@ -0,0 +1,214 @@
+package stock_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stockEndpoint(productID string) string {
+	return "/api/v1/products/" + productID + "/stock"
+}
+
+func TestProductStock(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should return empty stock for new product", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Get(stockEndpoint(productID))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock := e2e.ParseJSON[api.ProductStock](t, resp)
+
+			assert.Equal(t, productID, productStock.ProductId)
+			assert.Zero(t, productStock.Quantity)
+			assert.Zero(t, productStock.Available)
+			assert.False(t, productStock.LowStock)
+		})
+
+		t.Run("should adjust, reserve and release stock", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: 10})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock := e2e.ParseJSON[api.ProductStock](t, resp)
+			assert.Equal(t, 10, productStock.Quantity)
+
+			resp = client.Post(stockEndpoint(productID)+"/reserve", api.StockQuantity{Quantity: 4})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock = e2e.ParseJSON[api.ProductStock](t, resp)
+			assert.Equal(t, 4, productStock.Reserved)
+			assert.Equal(t, 6, productStock.Available)
+
+			resp = client.Post(stockEndpoint(productID)+"/release", api.StockQuantity{Quantity: 1})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock = e2e.ParseJSON[api.ProductStock](t, resp)
+			assert.Equal(t, 3, productStock.Reserved)
+			assert.Equal(t, 7, productStock.Available)
+		})
+
+		t.Run("should report low stock", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Put(stockEndpoint(productID), api.StockSettings{LowStockThreshold: 5})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock := e2e.ParseJSON[api.ProductStock](t, resp)
+			assert.Equal(t, 5, productStock.LowStockThreshold)
+
+			resp = client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: 8})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock = e2e.ParseJSON[api.ProductStock](t, resp)
+			assert.False(t, productStock.LowStock)
+
+			resp = client.Post(stockEndpoint(productID)+"/reserve", api.StockQuantity{Quantity: 3})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock = e2e.ParseJSON[api.ProductStock](t, resp)
+			assert.True(t, productStock.LowStock)
+		})
+
+		t.Run("should filter products by availability", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			inStockID := e2e.CreateTestProduct(t, env, client)
+			outOfStockID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(inStockID)+"/adjust", api.StockAdjustment{Delta: 1})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			resp = client.Get("/api/v1/products?in_stock=true")
+			products := assertions.AssertProductsListExact(resp, 1)
+			assert.Equal(t, inStockID, products[0].Id)
+			assert.True(t, products[0].InStock)
+
+			resp = client.Get("/api/v1/products?in_stock=false")
+			products = assertions.AssertProductsListExact(resp, 1)
+			assert.Equal(t, outOfStockID, products[0].Id)
+			assert.False(t, products[0].InStock)
+
+			resp = client.Get("/api/v1/products/" + inStockID)
+			product := assertions.AssertProductByID(resp, inStockID)
+			assert.True(t, product.InStock)
+		})
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Run("should return 409 when reserving more than available", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: 2})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			resp = client.Post(stockEndpoint(productID)+"/reserve", api.StockQuantity{Quantity: 3})
+			assertions.AssertConflictWithMessage(resp, "Insufficient stock")
+		})
+
+		t.Run("should return 409 when removing reserved stock", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: 5})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			resp = client.Post(stockEndpoint(productID)+"/reserve", api.StockQuantity{Quantity: 4})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			resp = client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: -2})
+			assertions.AssertConflictWithMessage(resp, "Insufficient stock")
+		})
+
+		t.Run("should return 409 when releasing more than reserved", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/release", api.StockQuantity{Quantity: 1})
+			assertions.AssertConflictWithMessage(resp, "Cannot release more than the reserved quantity")
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for zero delta", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: 0})
+			assertions.AssertBadRequestWithMessage(resp, "validation error: delta - delta must not be 0")
+		})
+
+		t.Run("should return 400 for non-positive quantity", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/reserve", api.StockQuantity{Quantity: 0})
+			assertions.AssertBadRequestWithMessage(resp, "validation error: quantity - quantity must be greater than 0")
+		})
+
+		t.Run("should return 404 for non-existent product", func(t *testing.T) {
+			resp := client.Post(stockEndpoint("999999")+"/adjust", api.StockAdjustment{Delta: 1})
+			assertions.AssertNotFoundWithMessage(resp, "Product not found")
+		})
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		t.Run("should not oversell under concurrent reservations", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(stockEndpoint(productID)+"/adjust", api.StockAdjustment{Delta: 5})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			resp.Body.Close()
+
+			const attempts = 10
+			statuses := make(chan int, attempts)
+			for i := 0; i < attempts; i++ {
+				go func() {
+					resp := client.Post(stockEndpoint(productID)+"/reserve", api.StockQuantity{Quantity: 1})
+					resp.Body.Close()
+					statuses <- resp.StatusCode
+				}()
+			}
+
+			reserved := 0
+			for i := 0; i < attempts; i++ {
+				status := <-statuses
+				if status == http.StatusOK {
+					reserved++
+					continue
+				}
+				assert.Equal(t, http.StatusConflict, status)
+			}
+			assert.Equal(t, 5, reserved)
+
+			resp = client.Get(stockEndpoint(productID))
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			productStock := e2e.ParseJSON[api.ProductStock](t, resp)
+			require.Equal(t, 0, productStock.Available)
+		})
+	})
+}
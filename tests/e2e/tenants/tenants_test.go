@@ -0,0 +1,163 @@
+package tenants_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	shopA := client.ForTenant("shop-a")
+	shopB := client.ForTenant("shop-b")
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should hide products of other tenants", func(t *testing.T) {
+		env.CleanupProducts(t)
+		productID := e2e.CreateTestProduct(t, env, shopA)
+
+		resp := shopA.Get("/api/v1/products/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = shopB.Get("/api/v1/products/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		resp = client.Get("/api/v1/products/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		resp = shopB.Get("/api/v1/products")
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		products := e2e.ParseJSON[[]api.Product](t, resp)
+		assert.Empty(t, products)
+
+		resp = shopB.Delete("/api/v1/products/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("should hide reviews of other tenants", func(t *testing.T) {
+		env.CleanupProducts(t)
+		productID := e2e.CreateTestProduct(t, env, shopA)
+		review := e2e.CreateTestReview(t, shopA, productID)
+
+		resp := shopA.Get("/api/v1/products/" + productID + "/reviews")
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		reviews := e2e.ParseJSON[[]api.Review](t, resp)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, review.Id, reviews[0].Id)
+
+		resp = shopB.Get("/api/v1/products/" + productID + "/reviews")
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		resp = shopB.AsNewUser().Post("/api/v1/products/"+productID+"/reviews", e2e.NewReviewFixtures().ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("should hide categories of other tenants", func(t *testing.T) {
+		env.CleanupCategories(t)
+		categoryID := e2e.CreateTestCategory(t, shopA, "Electronics", nil)
+
+		resp := shopA.Get("/api/v1/categories/" + categoryID)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = shopB.Get("/api/v1/categories/" + categoryID)
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		productID := e2e.CreateTestProduct(t, env, shopB)
+		resp = shopB.Put("/api/v1/products/"+productID+"/categories", api.ProductCategoriesUpdate{
+			CategoryIds: []string{categoryID},
+		})
+		e2e.NewCategoryAssertions(t).AssertErrorWithMessage(resp, http.StatusBadRequest, "category "+categoryID+" does not exist")
+	})
+
+	t.Run("should allow the same SKU in every tenant", func(t *testing.T) {
+		env.CleanupProducts(t)
+		e2e.CreateTestVariant(t, shopA, e2e.CreateTestProduct(t, env, shopA), "TSHIRT-M")
+		variant := e2e.CreateTestVariant(t, shopB, e2e.CreateTestProduct(t, env, shopB), "TSHIRT-M")
+		assert.Equal(t, "TSHIRT-M", variant.Sku)
+	})
+
+	t.Run("should reject unknown tenant", func(t *testing.T) {
+		resp := client.ForTenant("shop-c").Get("/api/v1/products")
+		defer resp.Body.Close()
+		assertions.AssertStatusCode(resp, http.StatusBadRequest)
+		errResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+		assert.Equal(t, "Unknown tenant", errResp.Error)
+	})
+}
+
+func TestTenantTokens(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+	shopAAdmin := client.WithToken(e2e.TenantToken(t, "shop-a", "admin", auth.RoleAdmin))
+
+	t.Run("should bind requests to the tenant of the token", func(t *testing.T) {
+		env.CleanupProducts(t)
+		productID := e2e.CreateTestProduct(t, env, shopAAdmin)
+
+		resp := client.ForTenant("shop-a").Get("/api/v1/products/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = client.Get("/api/v1/products/" + productID)
+		assertions.AssertStatusCode(resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("should reject token of another tenant", func(t *testing.T) {
+		resp := shopAAdmin.ForTenant("shop-b").Post("/api/v1/products", e2e.NewProductFixtures().ValidCreateRequest())
+		defer resp.Body.Close()
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		errResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+		assert.Equal(t, "Token is not valid for this tenant", errResp.Error)
+	})
+}
+
+func TestTenantAPIKeys(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+	shopA := client.ForTenant("shop-a")
+	user := e2e.CreateTestUser(t, client)
+	key := e2e.CreateTestAPIKey(t, shopA, user.Id, api.ProductsRead)
+
+	t.Run("should bind requests to the tenant of the key", func(t *testing.T) {
+		env.CleanupProducts(t)
+		productID := e2e.CreateTestProduct(t, env, shopA)
+
+		resp := client.WithAPIKey(key.Key).Get("/api/v1/products")
+		assertions.AssertStatusCode(resp, http.StatusOK)
+		products := e2e.ParseJSON[[]api.Product](t, resp)
+		require.Len(t, products, 1)
+		assert.Equal(t, productID, products[0].Id)
+	})
+
+	t.Run("should reject key of another tenant", func(t *testing.T) {
+		resp := client.WithAPIKey(key.Key).ForTenant("shop-b").Get("/api/v1/products")
+		defer resp.Body.Close()
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		errResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+		assert.Equal(t, "API key is not valid for this tenant", errResp.Error)
+	})
+}
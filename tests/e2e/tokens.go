@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"product_review_hub/internal/auth"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Token returns an HS256 token for the test server with the given subject and roles.
+func Token(t *testing.T, subject string, roles ...string) string {
+	t.Helper()
+	return SignToken(t, []byte(TestJWTSecret), auth.Claims{
+		Subject:   subject,
+		Roles:     roles,
+		IssuedAt:  time.Now().Unix(),
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+// TenantToken returns an HS256 token for the test server that is only valid for the given tenant.
+func TenantToken(t *testing.T, tenantID, subject string, roles ...string) string {
+	t.Helper()
+	return SignToken(t, []byte(TestJWTSecret), auth.Claims{
+		Subject:   subject,
+		Roles:     roles,
+		Tenant:    tenantID,
+		IssuedAt:  time.Now().Unix(),
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+// SignToken signs the claims as an HS256 token with the given secret.
+func SignToken(t *testing.T, secret []byte, claims auth.Claims) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	require.NoError(t, err, "Failed to marshal token header")
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err, "Failed to marshal token claims")
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// UploadFile is a file part of a multipart request.
+type UploadFile struct {
+	Field    string
+	FileName string
+	Data     []byte
+}
+
+// MultipartBody builds a multipart/form-data body from form fields and files
+// and returns it with its Content-Type header value.
+func MultipartBody(t *testing.T, fields map[string]string, files ...UploadFile) ([]byte, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value), "Failed to write form field")
+	}
+
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.FileName)
+		require.NoError(t, err, "Failed to create form file")
+		_, err = part.Write(file.Data)
+		require.NoError(t, err, "Failed to write form file")
+	}
+
+	require.NoError(t, writer.Close(), "Failed to close multipart writer")
+
+	return body.Bytes(), writer.FormDataContentType()
+}
+
+// TestPNG returns a PNG image of the given size filled with a gradient.
+func TestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img), "Failed to encode test image")
+
+	return buf.Bytes()
+}
+
+// TestJPEGWithExif returns a JPEG image of the given size with an EXIF segment holding the
+// orientation and a camera model, as phone cameras write it.
+func TestJPEGWithExif(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil), "Failed to encode test image")
+	data := buf.Bytes()
+
+	// Big-endian TIFF header with one IFD holding the orientation and the model (0x0110)
+	model := []byte("TestCam\x00")
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	for _, v := range []any{uint16(42), uint32(8), uint16(2),
+		uint16(0x0112), uint16(3), uint32(1), orientation, uint16(0),
+		uint16(0x0110), uint16(2), uint32(len(model)), uint32(8 + 2 + 2*12 + 4),
+		uint32(0)} {
+		require.NoError(t, binary.Write(&tiff, binary.BigEndian, v))
+	}
+	tiff.Write(model)
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
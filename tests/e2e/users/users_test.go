@@ -0,0 +1,224 @@
+package users_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/auth"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const usersEndpoint = "/api/v1/users"
+
+func reviewsEndpoint(productID string) string {
+	return fmt.Sprintf("/api/v1/products/%s/reviews", productID)
+}
+
+func TestCreateUser(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	fixtures := e2e.NewUserFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should create user with normalized email", func(t *testing.T) {
+			req := fixtures.ValidCreateRequest()
+			email := req.Email
+			req.Email = "  " + strings.ToUpper(email) + " "
+
+			resp := client.Post(usersEndpoint, req)
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			user := e2e.ParseJSON[api.User](t, resp)
+
+			assert.NotEmpty(t, user.Id)
+			assert.Equal(t, email, user.Email)
+			assert.Equal(t, req.FirstName, user.FirstName)
+			assert.Equal(t, req.LastName, user.LastName)
+
+			resp = client.Get(usersEndpoint + "/" + user.Id)
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			fetched := e2e.ParseJSON[map[string]any](t, resp)
+			assert.Equal(t, user.Id, fetched["id"])
+			assert.Equal(t, req.FirstName, fetched["first_name"])
+			assert.NotContains(t, fetched, "email", "email must not be public")
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for invalid email", func(t *testing.T) {
+			req := fixtures.ValidCreateRequest()
+			req.Email = "not-an-email"
+
+			resp := client.Post(usersEndpoint, req)
+			assertions.AssertBadRequestWithMessage(resp, "validation error: email - email must be a valid email address")
+		})
+
+		t.Run("should return 400 for missing first name", func(t *testing.T) {
+			req := fixtures.ValidCreateRequest()
+			req.FirstName = " "
+
+			resp := client.Post(usersEndpoint, req)
+			assertions.AssertBadRequestWithMessage(resp, "first_name")
+		})
+
+		t.Run("should return 409 for duplicate email", func(t *testing.T) {
+			req := fixtures.ValidCreateRequest()
+			resp := client.Post(usersEndpoint, req)
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			resp.Body.Close()
+
+			resp = client.Post(usersEndpoint, req)
+			assertions.AssertConflictWithMessage(resp, "User with this email already exists")
+		})
+	})
+
+	t.Run("Not Found Errors", func(t *testing.T) {
+		t.Run("should return 404 for non-existent user", func(t *testing.T) {
+			resp := client.Get(usersEndpoint + "/999999")
+			assertions.AssertNotFoundWithMessage(resp, "User not found")
+		})
+
+		t.Run("should return 400 for invalid user ID", func(t *testing.T) {
+			resp := client.Get(usersEndpoint + "/invalid")
+			assertions.AssertBadRequestWithMessage(resp, "Invalid user ID")
+		})
+	})
+}
+
+func TestUserReviews(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	reviewFixtures := e2e.NewReviewFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should list reviews of a user across products", func(t *testing.T) {
+		env.CleanupProducts(t)
+
+		user := e2e.CreateTestUser(t, client)
+		firstProductID := e2e.CreateTestProductWithoutCleanup(t, client)
+		secondProductID := e2e.CreateTestProductWithoutCleanup(t, client)
+
+		resp := client.As(user.Id).Post(reviewsEndpoint(firstProductID), reviewFixtures.ValidCreateRequestWithRating(4))
+		first := assertions.AssertReviewCreated(resp, reviewFixtures.ValidCreateRequestWithRating(4), firstProductID)
+		resp = client.As(user.Id).Post(reviewsEndpoint(secondProductID), reviewFixtures.ValidCreateRequestWithRating(2))
+		second := assertions.AssertReviewCreated(resp, reviewFixtures.ValidCreateRequestWithRating(2), secondProductID)
+
+		require.NotNil(t, first.UserId)
+		assert.Equal(t, user.Id, *first.UserId)
+		require.NotNil(t, first.FirstName)
+		assert.Equal(t, user.FirstName, *first.FirstName)
+
+		// Another user's review must not show up
+		e2e.CreateTestReview(t, client, firstProductID)
+
+		resp = client.Get(usersEndpoint + "/" + user.Id + "/reviews")
+		reviews := assertions.AssertReviewsList(resp, 2)
+		assert.Equal(t, second.Id, reviews[0].Id, "Newest review should come first")
+		assert.Equal(t, first.Id, reviews[1].Id)
+
+		resp = client.Get(usersEndpoint + "/" + user.Id + "/reviews?limit=1&offset=1")
+		reviews = assertions.AssertReviewsList(resp, 1)
+		assert.Equal(t, first.Id, reviews[0].Id)
+	})
+
+	t.Run("should return 404 for reviews of non-existent user", func(t *testing.T) {
+		resp := client.Get(usersEndpoint + "/999999/reviews")
+		assertions.AssertNotFoundWithMessage(resp, "User not found")
+	})
+}
+
+func TestReviewAuthorization(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	reviewFixtures := e2e.NewReviewFixtures()
+	assertions := e2e.NewReviewAssertions(t)
+
+	t.Run("should return 401 without token", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+
+		resp := client.Anonymous().Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusUnauthorized)
+		errorResp := e2e.ParseJSON[api.ErrorResponse](t, resp)
+		assert.Equal(t, "Authentication required", errorResp.Error)
+	})
+
+	t.Run("should return 401 when token subject is not a user", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+
+		resp := client.As("999999").Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 409 when user reviews the same product twice", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+		author := client.AsNewUser()
+
+		resp := author.Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequest())
+		assertions.AssertStatusCode(resp, http.StatusCreated)
+		resp.Body.Close()
+
+		resp = author.Post(reviewsEndpoint(productID), reviewFixtures.ValidCreateRequestWithRating(1))
+		assertions.AssertConflictWithMessage(resp, "You have already reviewed this product")
+	})
+
+	t.Run("should return 403 when another user modifies a review", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+		review := e2e.CreateTestReview(t, client, productID)
+		other := client.AsNewUser()
+		reviewPath := reviewsEndpoint(productID) + "/" + review.Id
+
+		resp := other.Put(reviewPath, reviewFixtures.ValidUpdateRequest())
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = other.Delete(reviewPath)
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = client.Anonymous().Put(reviewPath, reviewFixtures.ValidUpdateRequest())
+		assertions.AssertStatusCode(resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("should let admin update and delete any review", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+		review := e2e.CreateTestReview(t, client, productID)
+		reviewPath := reviewsEndpoint(productID) + "/" + review.Id
+
+		updateReq := reviewFixtures.ValidUpdateRequest()
+		resp := client.Put(reviewPath, updateReq)
+		assertions.AssertReviewUpdated(resp, updateReq, productID, review.Id)
+
+		resp = client.Delete(reviewPath)
+		assertions.AssertNoContent(resp)
+		resp.Body.Close()
+	})
+
+	t.Run("should let moderator delete but not update any review", func(t *testing.T) {
+		productID := e2e.CreateTestProduct(t, env, client)
+		review := e2e.CreateTestReview(t, client, productID)
+		moderator := client.AsRoles("moderator", auth.RoleModerator)
+		reviewPath := reviewsEndpoint(productID) + "/" + review.Id
+
+		resp := moderator.Put(reviewPath, reviewFixtures.ValidUpdateRequest())
+		assertions.AssertStatusCode(resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = moderator.Delete(reviewPath)
+		assertions.AssertNoContent(resp)
+		resp.Body.Close()
+	})
+}
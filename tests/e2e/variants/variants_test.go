@@ -0,0 +1,234 @@
+package variants_test
+
+import (
+	"net/http"
+	"testing"
+
+	"product_review_hub/internal/api"
+	"product_review_hub/internal/money"
+	"product_review_hub/tests/e2e"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func variantsEndpoint(productID string) string {
+	return "/api/v1/products/" + productID + "/variants"
+}
+
+func TestCreateProductVariant(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should create variant with defaults", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			options := api.VariantOptions{"size": "M", "color": "red"}
+			price := money.MustParse("24.99")
+
+			resp := client.Post(variantsEndpoint(productID), api.ProductVariantCreate{
+				Sku:     "TSHIRT-RED-M",
+				Options: &options,
+				Price:   &price,
+			})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			variant := e2e.ParseJSON[api.ProductVariant](t, resp)
+
+			assert.NotEmpty(t, variant.Id)
+			assert.Equal(t, productID, variant.ProductId)
+			assert.Equal(t, "TSHIRT-RED-M", variant.Sku)
+			assert.Equal(t, options, variant.Options)
+			require.NotNil(t, variant.Price)
+			assert.Equal(t, price, *variant.Price)
+			assert.True(t, variant.Active)
+		})
+
+		t.Run("should embed variants in product response", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			small := e2e.CreateTestVariant(t, client, productID, "TSHIRT-S")
+			medium := e2e.CreateTestVariant(t, client, productID, "TSHIRT-M")
+
+			product := assertions.AssertProductByID(client.Get("/api/v1/products/"+productID), productID)
+			require.Len(t, product.Variants, 2)
+			assert.Equal(t, small.Id, product.Variants[0].Id)
+			assert.Equal(t, medium.Id, product.Variants[1].Id)
+
+			products := assertions.AssertProductsListExact(client.Get("/api/v1/products"), 1)
+			assert.Len(t, products[0].Variants, 2)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for empty SKU", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+
+			resp := client.Post(variantsEndpoint(productID), api.ProductVariantCreate{Sku: " "})
+			assertions.AssertBadRequestWithMessage(resp, "sku is required")
+		})
+
+		t.Run("should return 400 for non-positive price", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			price := money.Amount(0)
+
+			resp := client.Post(variantsEndpoint(productID), api.ProductVariantCreate{Sku: "SKU-1", Price: &price})
+			assertions.AssertBadRequestWithMessage(resp, "price must be greater than 0")
+		})
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Run("should return 409 for duplicate SKU", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			e2e.CreateTestVariant(t, client, productID, "TSHIRT-M")
+
+			resp := client.Post(variantsEndpoint(productID), api.ProductVariantCreate{Sku: "TSHIRT-M"})
+			assertions.AssertConflictWithMessage(resp, "Variant with this SKU already exists")
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for non-existing product", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			resp := client.Post(variantsEndpoint("999999"), api.ProductVariantCreate{Sku: "SKU-1"})
+			assertions.AssertNotFoundWithMessage(resp, "Product not found")
+		})
+	})
+}
+
+func TestUpdateProductVariant(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should replace variant fields", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			variant := e2e.CreateTestVariant(t, client, productID, "TSHIRT-M")
+			options := api.VariantOptions{"size": "L"}
+
+			resp := client.Put(variantsEndpoint(productID)+"/"+variant.Id, api.ProductVariantUpdate{
+				Sku:     "TSHIRT-L",
+				Options: &options,
+				Active:  false,
+			})
+			assertions.AssertStatusCode(resp, http.StatusOK)
+			updated := e2e.ParseJSON[api.ProductVariant](t, resp)
+
+			assert.Equal(t, "TSHIRT-L", updated.Sku)
+			assert.Equal(t, options, updated.Options)
+			assert.Nil(t, updated.Price)
+			assert.False(t, updated.Active)
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Run("should return 404 for variant of another product", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			otherProductID := e2e.CreateTestProductWithoutCleanup(t, client)
+			variant := e2e.CreateTestVariant(t, client, otherProductID, "OTHER-M")
+
+			resp := client.Put(variantsEndpoint(productID)+"/"+variant.Id, api.ProductVariantUpdate{Sku: "SKU", Active: true})
+			assertions.AssertNotFoundWithMessage(resp, "Variant not found")
+		})
+	})
+}
+
+func TestDeleteProductVariant(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should delete variant without reviews", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			variant := e2e.CreateTestVariant(t, client, productID, "TSHIRT-M")
+
+			assertions.AssertNoContent(client.Delete(variantsEndpoint(productID) + "/" + variant.Id))
+
+			resp := client.Get(variantsEndpoint(productID) + "/" + variant.Id)
+			assertions.AssertNotFoundWithMessage(resp, "Variant not found")
+		})
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Run("should return 409 when variant has reviews", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			variant := e2e.CreateTestVariant(t, client, productID, "TSHIRT-M")
+
+			resp := client.AsNewUser().Post("/api/v1/products/"+productID+"/reviews", api.ReviewCreate{Rating: 5, VariantId: &variant.Id})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			resp.Body.Close()
+
+			resp = client.Delete(variantsEndpoint(productID) + "/" + variant.Id)
+			assertions.AssertConflictWithMessage(resp, "Cannot delete variant with existing reviews")
+		})
+	})
+}
+
+func TestVariantReviews(t *testing.T) {
+	env := e2e.Setup(t)
+	defer env.Teardown(t)
+
+	client := e2e.NewHTTPClient(t, env)
+	assertions := e2e.NewReviewAssertions(t)
+	productAssertions := e2e.NewProductAssertions(t)
+
+	t.Run("Success", func(t *testing.T) {
+		t.Run("should aggregate variant reviews into product rating", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			variant := e2e.CreateTestVariant(t, client, productID, "TSHIRT-M")
+
+			resp := client.AsNewUser().Post("/api/v1/products/"+productID+"/reviews", api.ReviewCreate{Rating: 2, VariantId: &variant.Id})
+			assertions.AssertStatusCode(resp, http.StatusCreated)
+			review := e2e.ParseJSON[api.Review](t, resp)
+			require.NotNil(t, review.VariantId)
+			assert.Equal(t, variant.Id, *review.VariantId)
+
+			e2e.CreateTestReviewWithRating(t, client, productID, 4)
+
+			product := productAssertions.AssertProductByID(client.Get("/api/v1/products/"+productID), productID)
+			require.NotNil(t, product.AverageRating)
+			assert.InDelta(t, 3.0, *product.AverageRating, 0.01)
+		})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Run("should return 400 for variant of another product", func(t *testing.T) {
+			env.CleanupProducts(t)
+
+			productID := e2e.CreateTestProduct(t, env, client)
+			otherProductID := e2e.CreateTestProductWithoutCleanup(t, client)
+			variant := e2e.CreateTestVariant(t, client, otherProductID, "OTHER-M")
+
+			resp := client.AsNewUser().Post("/api/v1/products/"+productID+"/reviews", api.ReviewCreate{Rating: 5, VariantId: &variant.Id})
+			assertions.AssertBadRequestWithMessage(resp, "variant does not exist for this product")
+		})
+	})
+}